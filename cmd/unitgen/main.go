@@ -0,0 +1,189 @@
+// Command unitgen converts a JSON or YAML unit System definition (see unit.LoadSystem)
+// into Go source that registers the same units, so programs can embed a System without
+// loading files at runtime. The format follows the file extension unless -format is set.
+//
+// Usage:
+//
+//	//go:generate go run github.com/armourstill/str2quantity/cmd/unitgen -in units.json -out units_gen.go -pkg mypkg
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func main() {
+	in := flag.String("in", "", "input definition file")
+	formatName := flag.String("format", "", "definition format, json or yaml (default: from the -in extension)")
+	out := flag.String("out", "", "output Go file (default: stdout)")
+	pkg := flag.String("pkg", "", "package name of the generated file")
+	varName := flag.String("var", "System", "name of the generated *unit.System variable")
+	flag.Parse()
+
+	if *in == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	def, err := readDefinition(*in, *formatName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(def, filepath.Base(*in), *pkg, *varName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readDefinition decodes the definition file at path in format, or in the format named
+// by its extension (".json", ".yaml", ".yml") if format is empty.
+func readDefinition(path, format string) (*unit.Definition, error) {
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return unit.DecodeDefinitionFormat(f, format)
+}
+
+// generate renders Go source registering the definition's units into a package-level System.
+// The definition is built once first, so invalid tables fail at generation time.
+func generate(def *unit.Definition, source, pkg, varName string) ([]byte, error) {
+	if _, err := def.Build(); err != nil {
+		return nil, fmt.Errorf("invalid definition: %w", err)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by unitgen from %s; DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	fmt.Fprintf(&b, "import \"github.com/armourstill/str2quantity/unit\"\n\n")
+	fmt.Fprintf(&b, "// %s is the unit system generated from %s.\n", varName, source)
+	fmt.Fprintf(&b, "var %s = new%s()\n\n", varName, exportName(varName))
+	fmt.Fprintf(&b, "func new%s() *unit.System {\n", exportName(varName))
+
+	fmt.Fprintf(&b, "sys := unit.NewSystem(unit.SystemConfig{\n")
+	for _, field := range configFields(def.Config) {
+		fmt.Fprintf(&b, "%s,\n", field)
+	}
+	fmt.Fprintf(&b, "})\n\n")
+
+	for _, u := range def.Units {
+		fmt.Fprintf(&b, "sys.Add(%s, %s, %s", strconv.Quote(u.Symbol), formatFloat(u.Scale), dimensionLiteral(u.Dimension))
+		if u.CaseSensitive {
+			fmt.Fprintf(&b, ", unit.WithCaseSensitive()")
+		}
+		if u.CaseInsensitive {
+			fmt.Fprintf(&b, ", unit.WithCaseInsensitive()")
+		}
+		fmt.Fprintf(&b, ")\n")
+		// Aliases follow their unit, in the order Build registers them, so every
+		// registration below was validated by Build above.
+		for _, alias := range u.Aliases {
+			fmt.Fprintf(&b, "mustRegister(sys.AddAlias(%s, %s))\n", strconv.Quote(alias), strconv.Quote(u.Symbol))
+		}
+	}
+
+	for _, p := range def.Prefixes {
+		fmt.Fprintf(&b, "mustRegister(sys.AddPrefix(%s, %s", strconv.Quote(p.Symbol), formatFloat(p.Scale))
+		for _, u := range p.Units {
+			fmt.Fprintf(&b, ", %s", strconv.Quote(u))
+		}
+		fmt.Fprintf(&b, "))\n")
+	}
+	for _, symbol := range def.Display {
+		fmt.Fprintf(&b, "mustRegister(sys.SetDisplaySymbol(%s))\n", strconv.Quote(symbol))
+	}
+
+	fmt.Fprintf(&b, "\nreturn sys\n}\n\n")
+	fmt.Fprintf(&b, "func mustRegister(err error) {\nif err != nil {\npanic(err)\n}\n}\n")
+
+	return format.Source(b.Bytes())
+}
+
+// configFields renders the set fields of c as "Name: value" entries of a unit.SystemConfig
+// literal. ConfigDefinition mirrors the SystemConfig field names; policies are spelled as
+// their constant names and separator runes as one-character strings.
+func configFields(c unit.ConfigDefinition) []string {
+	return literalFields(reflect.ValueOf(c))
+}
+
+// literalFields renders the set fields of the definition struct v (see configFields).
+// The Format definition renders as a unit.FormatConfig literal.
+func literalFields(v reflect.Value) []string {
+	var fields []string
+	for i := 0; i < v.NumField(); i++ {
+		name, f := v.Type().Field(i).Name, v.Field(i)
+		if f.IsZero() {
+			continue
+		}
+		var value string
+		switch {
+		case f.Kind() == reflect.Pointer:
+			value = "unit.FormatConfig{" + strings.Join(literalFields(f.Elem()), ", ") + "}"
+		case strings.HasSuffix(name, "Policy"):
+			value = "unit." + f.String()
+		case name == "DigitGroupSeparator" || name == "DecimalSeparator":
+			r, _ := utf8.DecodeRuneInString(f.String())
+			value = strconv.QuoteRune(r)
+		case f.Kind() == reflect.Float64:
+			value = formatFloat(f.Float())
+		default:
+			value = fmt.Sprintf("%#v", f.Interface())
+		}
+		fields = append(fields, name+": "+value)
+	}
+	return fields
+}
+
+// dimensionLiteral renders a unit.Dimension composite literal with only its non-zero fields.
+func dimensionLiteral(d unit.Dimension) string {
+	var fields []string
+	for _, f := range []struct {
+		name string
+		exp  int
+	}{{"L", d.L}, {"M", d.M}, {"T", d.T}, {"I", d.I}, {"K", d.K}, {"N", d.N}, {"J", d.J}} {
+		if f.exp != 0 {
+			fields = append(fields, fmt.Sprintf("%s: %d", f.name, f.exp))
+		}
+	}
+	if d.Extra != "" {
+		fields = append(fields, "Extra: "+strconv.Quote(d.Extra))
+	}
+	return "unit.Dimension{" + strings.Join(fields, ", ") + "}"
+}
+
+// formatFloat renders a float64 as a Go literal that round-trips exactly.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// exportName upper-cases the first letter of a variable name for the constructor name.
+func exportName(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func TestGenerate(t *testing.T) {
+	const src = `{
+		"config": {"allowMultiPart": true, "separators": ", ", "negativePolicy": "RejectNegative", "decimalSeparator": ",", "epsilon": 1e-9,
+			"format": {"fixedPrecision": true, "prefixPolicy": "PrefixBinary", "unitSeparator": " "}},
+		"units": [
+			{"symbol": "B", "scale": 8, "dimension": {"Extra": "storage"}, "caseSensitive": true, "aliases": ["Byte"]},
+			{"symbol": "m/s", "scale": 1, "dimension": {"L": 1, "T": -1}}
+		],
+		"prefixes": [{"symbol": "Ki", "scale": 1024, "units": ["B"]}],
+		"display": ["Byte"]
+	}`
+	def, err := unit.DecodeDefinition(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("DecodeDefinition failed: %v", err)
+	}
+
+	out, err := generate(def, "units.json", "units", "System")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	got := string(out)
+	for _, want := range []string{
+		"// Code generated by unitgen from units.json; DO NOT EDIT.",
+		"package units",
+		"var System = newSystem()",
+		`Separators:       ", ",`,
+		`NegativePolicy:   unit.RejectNegative,`,
+		`DecimalSeparator: ',',`,
+		`Epsilon:          1e-09,`,
+		`Format:           unit.FormatConfig{FixedPrecision: true, PrefixPolicy: unit.PrefixBinary, UnitSeparator: " "},`,
+		`sys.Add("B", 8, unit.Dimension{Extra: "storage"}, unit.WithCaseSensitive())`,
+		`sys.Add("m/s", 1, unit.Dimension{L: 1, T: -1})`,
+		`mustRegister(sys.AddPrefix("Ki", 1024, "B"))`,
+		`mustRegister(sys.AddAlias("Byte", "B"))`,
+		`mustRegister(sys.SetDisplaySymbol("Byte"))`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("generated source missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestGenerate_InvalidDefinition(t *testing.T) {
+	def := &unit.Definition{
+		Prefixes: []unit.PrefixDefinition{{Symbol: "k", Scale: 1000, Units: []string{"m"}}},
+	}
+	if _, err := generate(def, "units.json", "units", "System"); err == nil {
+		t.Error("generate with unbound prefix expected error, got nil")
+	}
+
+	// A unit reusing an earlier alias would panic in the generated code.
+	def = &unit.Definition{Units: []unit.UnitDefinition{
+		{Symbol: "B", Scale: 8, Dimension: unit.DimStorage, Aliases: []string{"Byte"}},
+		{Symbol: "Byte", Scale: 8, Dimension: unit.DimStorage},
+	}}
+	if _, err := generate(def, "units.json", "units", "System"); err == nil {
+		t.Error("generate with a unit named like an alias expected error, got nil")
+	}
+}
+
+func TestReadDefinition(t *testing.T) {
+	def, err := readDefinition("testdata/units.yaml", "")
+	if err != nil {
+		t.Fatalf("readDefinition failed: %v", err)
+	}
+	out, err := generate(def, "units.yaml", "units", "System")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	for _, want := range []string{
+		"AllowMultiPart: true",
+		`sys.Add("B", 8, unit.Dimension{Extra: "storage"}, unit.WithCaseSensitive())`,
+		`mustRegister(sys.AddPrefix("Ki", 1024, "B"))`,
+		`mustRegister(sys.AddAlias("Byte", "B"))`,
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("generated source missing %q:\n%s", want, out)
+		}
+	}
+
+	// An explicit format overrides the extension.
+	if _, err := readDefinition("testdata/units.yaml", "json"); err == nil {
+		t.Error("readDefinition(units.yaml, json) expected error, got nil")
+	}
+}
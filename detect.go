@@ -0,0 +1,59 @@
+package str2quantity
+
+import (
+	"fmt"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/std/length"
+	"github.com/armourstill/str2quantity/std/storage"
+	stdtime "github.com/armourstill/str2quantity/std/time"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// Kind names the family of a detected quantity.
+type Kind string
+
+// Known quantity kinds.
+const (
+	KindDuration Kind = "duration"
+	KindStorage  Kind = "storage"
+	KindLength   Kind = "length"
+)
+
+// Quantity is a parsed value expressed in the base unit of its Kind's System
+// (nanoseconds for durations, bits for storage, meters for lengths).
+type Quantity struct {
+	Kind      Kind
+	Value     float64
+	Dimension unit.Dimension
+}
+
+// detector is a std System with its kind and the unit ladder Format picks from.
+type detector struct {
+	kind  Kind
+	sys   *unit.System
+	dim   unit.Dimension
+	units []string
+}
+
+// detectors lists the std Systems tried by Detect, in priority order.
+// Durations come first, so "5m" is five minutes rather than five meters.
+var detectors = []detector{
+	{KindDuration, stdtime.System, unit.DimTime, []string{"h", "m", "s", "ms", "µs", "ns"}},
+	{KindStorage, storage.System, unit.DimStorage, []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}},
+	{KindLength, length.System, unit.DimLength, []string{"km", "m", "cm", "mm", "µm", "nm"}},
+}
+
+// Detect parses s with each std System in priority order (duration, storage, length)
+// and returns the first successful result with its detected kind and dimension.
+// It suits generic config fields such as "limit: 5m" where the unit decides the meaning.
+func Detect(s string) (Quantity, unit.Dimension, error) {
+	for _, d := range detectors {
+		val, dim, err := parser.Parse[float64](s, d.sys)
+		if err != nil || dim.Equals(unit.DimDimensionless) {
+			continue
+		}
+		return Quantity{Kind: d.kind, Value: val, Dimension: dim}, dim, nil
+	}
+	return Quantity{}, unit.Dimension{}, fmt.Errorf("cannot detect quantity kind of %q", s)
+}
@@ -0,0 +1,44 @@
+package str2quantity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantKind Kind
+		wantVal  float64
+		wantDim  unit.Dimension
+	}{
+		{"5m", KindDuration, 5 * 60e9, unit.DimTime}, // Duration wins over length
+		{"1h30m", KindDuration, 5400e9, unit.DimTime},
+		{"1.5GB", KindStorage, 1.5 * 8 * (1 << 30), unit.DimStorage},
+		{"8b", KindStorage, 8, unit.DimStorage},
+		{"1km", KindLength, 1000, unit.DimLength},
+		{"25cm", KindLength, 0.25, unit.DimLength},
+	}
+
+	for _, tt := range tests {
+		q, dim, err := Detect(tt.input)
+		if err != nil {
+			t.Errorf("Detect(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if q.Kind != tt.wantKind || !dim.Equals(tt.wantDim) || !q.Dimension.Equals(tt.wantDim) {
+			t.Errorf("Detect(%q) = %s (%s), want %s (%s)", tt.input, q.Kind, dim, tt.wantKind, tt.wantDim)
+		}
+		if math.Abs(q.Value-tt.wantVal) > 1e-9 {
+			t.Errorf("Detect(%q) value = %g, want %g", tt.input, q.Value, tt.wantVal)
+		}
+	}
+
+	for _, input := range []string{"", "10", "5kg", "hello"} {
+		if _, _, err := Detect(input); err == nil {
+			t.Errorf("Detect(%q) expected error, got nil", input)
+		}
+	}
+}
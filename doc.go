@@ -0,0 +1,3 @@
+// Package str2quantity provides entry points spanning the standard unit systems,
+// such as detecting which kind of quantity a string holds.
+package str2quantity
@@ -0,0 +1,2 @@
+// Package httpparam provides helpers for parsing quantity-valued HTTP query parameters and headers.
+package httpparam
@@ -0,0 +1,84 @@
+package httpparam
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// ErrMissing is reported (wrapped in *Error) when a parameter or header is absent or empty.
+var ErrMissing = errors.New("missing value")
+
+// Error describes a quantity that could not be read from a request.
+// It is meant to be reported back to the client as a 400 Bad Request.
+type Error struct {
+	Source string // "query" or "header"
+	Name   string // Parameter or header name
+	Value  string // Raw value as received
+	Err    error  // Underlying parse error, or ErrMissing
+}
+
+func (e *Error) Error() string {
+	if errors.Is(e.Err, ErrMissing) {
+		return fmt.Sprintf("%s %s: %v", e.Source, e.Name, e.Err)
+	}
+	return fmt.Sprintf("%s %s=%q: %v", e.Source, e.Name, e.Value, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the HTTP status code to answer with (always 400).
+func (e *Error) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// Query parses the named query parameter (e.g. "?maxSize=10GiB") with the given System.
+func Query[N parser.Number](r *http.Request, name string, sys *unit.System) (N, error) {
+	return parse[N]("query", name, r.URL.Query().Get(name), sys)
+}
+
+// Header parses the named header (e.g. "X-Rate-Window: 5m") with the given System.
+func Header[N parser.Number](h http.Header, name string, sys *unit.System) (N, error) {
+	return parse[N]("header", name, h.Get(name), sys)
+}
+
+// parse parses a raw value and wraps failures into *Error.
+func parse[N parser.Number](source, name, raw string, sys *unit.System) (N, error) {
+	if strings.TrimSpace(raw) == "" {
+		return 0, &Error{Source: source, Name: name, Err: ErrMissing}
+	}
+	val, _, err := parser.Parse[N](raw, sys)
+	if err != nil {
+		return 0, &Error{Source: source, Name: name, Value: raw, Err: err}
+	}
+	return val, nil
+}
+
+// RetryAfter reads the Retry-After header, which holds either delay-seconds or an HTTP-date.
+// Dates in the past yield a zero delay.
+func RetryAfter(h http.Header) (time.Duration, error) {
+	raw := strings.TrimSpace(h.Get("Retry-After"))
+	if raw == "" {
+		return 0, &Error{Source: "header", Name: "Retry-After", Err: ErrMissing}
+	}
+
+	if secs, err := strconv.ParseUint(raw, 10, 32); err == nil {
+		return time.Duration(secs) * time.Second, nil
+	}
+	if t, err := http.ParseTime(raw); err == nil {
+		return max(time.Until(t), 0), nil
+	}
+	return 0, &Error{
+		Source: "header", Name: "Retry-After", Value: raw,
+		Err: errors.New("expected delay-seconds or HTTP-date"),
+	}
+}
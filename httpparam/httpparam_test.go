@@ -0,0 +1,96 @@
+package httpparam
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func createTestSystem() *unit.System {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true})
+	sys.Add("B", 1, unit.DimStorage)
+	sys.AddPrefix("Gi", 1<<30, "B")
+	sys.Add("s", 1, unit.DimTime)
+	sys.Add("m", 60, unit.DimTime)
+	return sys
+}
+
+func TestQuery(t *testing.T) {
+	sys := createTestSystem()
+	r := httptest.NewRequest(http.MethodGet, "/upload?maxSize=10GiB&bad=10x&empty=", nil)
+
+	got, err := Query[int64](r, "maxSize", sys)
+	if err != nil {
+		t.Fatalf("Query(maxSize) unexpected error: %v", err)
+	}
+	if got != 10<<30 {
+		t.Errorf("Query(maxSize) = %d, want %d", got, int64(10<<30))
+	}
+
+	for _, name := range []string{"bad", "empty", "absent"} {
+		_, err := Query[int64](r, name, sys)
+		var qErr *Error
+		if !errors.As(err, &qErr) {
+			t.Errorf("Query(%s) error = %v, want *Error", name, err)
+			continue
+		}
+		if qErr.StatusCode() != http.StatusBadRequest {
+			t.Errorf("Query(%s) status = %d, want 400", name, qErr.StatusCode())
+		}
+		if wantMissing := name != "bad"; errors.Is(err, ErrMissing) != wantMissing {
+			t.Errorf("Query(%s) errors.Is(ErrMissing) = %v, want %v", name, !wantMissing, wantMissing)
+		}
+	}
+}
+
+func TestHeader(t *testing.T) {
+	sys := createTestSystem()
+	h := http.Header{}
+	h.Set("X-Rate-Window", "5m")
+
+	got, err := Header[float64](h, "X-Rate-Window", sys)
+	if err != nil {
+		t.Fatalf("Header unexpected error: %v", err)
+	}
+	if got != 300 {
+		t.Errorf("Header(X-Rate-Window) = %g, want 300", got)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"120", 2 * time.Minute, false},
+		{"0", 0, false},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 0, false}, // Past date
+		{"", 0, true},
+		{"-1", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		h := http.Header{}
+		h.Set("Retry-After", tt.value)
+		got, err := RetryAfter(h)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("RetryAfter(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("RetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	h := http.Header{}
+	h.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	if got, err := RetryAfter(h); err != nil || got <= 58*time.Minute || got > time.Hour {
+		t.Errorf("RetryAfter(future date) = %v, %v; want ~1h", got, err)
+	}
+}
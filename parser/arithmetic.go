@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// Arithmetic is a numeric backend for ParseWith, so that values can be accumulated
+// in a decimal or arbitrary precision type instead of binary floats.
+type Arithmetic[T any] interface {
+	// Parse converts a number as written in the input: a signed decimal ("-1.5", "2e3")
+	// or, for fractions and mixed numbers, an exact "num/den" ("3/2").
+	Parse(tok string) (T, error)
+	// MulScale returns v times a registered unit or prefix scale.
+	MulScale(v T, scale float64) (T, error)
+	// Add returns a + b.
+	Add(a, b T) T
+	// Cmp compares a and b like cmp.Compare, for callers checking parsed values against limits.
+	Cmp(a, b T) int
+}
+
+// ArithmeticFuncs adapts a decimal library to Arithmetic, e.g. for shopspring/decimal:
+//
+//	dec := parser.ArithmeticFuncs[decimal.Decimal]{
+//		ParseFunc:    parseDecimal, // decimal.NewFromString, splitting "num/den"
+//		MulScaleFunc: func(v decimal.Decimal, f float64) (decimal.Decimal, error) { return v.Mul(decimal.NewFromFloat(f)), nil },
+//		AddFunc:      decimal.Decimal.Add,
+//		CmpFunc:      decimal.Decimal.Cmp,
+//	}
+type ArithmeticFuncs[T any] struct {
+	ParseFunc    func(tok string) (T, error)
+	MulScaleFunc func(v T, scale float64) (T, error)
+	AddFunc      func(a, b T) T
+	CmpFunc      func(a, b T) int
+}
+
+func (a ArithmeticFuncs[T]) Parse(tok string) (T, error)            { return a.ParseFunc(tok) }
+func (a ArithmeticFuncs[T]) MulScale(v T, scale float64) (T, error) { return a.MulScaleFunc(v, scale) }
+func (a ArithmeticFuncs[T]) Add(x, y T) T                           { return a.AddFunc(x, y) }
+func (a ArithmeticFuncs[T]) Cmp(x, y T) int                         { return a.CmpFunc(x, y) }
+
+// Float64Arithmetic is the binary floating point Arithmetic that Parse[float64] uses.
+type Float64Arithmetic struct{}
+
+func (Float64Arithmetic) Parse(tok string) (float64, error) {
+	if num, den, ok := strings.Cut(tok, "/"); ok {
+		n, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return 0, err
+		}
+		d, err := strconv.ParseFloat(den, 64)
+		if err != nil {
+			return 0, err
+		}
+		return n / d, nil
+	}
+	return strconv.ParseFloat(tok, 64)
+}
+
+func (Float64Arithmetic) MulScale(v, scale float64) (float64, error) { return v * scale, nil }
+func (Float64Arithmetic) Add(a, b float64) float64                   { return a + b }
+func (Float64Arithmetic) Cmp(a, b float64) int                       { return cmp.Compare(a, b) }
+
+// ParseWith parses like Parse but computes every part value (number times prefix and
+// unit scale) and the total with arith. Syntax, units, signs and policies are checked
+// exactly as in Parse.
+func ParseWith[T any](s string, sys *unit.System, arith Arithmetic[T], opts ...ParseOption) (T, unit.Dimension, error) {
+	var total T
+	x := parseExtras{exact: true}
+	_, dim, err := parse[float64](s, sys, opts, &x)
+	if err != nil {
+		return total, dim, err
+	}
+	for i, p := range x.numbers {
+		v, err := arith.Parse(p.tok)
+		if err == nil {
+			v, err = arith.MulScale(v, p.prefixScale)
+		}
+		if err == nil {
+			v, err = arith.MulScale(v, p.unitScale)
+		}
+		if err == nil && p.offset != 0 {
+			var off T
+			if off, err = arith.Parse(strconv.FormatFloat(p.offset, 'g', -1, 64)); err == nil {
+				v = arith.Add(v, off)
+			}
+		}
+		if err != nil {
+			return total, dim, fmt.Errorf("part %d (%s): %w", i+1, p.tok, err)
+		}
+		if i == 0 {
+			total = v
+		} else {
+			total = arith.Add(total, v)
+		}
+	}
+	return total, dim, nil
+}
+
+// numberPart is a part as recorded for ParseWith: the exact number, its scales and
+// the offset of an nonLinear unit.
+type numberPart struct {
+	tok                    string
+	prefixScale, unitScale float64
+	offset                 float64
+}
+
+// exactToken returns the number at the beginning of s in the form Arithmetic.Parse
+// receives, with the sign set by neg (the sign after the sign and negative policies).
+func exactToken(s string, sys *unit.System, cfg *unit.SystemConfig, neg bool) (string, error) {
+	var tok string
+	if r, _, ok, err := scanExact(s, cfg); err != nil {
+		return "", err
+	} else if ok {
+		tok = strconv.FormatUint(r.num, 10)
+		if r.den != 1 {
+			tok += "/" + strconv.FormatUint(r.den, 10)
+		}
+	} else if tok, _, err = scanNumber(s, sys, cfg); err != nil {
+		return "", err
+	}
+	tok = strings.TrimLeft(tok, "+-")
+	if neg {
+		tok = "-" + tok
+	}
+	return tok, nil
+}
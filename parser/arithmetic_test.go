@@ -0,0 +1,65 @@
+package parser_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func newArithmeticSystem() *unit.System {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true, AllowFractions: true})
+	sys.Add("kg", 1, unit.DimMass)
+	sys.Add("g", 1e-3, unit.DimMass)
+	return sys
+}
+
+func TestParseWithFloat64(t *testing.T) {
+	sys := newArithmeticSystem()
+	for _, input := range []string{"0.1kg 0.2kg", "1kg -250g", "1 1/2 kg", "+3g"} {
+		want, _, err := parser.Parse[float64](input, sys)
+		if err != nil {
+			t.Fatalf("Parse(%q) unexpected error: %v", input, err)
+		}
+		got, _, err := parser.ParseWith[float64](input, sys, parser.Float64Arithmetic{})
+		if err != nil || got != want {
+			t.Errorf("ParseWith(%q, Float64Arithmetic) = %v, %v, want %v", input, got, err, want)
+		}
+	}
+}
+
+func TestParseWithAdapter(t *testing.T) {
+	sys := newArithmeticSystem()
+	rat := parser.RatArithmetic{}
+	adapter := parser.ArithmeticFuncs[*big.Rat]{
+		ParseFunc:    rat.Parse,
+		MulScaleFunc: rat.MulScale,
+		AddFunc:      rat.Add,
+		CmpFunc:      rat.Cmp,
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"0.1kg 0.2kg", "3/10"}, // 0.30000000000000004 in float64
+		{"1kg -250g", "3/4"},
+		{"1 1/3 kg", "4/3"},
+		{"100g", "1/10"},
+	}
+	for _, tt := range tests {
+		got, _, err := parser.ParseWith[*big.Rat](tt.input, sys, adapter)
+		if err != nil {
+			t.Errorf("ParseWith(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if want, _ := new(big.Rat).SetString(tt.want); adapter.Cmp(got, want) != 0 {
+			t.Errorf("ParseWith(%q) = %s, want %s", tt.input, got.RatString(), tt.want)
+		}
+	}
+
+	if _, _, err := parser.ParseWith[*big.Rat]("1kg 5x", sys, adapter); err == nil {
+		t.Errorf("ParseWith(%q) expected error, got nil", "1kg 5x")
+	}
+}
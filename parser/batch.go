@@ -0,0 +1,26 @@
+package parser
+
+import "github.com/armourstill/str2quantity/unit"
+
+// ParseAll parses every input like Parse, e.g. a CSV column. Options are applied once
+// for the whole batch, and a failed input does not stop the others: errs is nil if all
+// inputs parsed, otherwise errs[i] holds the error of inputs[i] (whose value is zero).
+func ParseAll[N Number](inputs []string, sys *unit.System, opts ...ParseOption) (vals []N, errs []error) {
+	if len(opts) > 0 {
+		o := newParseOptions(sys, opts)
+		opts = []ParseOption{func(p *parseOptions) { *p = o }}
+	}
+	vals = make([]N, len(inputs))
+	for i, s := range inputs {
+		v, _, err := parse[N](s, sys, opts, nil)
+		if err != nil {
+			if errs == nil {
+				errs = make([]error, len(inputs))
+			}
+			errs[i] = err
+			continue
+		}
+		vals[i] = v
+	}
+	return vals, errs
+}
@@ -0,0 +1,37 @@
+package parser_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+)
+
+func TestParseAll(t *testing.T) {
+	sys := createTestSystem()
+
+	vals, errs := parser.ParseAll[int64]([]string{"1s", "1m 30s", "2h"}, sys)
+	if errs != nil {
+		t.Fatalf("ParseAll unexpected errors: %v", errs)
+	}
+	if want := []int64{1, 90, 7200}; !slices.Equal(vals, want) {
+		t.Errorf("ParseAll = %v, want %v", vals, want)
+	}
+
+	vals, errs = parser.ParseAll[int64]([]string{"1s", "5xyz", "", "0.5s"}, sys)
+	if want := []int64{1, 0, 0, 0}; !slices.Equal(vals, want) {
+		t.Errorf("ParseAll with errors = %v, want %v", vals, want)
+	}
+	var unknown *parser.UnknownUnitError
+	var precision *parser.PrecisionLossError
+	if len(errs) != 4 || errs[0] != nil || !errors.As(errs[1], &unknown) || errs[2] != nil || !errors.As(errs[3], &precision) {
+		t.Errorf("ParseAll errors = %v, want unknown unit at 1 and precision loss at 3", errs)
+	}
+
+	// Options apply to every input.
+	_, errs = parser.ParseAll[float64]([]string{"1s", "1s 2s"}, sys, parser.WithMaxInputLength(3))
+	if len(errs) != 2 || errs[0] != nil || !errors.Is(errs[1], parser.ErrInputTooLong) {
+		t.Errorf("ParseAll with WithMaxInputLength errors = %v", errs)
+	}
+}
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// ParseBig parses like Parse but accumulates the parts as exact rationals, for values
+// that float64 and int64 cannot hold exactly (e.g. yottabyte-scale bit counts).
+//
+// Numbers are taken exactly as written. Scales are registered as float64: integral
+// scales (e.g. 2^80 for "Yi") are used exactly, fractional ones as the shortest decimal
+// that round-trips (e.g. 1e-3 = 1/1000).
+func ParseBig(s string, sys *unit.System, opts ...ParseOption) (*big.Rat, unit.Dimension, error) {
+	total, dim, err := ParseWith[*big.Rat](s, sys, RatArithmetic{}, opts...)
+	if err != nil {
+		return nil, dim, err
+	}
+	if total == nil {
+		total = new(big.Rat)
+	}
+	return total, dim, nil
+}
+
+// RatArithmetic is the exact Arithmetic behind ParseBig. Its operations allocate
+// new values and never modify their arguments.
+type RatArithmetic struct{}
+
+func (RatArithmetic) Parse(tok string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(tok)
+	if !ok {
+		return nil, ErrInvalidNumber
+	}
+	return r, nil
+}
+
+func (RatArithmetic) MulScale(v *big.Rat, scale float64) (*big.Rat, error) {
+	r, ok := bigScale(scale)
+	if !ok {
+		return nil, fmt.Errorf("scale %g is not finite", scale)
+	}
+	return r.Mul(v, r), nil
+}
+
+func (RatArithmetic) Add(a, b *big.Rat) *big.Rat { return new(big.Rat).Add(a, b) }
+func (RatArithmetic) Cmp(a, b *big.Rat) int      { return a.Cmp(b) }
+
+// bigScale converts a registered scale into the rational it stands for (see ParseBig).
+func bigScale(f float64) (*big.Rat, bool) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, false
+	}
+	if f == math.Trunc(f) {
+		return new(big.Rat).SetFloat64(f), true
+	}
+	return new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+}
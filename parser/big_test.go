@@ -0,0 +1,47 @@
+package parser_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func TestParseBig(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true, AllowFractions: true, SignPolicy: unit.SignLeading})
+	sys.Add("b", 1, unit.DimStorage)
+	sys.Add("B", 8, unit.DimStorage)
+	sys.Add("g", 1, unit.DimMass)
+	sys.AddPrefix("Yi", 1<<80, "b", "B")
+	sys.AddPrefix("k", 1e3, "g")
+	sys.AddPrefix("m", 1e-3, "g")
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"1YiB 1b", "9671406556917033397649409"},                                       // 2^83 + 1, beyond float64 precision
+		{"123456789.123456789YiB", "2332032812157272424433747543184507928576/1953125"}, // exact decimal times 2^83
+		{"0.1kg 0.2kg", "300"},
+		{"1/3 mg", "1/3000"},
+		{"-1kg 1g", "-1001"},
+		{"", "0"},
+	}
+
+	for _, tt := range tests {
+		got, _, err := parser.ParseBig(tt.input, sys)
+		if err != nil {
+			t.Errorf("ParseBig(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		want, _ := new(big.Rat).SetString(tt.want)
+		if got.Cmp(want) != 0 {
+			t.Errorf("ParseBig(%q) = %s, want %s", tt.input, got.RatString(), want.RatString())
+		}
+	}
+
+	if _, _, err := parser.ParseBig("1kg 1b", sys); err == nil {
+		t.Errorf("ParseBig(%q) expected error, got nil", "1kg 1b")
+	}
+}
@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"unsafe"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// intBounds describes the range of an integer type N.
+type intBounds struct {
+	min      int64
+	max      uint64
+	lo, hiEx float64 // min and max+1 as exact float64 powers of two
+}
+
+// boundsOf returns the range of N, or ok=false if N is a floating point type.
+func boundsOf[N Number]() (b intBounds, ok bool) {
+	var zero N
+	half := 0.5
+	if N(half) != 0 {
+		return intBounds{}, false
+	}
+	bits := uint(unsafe.Sizeof(zero)) * 8
+	if minusOne := zero - 1; minusOne < zero {
+		b.min = -1 << (bits - 1)
+		b.max = 1<<(bits-1) - 1
+		b.lo = -float64(uint64(1) << (bits - 1))
+		b.hiEx = float64(uint64(1) << (bits - 1))
+	} else {
+		b.max = 1<<bits - 1
+		b.hiEx = 2 * float64(uint64(1)<<(bits-1))
+	}
+	return b, true
+}
+
+// checkRange reports a *RangeError if v does not fit into the integer type N.
+func checkRange[N Number](v float64) error {
+	b, ok := boundsOf[N]()
+	if !ok || (v >= b.lo && v < b.hiEx) {
+		return nil
+	}
+	var zero N
+	return &RangeError{Value: v, Type: fmt.Sprintf("%T", zero), Min: b.min, Max: b.max}
+}
+
+// addChecked returns total + part, or a *RangeError if the sum overflows the integer type N
+// (e.g. "8EiB 8EiB" into int64, where each part fits but the total does not).
+func addChecked[N Number](total, part N) (N, error) {
+	sum := total + part
+	if (part > 0 && sum < total) || (part < 0 && sum > total) {
+		b, _ := boundsOf[N]()
+		var zero N
+		return sum, &RangeError{Value: float64(total) + float64(part), Type: fmt.Sprintf("%T", zero), Min: b.min, Max: b.max}
+	}
+	return sum, nil
+}
+
+// saturated returns the bound of the integer type N on the side of v, for
+// unit.OverflowSaturate. It must only be called for integer types.
+func saturated[N Number](v float64) N {
+	b, _ := boundsOf[N]()
+	if v < 0 {
+		return N(b.min)
+	}
+	return N(b.max)
+}
+
+// checkFinite reports ErrNotFinite for NaN, and for ±Inf unless cfg.AllowInfinity is set.
+func checkFinite(v float64, cfg *unit.SystemConfig) error {
+	if math.IsNaN(v) || (math.IsInf(v, 0) && !cfg.AllowInfinity) {
+		return fmt.Errorf("%w: %g", ErrNotFinite, v)
+	}
+	return nil
+}
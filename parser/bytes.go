@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"unsafe"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// ParseBytes is Parse for byte slices, e.g. fields of a network buffer. It reads b in
+// place instead of converting it to a string, so a successful parse does not allocate
+// for the input. b must not be modified while ParseBytes runs.
+//
+// The returned error owns its strings, so it stays valid after the buffer is reused.
+// Errors passed to a ParseObserver during the call still refer to b.
+func ParseBytes[N Number](b []byte, sys *unit.System, opts ...ParseOption) (N, unit.Dimension, error) {
+	if len(b) == 0 {
+		return Parse[N]("", sys, opts...)
+	}
+	val, dim, err := Parse[N](unsafe.String(&b[0], len(b)), sys, opts...)
+	if err != nil {
+		return val, dim, ownError(err)
+	}
+	return val, dim, nil
+}
+
+// ownError copies the input substrings retained by the parser's error values, so err
+// no longer refers to the memory of the input. Other errors are returned as is.
+func ownError(err error) error {
+	switch e := err.(type) {
+	case *SyntaxError:
+		return &SyntaxError{Input: strings.Clone(e.Input), Offset: e.Offset, Token: strings.Clone(e.Token), Err: ownError(e.Err)}
+	case *UnknownUnitError:
+		return &UnknownUnitError{Symbol: strings.Clone(e.Symbol)}
+	case *AmbiguousUnitError:
+		a := unit.Ambiguity{Symbol: strings.Clone(e.Ambiguity.Symbol), Readings: make([]unit.Resolution, len(e.Ambiguity.Readings))}
+		for i, r := range e.Ambiguity.Readings {
+			r.Unit.Symbol, r.Alias = strings.Clone(r.Unit.Symbol), strings.Clone(r.Alias)
+			a.Readings[i] = r
+		}
+		return &AmbiguousUnitError{Ambiguity: a}
+	case *ConstraintError:
+		return &ConstraintError{Symbol: strings.Clone(e.Symbol), Value: e.Value, Err: ownError(e.Err)}
+	case interface{ Unwrap() []error }:
+		errs := e.Unwrap()
+		owned := make([]error, len(errs))
+		for i, err := range errs {
+			owned[i] = ownError(err)
+		}
+		return errors.Join(owned...)
+	}
+	return err
+}
@@ -0,0 +1,51 @@
+package parser_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+)
+
+func TestParseBytes(t *testing.T) {
+	sys := createTestSystem()
+
+	buf := []byte("1h 30m")
+	got, dim, err := parser.ParseBytes[int64](buf, sys)
+	want, wantDim, _ := parser.Parse[int64]("1h 30m", sys)
+	if err != nil || got != want || !dim.Equals(wantDim) {
+		t.Errorf("ParseBytes(%q) = %d, %v, %v, want %d", buf, got, dim, err, want)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		parser.ParseBytes[int64](buf, sys)
+	})
+	if allocs != 0 {
+		t.Errorf("ParseBytes(%q) allocates %v times, want 0", buf, allocs)
+	}
+
+	// Errors must not alias the buffer.
+	bad := []byte("5xyz")
+	_, _, err = parser.ParseBytes[int64](bad, sys)
+	copy(bad, "0000")
+	var unknown *parser.UnknownUnitError
+	if !errors.As(err, &unknown) || unknown.Symbol != "xyz" {
+		t.Errorf("ParseBytes error after buffer reuse = %v, want unknown unit xyz", err)
+	}
+	var syntax *parser.SyntaxError
+	if !errors.As(err, &syntax) || syntax.Input != "5xyz" || syntax.Token != "xyz" {
+		t.Errorf("ParseBytes syntax error after buffer reuse = %+v, want input 5xyz, token xyz", syntax)
+	}
+
+	// The input is parsed once, also when it fails.
+	var r recorder
+	parser.ParseBytes[int64]([]byte("1h 5x 2q"), sys, parser.CollectErrors(), parser.WithObserver(&r))
+	if want := []string{"part 1 h", "error @4", "error @7"}; !slices.Equal(r.events, want) {
+		t.Errorf("ParseBytes observed %q, want %q", r.events, want)
+	}
+
+	if _, _, err := parser.ParseBytes[int64](nil, sys); err != nil {
+		t.Errorf("ParseBytes(nil) error = %v", err)
+	}
+}
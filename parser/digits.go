@@ -0,0 +1,28 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+// normalizeDigits replaces non-ASCII decimal digits (Unicode category Nd), such as
+// full-width "１２３" or Arabic-Indic "١٢٣", with their ASCII equivalents.
+func normalizeDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x80 || !unicode.IsDigit(r) {
+			return r
+		}
+		return '0' + digitValue(r)
+	}, s)
+}
+
+// digitValue returns the value of the decimal digit r. Every run of consecutive Nd
+// code points consists of complete 0-9 sequences, so the value is the offset from
+// the start of the run modulo 10.
+func digitValue(r rune) rune {
+	start := r
+	for unicode.IsDigit(start - 1) {
+		start--
+	}
+	return (r - start) % 10
+}
@@ -0,0 +1,258 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// Sentinel errors reported (possibly wrapped) by the parse functions.
+var (
+	// ErrInvalidNumber is reported when a part does not start with a valid number.
+	ErrInvalidNumber = errors.New("invalid number")
+	// ErrMissingUnit is reported when a number is not followed by a unit.
+	ErrMissingUnit = errors.New("missing unit")
+	// ErrMultiPart is reported when a System without AllowMultiPart receives several parts.
+	ErrMultiPart = errors.New("multi-part format is not allowed")
+	// ErrNegative is reported for negative values in a System with unit.RejectNegative.
+	ErrNegative = errors.New("negative value is not allowed")
+	// ErrDuplicateUnit is reported for a repeated unit in a System with RejectDuplicateUnits.
+	ErrDuplicateUnit = errors.New("duplicate unit")
+	// ErrUnitOrder is reported for a part with a larger unit than an earlier part in a
+	// System with RequireDescendingOrder.
+	ErrUnitOrder = errors.New("units are not in descending order")
+	// ErrEmptyInput is reported for empty input in a System with StrictSyntax.
+	ErrEmptyInput = errors.New("empty input")
+	// ErrTooManyParts is reported when an input has more parts than SystemConfig.MaxParts.
+	ErrTooManyParts = errors.New("too many parts")
+	// ErrInputTooLong is reported for inputs longer than SystemConfig.MaxInputLength.
+	ErrInputTooLong = errors.New("input too long")
+	// ErrNotFinite is reported for numbers, parts or totals that are infinite (beyond the
+	// float64 range, e.g. "1e400 B") or NaN, unless SystemConfig.AllowInfinity is set.
+	ErrNotFinite = errors.New("value is not finite")
+	// ErrOverflow is reported when a value does not fit into the target type. *RangeError
+	// matches it with errors.Is.
+	ErrOverflow = errors.New("value overflows target type")
+)
+
+// errNonLinearMultiPart is reported for a part in a non-linear unit ("20°C", "3dB") that is
+// combined with other parts, since such values do not add up.
+var errNonLinearMultiPart = fmt.Errorf("%w: units with an offset or conversion function stand alone", ErrMultiPart)
+
+// checkInputLength reports ErrInputTooLong if s exceeds cfg.MaxInputLength.
+func checkInputLength(s string, cfg *unit.SystemConfig) error {
+	if cfg.MaxInputLength > 0 && len(s) > cfg.MaxInputLength {
+		return fmt.Errorf("%w: %d bytes, limit is %d", ErrInputTooLong, len(s), cfg.MaxInputLength)
+	}
+	return nil
+}
+
+// skipFailedPart returns where scanning resumes after the part starting at part failed
+// with rest left unparsed: at rest if the scan moved past the part start, or else after
+// the offending token.
+func skipFailedPart(part, rest, separators string) string {
+	if rest == part {
+		rest = rest[len(badToken(rest, separators)):]
+	}
+	return safeSkipSeps(rest, separators)
+}
+
+// skipUnit returns rest after the unit that follows a rejected number, so that
+// CollectErrors resumes after the whole part.
+func skipUnit(rest, separators string) string {
+	_, rest = parseUnit(safeSkipSeps(rest, separators), separators)
+	return rest
+}
+
+// joinPartErrors combines the errors collected under CollectErrors.
+func joinPartErrors(errs []error) error {
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+// errSignPosition reports a sign on a later part under unit.SignLeading.
+var errSignPosition = fmt.Errorf("%w: a sign is only allowed before the first part", ErrInvalidNumber)
+
+// UnknownUnitError is reported when a unit symbol cannot be resolved.
+type UnknownUnitError struct {
+	Symbol string
+}
+
+func (e *UnknownUnitError) Error() string {
+	return fmt.Sprintf("unknown unit: %s", clipRight(e.Symbol))
+}
+
+// AmbiguousUnitError is reported for a symbol with several meanings in a System with
+// RejectAmbiguousUnits.
+type AmbiguousUnitError struct {
+	Ambiguity unit.Ambiguity
+}
+
+func (e *AmbiguousUnitError) Error() string {
+	return fmt.Sprintf("ambiguous unit %s", e.Ambiguity)
+}
+
+// unknownUnit returns the error for a symbol that sys does not resolve.
+func unknownUnit(sys *unit.System, symbol string) error {
+	if sys.Config.RejectAmbiguousUnits {
+		if a, ok := sys.Ambiguity(symbol); ok {
+			return &AmbiguousUnitError{Ambiguity: a}
+		}
+	}
+	return &UnknownUnitError{Symbol: symbol}
+}
+
+// MixedDimensionsError is reported when parts of one input have different dimensions (e.g. "1h 1kg").
+type MixedDimensionsError struct {
+	First, Second unit.Dimension
+}
+
+func (e *MixedDimensionsError) Error() string {
+	return fmt.Sprintf("mixed dimensions: %s and %s", e.First, e.Second)
+}
+
+// PrecisionLossError is reported when a part value (in base units) cannot be
+// represented exactly by the target type, e.g. "0.5ns" parsed into time.Duration.
+type PrecisionLossError struct {
+	Value float64
+}
+
+func (e *PrecisionLossError) Error() string {
+	return fmt.Sprintf("precision loss: part value %g cannot be represented exactly in target type", e.Value)
+}
+
+// ConstraintError is reported when a part violates a constraint of its unit
+// (see unit.WithConstraint). Value is expressed in the unit, prefix applied.
+// For a constraint of the System (see unit.System.AddConstraint), Symbol is empty
+// and Value is the total in base units.
+type ConstraintError struct {
+	Symbol string
+	Value  float64
+	Err    error
+}
+
+func (e *ConstraintError) Error() string {
+	if e.Symbol == "" {
+		return fmt.Sprintf("invalid value: %v", e.Err)
+	}
+	return fmt.Sprintf("invalid value for unit %s: %v", clipRight(e.Symbol), e.Err)
+}
+
+func (e *ConstraintError) Unwrap() error {
+	return e.Err
+}
+
+// SyntaxError locates a parse failure within the input. It wraps the underlying
+// error (e.g. ErrMissingUnit or *UnknownUnitError), so errors.Is/As still match it.
+type SyntaxError struct {
+	Input  string // the complete input string
+	Offset int    // byte offset of Token within Input
+	Token  string // the offending substring
+	Err    error
+}
+
+// syntaxErrorContext is the number of bytes of input shown on each side of the offset,
+// and the longest token or symbol quoted, in error messages. Longer text is clipped with
+// an ellipsis, so hostile inputs do not make for huge errors; the error fields keep it whole.
+const syntaxErrorContext = 32
+
+func (e *SyntaxError) Error() string {
+	before, after := clipLeft(e.Input[:e.Offset]), clipRight(e.Input[e.Offset:])
+	return fmt.Sprintf("%v at offset %d (%q):\n\t%s%s\n\t%s^", e.Err, e.Offset, clipRight(e.Token), before, after, caretPadding(before))
+}
+
+// clipLeft keeps the last syntaxErrorContext bytes of s, on a rune boundary.
+func clipLeft(s string) string {
+	if len(s) <= syntaxErrorContext {
+		return s
+	}
+	i := len(s) - syntaxErrorContext
+	for i < len(s) && !utf8.RuneStart(s[i]) {
+		i++
+	}
+	return "..." + s[i:]
+}
+
+// clipRight keeps the first syntaxErrorContext bytes of s, on a rune boundary.
+func clipRight(s string) string {
+	if len(s) <= syntaxErrorContext {
+		return s
+	}
+	i := syntaxErrorContext
+	for i > 0 && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return s[:i] + "..."
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// syntaxError wraps err with the position of rest within input.
+func syntaxError(input, rest, token string, err error) error {
+	return &SyntaxError{Input: input, Offset: len(input) - len(rest), Token: token, Err: err}
+}
+
+// rebaseSyntaxError moves the position of a *SyntaxError reported for the substring of
+// input at offset to input itself. Other errors are returned unchanged.
+func rebaseSyntaxError(err error, input string, offset int) error {
+	var se *SyntaxError
+	if !errors.As(err, &se) {
+		return err
+	}
+	return &SyntaxError{Input: input, Offset: offset + se.Offset, Token: se.Token, Err: se.Err}
+}
+
+// caretPadding returns the whitespace that aligns a caret below the end of prefix,
+// one column per rune, keeping tabs so the alignment survives tab expansion.
+func caretPadding(prefix string) string {
+	var b strings.Builder
+	for _, r := range prefix {
+		if r == '\t' {
+			b.WriteRune('\t')
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+	return b.String()
+}
+
+// badToken returns the substring at the beginning of s that failed to parse:
+// everything up to the next separator, or at least the first rune.
+func badToken(s, separators string) string {
+	if separators == "" {
+		separators = defaultSeparators
+	}
+	end := strings.IndexFunc(s, func(r rune) bool { return strings.ContainsRune(separators, r) })
+	if end < 0 {
+		end = len(s)
+	}
+	if end == 0 && s != "" {
+		_, end = utf8.DecodeRuneInString(s)
+	}
+	return s[:end]
+}
+
+// RangeError is reported when a value does not fit into the integer target type,
+// e.g. "5400s" parsed into int8.
+type RangeError struct {
+	Value float64
+	Type  string // target type, e.g. "int8" or "time.Duration"
+	Min   int64
+	Max   uint64
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("value %g exceeds %s range [%d,%d]", e.Value, e.Type, e.Min, e.Max)
+}
+
+// Is makes errors.Is(err, ErrOverflow) match range errors.
+func (e *RangeError) Is(target error) bool {
+	return target == ErrOverflow
+}
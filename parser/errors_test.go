@@ -0,0 +1,625 @@
+package parser_test
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func newErrorsSystem(multiPart bool) *unit.System {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: multiPart})
+	sys.Add("ns", 1, unit.DimTime)
+	sys.Add("s", 1e9, unit.DimTime)
+	sys.Add("m", 1, unit.DimLength)
+	return sys
+}
+
+func TestParseSentinelErrors(t *testing.T) {
+	tests := []struct {
+		input     string
+		multiPart bool
+		want      error
+	}{
+		{"abc", true, parser.ErrInvalidNumber},
+		{"10", true, parser.ErrMissingUnit},
+		{"1s 2ns", false, parser.ErrMultiPart},
+	}
+
+	for _, tt := range tests {
+		sys := newErrorsSystem(tt.multiPart)
+		if _, _, err := parser.Parse[float64](tt.input, sys); !errors.Is(err, tt.want) {
+			t.Errorf("Parse(%q) error = %v, want %v", tt.input, err, tt.want)
+		}
+		if _, _, err := parser.ParseInt(tt.input, sys); !errors.Is(err, tt.want) {
+			t.Errorf("ParseInt(%q) error = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestParseTypedErrors(t *testing.T) {
+	sys := newErrorsSystem(true)
+
+	_, _, err := parser.Parse[float64]("5xyz", sys)
+	var unknown *parser.UnknownUnitError
+	if !errors.As(err, &unknown) || unknown.Symbol != "xyz" {
+		t.Errorf("Parse(%q) error = %v, want UnknownUnitError{xyz}", "5xyz", err)
+	}
+
+	_, _, err = parser.ParseInt("1s 1m", sys)
+	var mixed *parser.MixedDimensionsError
+	if !errors.As(err, &mixed) || !mixed.First.Equals(unit.DimTime) || !mixed.Second.Equals(unit.DimLength) {
+		t.Errorf("ParseInt(%q) error = %v, want MixedDimensionsError{time, length}", "1s 1m", err)
+	}
+
+	_, _, err = parser.Parse[int64]("0.5ns", sys)
+	var loss *parser.PrecisionLossError
+	if !errors.As(err, &loss) || loss.Value != 0.5 {
+		t.Errorf("Parse[int64](%q) error = %v, want PrecisionLossError{0.5}", "0.5ns", err)
+	}
+
+	_, _, err = parser.ParseInt("-0.5ns", sys)
+	if !errors.As(err, &loss) || loss.Value != -0.5 {
+		t.Errorf("ParseInt(%q) error = %v, want PrecisionLossError{-0.5}", "-0.5ns", err)
+	}
+}
+
+func TestAmbiguousUnitError(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{RejectAmbiguousUnits: true})
+	sys.Add("min", 60e9, unit.DimTime)
+	sys.Add("in", 0.0254, unit.DimLength)
+	sys.AddPrefix("m", 1e-3, "in")
+
+	_, _, err := parser.Parse[float64]("5min", sys)
+	var ambiguous *parser.AmbiguousUnitError
+	if !errors.As(err, &ambiguous) || ambiguous.Ambiguity.Symbol != "min" {
+		t.Errorf("Parse(%q) error = %v, want AmbiguousUnitError{min}", "5min", err)
+	}
+	if _, _, err := parser.ParseInt("5min", sys); !errors.As(err, &ambiguous) {
+		t.Errorf("ParseInt(%q) error = %v, want AmbiguousUnitError", "5min", err)
+	}
+
+	sys.Config.RejectAmbiguousUnits = false
+	if got, _, err := parser.Parse[float64]("5min", sys); err != nil || got != 300e9 {
+		t.Errorf("Parse(%q) without RejectAmbiguousUnits = %v, %v, want 300e9", "5min", got, err)
+	}
+}
+
+func TestSyntaxErrorPosition(t *testing.T) {
+	sys := newErrorsSystem(true)
+
+	tests := []struct {
+		input      string
+		wantOffset int
+		wantToken  string
+	}{
+		{"1s 30ns 5Kg", 9, "Kg"},
+		{"1s x", 3, "x"},
+		{"1s 5", 3, "5"},
+		{"1s 2m", 4, "m"},
+	}
+
+	for _, tt := range tests {
+		for name, parse := range map[string]func(string) error{
+			"Parse": func(s string) error {
+				_, _, err := parser.Parse[float64](s, sys)
+				return err
+			},
+			"ParseInt": func(s string) error {
+				_, _, err := parser.ParseInt(s, sys)
+				return err
+			},
+		} {
+			var syntaxErr *parser.SyntaxError
+			if err := parse(tt.input); !errors.As(err, &syntaxErr) {
+				t.Errorf("%s(%q) error = %v, want *SyntaxError", name, tt.input, err)
+				continue
+			}
+			if syntaxErr.Offset != tt.wantOffset || syntaxErr.Token != tt.wantToken {
+				t.Errorf("%s(%q) position = %d %q, want %d %q", name, tt.input, syntaxErr.Offset, syntaxErr.Token, tt.wantOffset, tt.wantToken)
+			}
+		}
+	}
+}
+
+func TestSyntaxErrorMessage(t *testing.T) {
+	_, _, err := parser.Parse[float64]("1s 5Kg", newErrorsSystem(true))
+	want := "unknown unit: Kg at offset 4 (\"Kg\"):\n\t1s 5Kg\n\t    ^"
+	if err == nil || err.Error() != want {
+		t.Errorf("Parse error message = %q, want %q", err, want)
+	}
+}
+
+func TestSyntaxErrorMessage_Clipped(t *testing.T) {
+	input := "1s" + strings.Repeat(" ", 100000) + "5Kg" + strings.Repeat("x", 100000)
+	_, _, err := parser.Parse[float64](input, newErrorsSystem(true))
+	var se *parser.SyntaxError
+	if !errors.As(err, &se) || len(se.Input) != len(input) {
+		t.Fatalf("Parse error = %v, want *SyntaxError with the complete input", err)
+	}
+	msg := err.Error()
+	if len(msg) > 300 {
+		t.Errorf("Parse error message has %d bytes, want it clipped", len(msg))
+	}
+	if !strings.Contains(msg, `("Kgxxx`) || !strings.Contains(msg, "\t...    ") || !strings.HasSuffix(strings.Split(msg, "\n")[1], "xx...") {
+		t.Errorf("Parse error message = %q, want the input around the offset with ellipses", msg)
+	}
+	lines := strings.Split(msg, "\n\t")
+	if len(lines) != 3 || strings.Index(lines[1], "Kg") != strings.Index(lines[2], "^") {
+		t.Errorf("caret misaligned in %q", msg)
+	}
+}
+
+func TestRangeError(t *testing.T) {
+	sys := newErrorsSystem(true)
+
+	check := func(name string, err error, wantType string, wantMin int64, wantMax uint64) {
+		t.Helper()
+		var rangeErr *parser.RangeError
+		if !errors.As(err, &rangeErr) {
+			t.Errorf("%s error = %v, want *RangeError", name, err)
+			return
+		}
+		if rangeErr.Type != wantType || rangeErr.Min != wantMin || rangeErr.Max != wantMax {
+			t.Errorf("%s range = %s [%d,%d], want %s [%d,%d]", name, rangeErr.Type, rangeErr.Min, rangeErr.Max, wantType, wantMin, wantMax)
+		}
+	}
+
+	_, _, err := parser.Parse[int8]("5400ns", sys)
+	check("Parse[int8](5400ns)", err, "int8", -128, 127)
+	if want := "value 5400 exceeds int8 range [-128,127]"; !strings.Contains(err.Error(), want) {
+		t.Errorf("Parse[int8](5400ns) error = %q, want it to contain %q", err, want)
+	}
+	_, _, err = parser.Parse[uint8]("-1ns", sys)
+	check("Parse[uint8](-1ns)", err, "uint8", 0, 255)
+	_, _, err = parser.Parse[int16]("1s", sys)
+	check("Parse[int16](1s)", err, "int16", -32768, 32767)
+	_, _, err = parser.Parse[time.Duration]("1e10s", sys)
+	check("Parse[time.Duration](1e10s)", err, "time.Duration", math.MinInt64, math.MaxInt64)
+
+	valid := []struct {
+		input string
+		parse func(string) error
+	}{
+		{"127ns", func(s string) error { _, _, err := parser.Parse[int8](s, sys); return err }},
+		{"-128ns", func(s string) error { _, _, err := parser.Parse[int8](s, sys); return err }},
+		{"255ns", func(s string) error { _, _, err := parser.Parse[uint8](s, sys); return err }},
+		{"1e10s", func(s string) error { _, _, err := parser.Parse[float32](s, sys); return err }},
+	}
+	for _, tt := range valid {
+		if err := tt.parse(tt.input); err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", tt.input, err)
+		}
+	}
+}
+
+func TestConstraintError(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true})
+	sys.Add("ns", 1, unit.DimTime, unit.WithConstraint(unit.IntegerValue()))
+	sys.Add("pct", 1, unit.DimDimensionless, unit.WithConstraint(unit.NonNegativeValue(), unit.ValueRange(0, 100)))
+	sys.AddPrefix("k", 1e3, "ns")
+
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{"15ns", false},
+		{"1.5kns", false},
+		{"1.5ns", true},
+		{"1ns 0.5ns", true},
+		{"50pct", false},
+		{"100pct", false},
+		{"101pct", true},
+		{"-1pct", true},
+	}
+
+	for _, tt := range tests {
+		_, _, errF := parser.Parse[float64](tt.input, sys)
+		_, _, errI := parser.ParseInt(tt.input, sys)
+		for name, err := range map[string]error{"Parse": errF, "ParseInt": errI} {
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("%s(%q) unexpected error: %v", name, tt.input, err)
+				}
+				continue
+			}
+			var constraintErr *parser.ConstraintError
+			if !errors.As(err, &constraintErr) || !errors.Is(err, unit.ErrConstraint) {
+				t.Errorf("%s(%q) error = %v, want *ConstraintError", name, tt.input, err)
+			}
+		}
+	}
+}
+
+func TestSystemConstraint(t *testing.T) {
+	// A TTL between 1s and 24h, checked on the total in base units (ns).
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true})
+	sys.Add("ns", 1, unit.DimTime)
+	sys.Add("s", 1e9, unit.DimTime)
+	sys.Add("h", 3600e9, unit.DimTime)
+	if err := sys.AddConstraint(unit.MinValue(1e9), unit.MaxValue(24*3600e9)); err != nil {
+		t.Fatalf("AddConstraint error: %v", err)
+	}
+
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{"1s", false},
+		{"24h", false},
+		{"23h 3600s", false},
+		{"999999999ns", true},
+		{"24h 1ns", true},
+	}
+	for _, tt := range tests {
+		_, _, errF := parser.Parse[float64](tt.input, sys)
+		_, _, errI := parser.ParseInt(tt.input, sys)
+		for name, err := range map[string]error{"Parse": errF, "ParseInt": errI} {
+			var constraintErr *parser.ConstraintError
+			if !tt.wantErr && err != nil {
+				t.Errorf("%s(%q) unexpected error: %v", name, tt.input, err)
+			} else if tt.wantErr && (!errors.As(err, &constraintErr) || constraintErr.Symbol != "" || !errors.Is(err, unit.ErrConstraint)) {
+				t.Errorf("%s(%q) error = %v, want *ConstraintError for the total", name, tt.input, err)
+			}
+		}
+	}
+
+	if err := sys.Clone().Check(0); err == nil {
+		t.Error("Clone().Check(0) expected the cloned constraint to fail, got nil")
+	}
+}
+
+func TestOverflowPolicy(t *testing.T) {
+	sys := newErrorsSystem(true)
+	sys.Add("B", 8, unit.DimStorage)
+	sys.AddPrefix("Ei", 1<<60, "B")
+
+	for _, input := range []string{"100EiB", "1e12s"} {
+		if _, _, err := parser.Parse[int64](input, sys); !errors.Is(err, parser.ErrOverflow) {
+			t.Errorf("Parse[int64](%q) error = %v, want ErrOverflow", input, err)
+		}
+		if _, _, err := parser.ParseInt(input, sys); !errors.Is(err, parser.ErrOverflow) {
+			t.Errorf("ParseInt(%q) error = %v, want ErrOverflow", input, err)
+		}
+	}
+
+	sys.Config.OverflowPolicy = unit.OverflowSaturate
+	tests := []struct {
+		input string
+		want  int64
+	}{
+		{"100EiB", math.MaxInt64},
+		{"-100EiB", math.MinInt64},
+		{"1e12s", math.MaxInt64},
+		{"-1e12s", math.MinInt64},
+		{"-1e12s 1s", math.MinInt64 + 1e9},
+		{"5s", 5e9},
+	}
+	for _, tt := range tests {
+		if got, _, err := parser.Parse[int64](tt.input, sys); err != nil || got != tt.want {
+			t.Errorf("Parse[int64](%q) = %v, %v, want %v", tt.input, got, err, tt.want)
+		}
+		if got, _, err := parser.ParseInt(tt.input, sys); err != nil || got != tt.want {
+			t.Errorf("ParseInt(%q) = %v, %v, want %v", tt.input, got, err, tt.want)
+		}
+	}
+	if got, _, err := parser.Parse[int8]("1s", sys); err != nil || got != math.MaxInt8 {
+		t.Errorf("Parse[int8](1s) = %v, %v, want %v", got, err, math.MaxInt8)
+	}
+	if got, _, err := parser.Parse[uint8]("-1ns", sys); err != nil || got != 0 {
+		t.Errorf("Parse[uint8](-1ns) = %v, %v, want 0", got, err)
+	}
+}
+
+func TestAccumulationOverflow(t *testing.T) {
+	sys := newErrorsSystem(true)
+	sys.Add("B", 8, unit.DimStorage)
+	sys.AddPrefix("Ei", 1<<60, "B")
+
+	tests := []struct {
+		input        string
+		wantOverflow bool
+		saturated    int64
+	}{
+		{"0.5EiB 0.5EiB", true, math.MaxInt64}, // 2^63: one past the maximum
+		{"-0.5EiB -0.5EiB", false, math.MinInt64},
+		{"-0.5EiB -0.5EiB -1B", true, math.MinInt64},
+	}
+	for _, tt := range tests {
+		_, _, err := parser.Parse[int64](tt.input, sys)
+		_, _, errInt := parser.ParseInt(tt.input, sys)
+		if !tt.wantOverflow {
+			if err != nil || errInt != nil {
+				t.Errorf("Parse/ParseInt(%q) unexpected error: %v, %v", tt.input, err, errInt)
+			}
+			continue
+		}
+		var rangeErr *parser.RangeError
+		if !errors.As(err, &rangeErr) || !errors.Is(errInt, parser.ErrOverflow) {
+			t.Errorf("Parse/ParseInt(%q) error = %v, %v, want ErrOverflow", tt.input, err, errInt)
+		}
+	}
+
+	if _, _, err := parser.Parse[int16]("20000ns 20000ns", sys); !errors.Is(err, parser.ErrOverflow) {
+		t.Errorf("Parse[int16](20000ns 20000ns) error = %v, want ErrOverflow", err)
+	}
+	if _, _, err := parser.Parse[uint8]("200ns 100ns", sys); !errors.Is(err, parser.ErrOverflow) {
+		t.Errorf("Parse[uint8](200ns 100ns) error = %v, want ErrOverflow", err)
+	}
+
+	sys.Config.OverflowPolicy = unit.OverflowSaturate
+	for _, tt := range tests {
+		if got, _, err := parser.Parse[int64](tt.input, sys); err != nil || got != tt.saturated {
+			t.Errorf("Parse[int64](%q) = %v, %v, want %v", tt.input, got, err, tt.saturated)
+		}
+		if got, _, err := parser.ParseInt(tt.input, sys); err != nil || got != tt.saturated {
+			t.Errorf("ParseInt(%q) = %v, %v, want %v", tt.input, got, err, tt.saturated)
+		}
+	}
+}
+
+func TestMaxParts(t *testing.T) {
+	sys := newErrorsSystem(true)
+	long := strings.Repeat("1s", 101)
+
+	for name, parse := range map[string]func(string) error{
+		"Parse": func(s string) error {
+			_, _, err := parser.Parse[float64](s, sys)
+			return err
+		},
+		"ParseInt": func(s string) error {
+			_, _, err := parser.ParseInt(s, sys)
+			return err
+		},
+	} {
+		sys.Config.MaxParts = 0
+		if err := parse(strings.Repeat("1s", 100)); err != nil {
+			t.Errorf("%s with 100 parts unexpected error: %v", name, err)
+		}
+		if err := parse(long); !errors.Is(err, parser.ErrTooManyParts) {
+			t.Errorf("%s with 101 parts error = %v, want ErrTooManyParts", name, err)
+		}
+		sys.Config.MaxParts = 2
+		if err := parse("1s 1s 1s"); !errors.Is(err, parser.ErrTooManyParts) {
+			t.Errorf("%s(%q) with MaxParts 2 error = %v, want ErrTooManyParts", name, "1s 1s 1s", err)
+		}
+		sys.Config.MaxParts = -1
+		if err := parse(long); err != nil {
+			t.Errorf("%s with 101 parts and no limit unexpected error: %v", name, err)
+		}
+	}
+}
+
+func TestMaxInputLength(t *testing.T) {
+	sys := newErrorsSystem(true)
+	sys.Config.MaxInputLength = 8
+
+	if _, _, err := parser.Parse[float64]("1s 500ns", sys); err != nil {
+		t.Errorf("Parse with 8 bytes unexpected error: %v", err)
+	}
+	if _, _, err := parser.Parse[float64]("1s 5000ns", sys); !errors.Is(err, parser.ErrInputTooLong) {
+		t.Errorf("Parse with 9 bytes error = %v, want ErrInputTooLong", err)
+	}
+	if _, _, err := parser.ParseInt("1s 5000ns", sys); !errors.Is(err, parser.ErrInputTooLong) {
+		t.Errorf("ParseInt with 9 bytes error = %v, want ErrInputTooLong", err)
+	}
+	if _, err := parser.Tokenize("1s 5000ns", sys); !errors.Is(err, parser.ErrInputTooLong) {
+		t.Errorf("Tokenize with 9 bytes error = %v, want ErrInputTooLong", err)
+	}
+	if _, _, err := parser.Parse[float64]("1s 5000ns", sys, parser.WithMaxInputLength(0)); err != nil {
+		t.Errorf("Parse with WithMaxInputLength(0) unexpected error: %v", err)
+	}
+	if _, _, err := parser.Parse[float64]("1s", sys, parser.WithMaxInputLength(1)); !errors.Is(err, parser.ErrInputTooLong) {
+		t.Errorf("Parse with WithMaxInputLength(1) error = %v, want ErrInputTooLong", err)
+	}
+}
+
+func TestStrictSyntax(t *testing.T) {
+	sys := newErrorsSystem(true)
+	sys.Config.StrictSyntax = true
+
+	valid := []string{"5s", "0.5s", "-1s", "1s 500ns", "10 m"}
+	for _, in := range valid {
+		if _, _, err := parser.Parse[float64](in, sys); err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", in, err)
+		}
+		if _, _, err := parser.ParseInt(in, sys); err != nil {
+			t.Errorf("ParseInt(%q) unexpected error: %v", in, err)
+		}
+	}
+
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{"1e3s", parser.ErrInvalidNumber},
+		{"1E-3s", parser.ErrInvalidNumber},
+		{"+5s", parser.ErrInvalidNumber},
+		{"1s +5ns", parser.ErrInvalidNumber},
+		{".5s", parser.ErrInvalidNumber},
+		{"-.5s", parser.ErrInvalidNumber},
+		{"", parser.ErrEmptyInput},
+		{"  ", parser.ErrEmptyInput},
+	}
+	for _, tt := range tests {
+		if _, _, err := parser.Parse[float64](tt.input, sys); !errors.Is(err, tt.want) {
+			t.Errorf("Parse(%q) error = %v, want %v", tt.input, err, tt.want)
+		}
+		if _, _, err := parser.ParseInt(tt.input, sys); !errors.Is(err, tt.want) {
+			t.Errorf("ParseInt(%q) error = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+
+	lenient := newErrorsSystem(true)
+	for _, in := range []string{"1e3s", "+5s", ".5s"} {
+		if _, _, err := parser.Parse[float64](in, lenient); err != nil {
+			t.Errorf("lenient Parse(%q) unexpected error: %v", in, err)
+		}
+		if _, _, err := parser.Parse[float64](in, lenient, parser.WithStrictSyntax()); !errors.Is(err, parser.ErrInvalidNumber) {
+			t.Errorf("Parse(%q, WithStrictSyntax()) error = %v, want ErrInvalidNumber", in, err)
+		}
+	}
+}
+
+func TestCollectErrors(t *testing.T) {
+	sys := newErrorsSystem(true)
+	sys.Config.NegativePolicy = unit.RejectNegative
+
+	tests := []struct {
+		input   string
+		offsets []int
+	}{
+		{"1s 5x 2q", []int{4, 7}},
+		{"5x2q 1s", []int{1, 3}},
+		{"-1s 5ns abc 2", []int{0, 8, 12}},
+		{"1s 2m", []int{4}},
+	}
+	for _, tt := range tests {
+		for name, parse := range map[string]func() error{
+			"Parse": func() error {
+				_, _, err := parser.Parse[float64](tt.input, sys, parser.CollectErrors())
+				return err
+			},
+			"ParseInt": func() error {
+				_, _, err := parser.ParseInt(tt.input, sys, parser.CollectErrors())
+				return err
+			},
+		} {
+			err := parse()
+			errs := []error{err}
+			if joined, ok := err.(interface{ Unwrap() []error }); ok {
+				errs = joined.Unwrap()
+			}
+			var offsets []int
+			for _, e := range errs {
+				var syntaxErr *parser.SyntaxError
+				if !errors.As(e, &syntaxErr) {
+					t.Fatalf("%s(%q) error %v is not a *SyntaxError", name, tt.input, e)
+				}
+				offsets = append(offsets, syntaxErr.Offset)
+			}
+			if !slices.Equal(offsets, tt.offsets) {
+				t.Errorf("%s(%q) error offsets = %v, want %v (%v)", name, tt.input, offsets, tt.offsets, err)
+			}
+		}
+	}
+
+	// Fail fast without the option
+	_, _, err := parser.Parse[float64]("1s 5x 2q", sys)
+	if _, ok := err.(interface{ Unwrap() []error }); ok {
+		t.Errorf("Parse without CollectErrors returned %v, want a single error", err)
+	}
+	if _, _, err := parser.Parse[float64]("1s 2s", sys, parser.CollectErrors()); err != nil {
+		t.Errorf("Parse with CollectErrors unexpected error: %v", err)
+	}
+}
+
+func TestRejectDuplicateUnits(t *testing.T) {
+	sys := newErrorsSystem(true)
+	sys.Add("sec", 1e9, unit.DimTime)
+	if err := sys.AddPrefix("m", 1e-3, "s"); err != nil {
+		t.Fatal(err)
+	}
+	sys.Config.RejectDuplicateUnits = true
+
+	tests := []struct {
+		input  string
+		offset int // of the duplicate, -1 if accepted
+	}{
+		{"1s 500ns", -1},
+		{"1s 500ms", -1},
+		{"1s 2s", 4},
+		{"1s 5ns 2s", 8},
+		{"1s 2sec", 4},
+		{"1ms 2ms", 5},
+	}
+	for _, tt := range tests {
+		_, _, errF := parser.Parse[float64](tt.input, sys)
+		_, _, errI := parser.ParseInt(tt.input, sys)
+		for name, err := range map[string]error{"Parse": errF, "ParseInt": errI} {
+			if tt.offset < 0 {
+				if err != nil {
+					t.Errorf("%s(%q) unexpected error: %v", name, tt.input, err)
+				}
+				continue
+			}
+			var syntaxErr *parser.SyntaxError
+			if !errors.Is(err, parser.ErrDuplicateUnit) || !errors.As(err, &syntaxErr) || syntaxErr.Offset != tt.offset {
+				t.Errorf("%s(%q) error = %v, want ErrDuplicateUnit at offset %d", name, tt.input, err, tt.offset)
+			}
+		}
+	}
+}
+
+func TestRequireDescendingOrder(t *testing.T) {
+	sys := newErrorsSystem(true)
+	sys.Config.RequireDescendingOrder = true
+
+	tests := []struct {
+		input  string
+		offset int // of the out-of-order unit, -1 if accepted
+	}{
+		{"1s", -1},
+		{"1s 500ns", -1},
+		{"1s 1s", -1},
+		{"500ns 1s", 7},
+		{"1s 5ns 2s", 8},
+	}
+	for _, tt := range tests {
+		_, _, errF := parser.Parse[float64](tt.input, sys)
+		_, _, errI := parser.ParseInt(tt.input, sys)
+		for name, err := range map[string]error{"Parse": errF, "ParseInt": errI} {
+			if tt.offset < 0 {
+				if err != nil {
+					t.Errorf("%s(%q) unexpected error: %v", name, tt.input, err)
+				}
+				continue
+			}
+			var syntaxErr *parser.SyntaxError
+			if !errors.Is(err, parser.ErrUnitOrder) || !errors.As(err, &syntaxErr) || syntaxErr.Offset != tt.offset {
+				t.Errorf("%s(%q) error = %v, want ErrUnitOrder at offset %d", name, tt.input, err, tt.offset)
+			}
+		}
+	}
+}
+
+func TestNotFinite(t *testing.T) {
+	sys := newErrorsSystem(true)
+	sys.Add("Qs", 1e300, unit.DimTime)
+
+	for _, in := range []string{"1e400ns", "1e300Qs", "1e308s 1e308s", "-1e400ns"} {
+		if _, _, err := parser.Parse[float64](in, sys); !errors.Is(err, parser.ErrNotFinite) {
+			t.Errorf("Parse(%q) error = %v, want ErrNotFinite", in, err)
+		}
+	}
+	// Integer targets as well; with AllowInfinity they overflow instead (below).
+	if _, _, err := parser.Parse[int64]("1e300Qs", sys); !errors.Is(err, parser.ErrNotFinite) {
+		t.Errorf("Parse[int64](1e300Qs) error = %v, want ErrNotFinite", err)
+	}
+
+	sys.Config.AllowInfinity = true
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"1e400ns", math.Inf(1)},
+		{"-1e300Qs", math.Inf(-1)},
+		{"1e308s 1e308s", math.Inf(1)},
+	}
+	for _, tt := range tests {
+		if got, _, err := parser.Parse[float64](tt.input, sys); err != nil || got != tt.want {
+			t.Errorf("Parse(%q) with AllowInfinity = %v, %v, want %v", tt.input, got, err, tt.want)
+		}
+	}
+	if _, _, err := parser.Parse[float64]("1e400ns -1e400ns", sys); !errors.Is(err, parser.ErrNotFinite) {
+		t.Errorf("Parse(Inf - Inf) error = %v, want ErrNotFinite for NaN", err)
+	}
+	if _, _, err := parser.Parse[int64]("1e400ns", sys); !errors.Is(err, parser.ErrOverflow) {
+		t.Errorf("Parse[int64](1e400ns) with AllowInfinity error = %v, want ErrOverflow", err)
+	}
+}
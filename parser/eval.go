@@ -0,0 +1,284 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// ErrInvalidExpression is reported by Eval for malformed expressions, e.g. unbalanced
+// parentheses or a missing operand.
+var ErrInvalidExpression = errors.New("invalid expression")
+
+// Eval evaluates an arithmetic expression on quantities, such as "2*512MiB", "1h + 30m"
+// or "(1GB - 100MB) / 4", and returns the result in base units with its dimension.
+//
+// Operands are quantities in the grammar of Parse (multi-part operands like "1h 30m"
+// follow SystemConfig.AllowMultiPart) or plain numbers. '+' and '-' require operands of
+// the same dimension; '*' and '/' combine dimensions, so "1km / 1h" is a speed. Non-SI
+// dimensions (unit.DimStorage) only cancel out ("1GB / 100MB") or scale by numbers.
+// Affine and function units ("°C", "dB") are only accepted as the whole expression,
+// since arithmetic on their base-unit values is meaningless; operators applied to them
+// fail with a *NonLinearOperandError.
+// Units containing '/' or '*' cannot be used, since those characters are operators.
+func Eval(s string, sys *unit.System, opts ...ParseOption) (float64, unit.Dimension, error) {
+	o := newParseOptions(sys, opts)
+	if err := checkInputLength(s, &o.config); err != nil {
+		return 0, unit.Dimension{}, err
+	}
+	e := evaluator{orig: s, s: s, sys: sys, opts: opts, cfg: o.config}
+	q, err := e.expr()
+	if err == nil {
+		e.skipSpace()
+		if e.s != "" {
+			err = e.errorf("unexpected %q", badToken(e.s, " "))
+		}
+	}
+	if err != nil {
+		return 0, unit.Dimension{}, err
+	}
+	return q.val, q.dim, nil
+}
+
+// NonLinearOperandError is reported by Eval for an operator applied to a quantity in an
+// affine or function unit, e.g. "20°C + 30°C".
+type NonLinearOperandError struct {
+	Symbol string
+}
+
+func (e *NonLinearOperandError) Error() string {
+	return fmt.Sprintf("%v: unit %s has an offset or conversion function and cannot be used with operators", ErrInvalidExpression, e.Symbol)
+}
+
+// Unwrap returns ErrInvalidExpression.
+func (e *NonLinearOperandError) Unwrap() error {
+	return ErrInvalidExpression
+}
+
+// quantity is an intermediate result of Eval in base units.
+type quantity struct {
+	val float64
+	dim unit.Dimension
+
+	// nonLinear is the symbol of the affine or function unit the quantity was written
+	// in, if any; such quantities cannot be operands (see NonLinearOperandError).
+	nonLinear string
+}
+
+// checkLinear reports a *NonLinearOperandError at pos if an operand is non-linear.
+func (e *evaluator) checkLinear(pos string, operands ...quantity) error {
+	for _, q := range operands {
+		if q.nonLinear != "" {
+			return syntaxError(e.orig, pos, pos[:1], &NonLinearOperandError{Symbol: q.nonLinear})
+		}
+	}
+	return nil
+}
+
+// evaluator is a recursive-descent parser for Eval:
+//
+//	expr   = term { ("+" | "-") term }
+//	term   = factor { ("*" | "/") factor }
+//	factor = ("+" | "-") factor | "(" expr ")" | operand
+type evaluator struct {
+	orig string
+	s    string // remaining input
+	sys  *unit.System
+	opts []ParseOption
+	cfg  unit.SystemConfig
+}
+
+func (e *evaluator) expr() (quantity, error) {
+	q, err := e.term()
+	for err == nil {
+		e.skipSpace()
+		if e.s == "" || (e.s[0] != '+' && e.s[0] != '-') {
+			break
+		}
+		op, pos := e.s[0], e.s
+		e.s = e.s[1:]
+		var r quantity
+		if r, err = e.term(); err != nil {
+			break
+		}
+		if err := e.checkLinear(pos, q, r); err != nil {
+			return q, err
+		}
+		if !q.dim.Equals(r.dim) {
+			return q, syntaxError(e.orig, pos, pos[:1], &MixedDimensionsError{First: q.dim, Second: r.dim})
+		}
+		if op == '+' {
+			q.val += r.val
+		} else {
+			q.val -= r.val
+		}
+	}
+	return q, err
+}
+
+func (e *evaluator) term() (quantity, error) {
+	q, err := e.factor()
+	for err == nil {
+		e.skipSpace()
+		if e.s == "" || (e.s[0] != '*' && e.s[0] != '/') {
+			break
+		}
+		op, pos := e.s[0], e.s
+		e.s = e.s[1:]
+		var r quantity
+		if r, err = e.factor(); err != nil {
+			break
+		}
+		if err := e.checkLinear(pos, q, r); err != nil {
+			return q, err
+		}
+		if op == '*' {
+			q, err = mulQuantity(q, r)
+		} else {
+			q, err = divQuantity(q, r)
+		}
+		if err != nil {
+			err = syntaxError(e.orig, pos, pos[:1], err)
+		}
+	}
+	return q, err
+}
+
+func (e *evaluator) factor() (quantity, error) {
+	e.skipSpace()
+	switch {
+	case e.s == "":
+		return quantity{}, e.errorf("missing operand")
+	case e.s[0] == '-' || e.s[0] == '+':
+		neg, pos := e.s[0] == '-', e.s
+		e.s = e.s[1:]
+		q, err := e.factor()
+		if err == nil && neg {
+			err = e.checkLinear(pos, q)
+		}
+		if neg {
+			q.val = -q.val
+		}
+		return q, err
+	case e.s[0] == '(':
+		open := e.s
+		e.s = e.s[1:]
+		q, err := e.expr()
+		if err != nil {
+			return q, err
+		}
+		e.skipSpace()
+		if !strings.HasPrefix(e.s, ")") {
+			return q, syntaxError(e.orig, open, "(", fmt.Errorf("%w: unbalanced parenthesis", ErrInvalidExpression))
+		}
+		e.s = e.s[1:]
+		return q, nil
+	}
+	return e.operand()
+}
+
+// operand reads a plain number or a quantity, possibly of several parts.
+func (e *evaluator) operand() (quantity, error) {
+	start := e.s
+	hasUnit := false
+	for {
+		if e.s == "" || (!isDigit(e.s[0]) && e.s[0] != '.') {
+			if e.s == start {
+				return quantity{}, e.errorf("unexpected %q", badToken(e.s, " "))
+			}
+			break
+		}
+		_, rest, err := parseValue(e.s, e.sys, &e.cfg)
+		if err != nil {
+			return quantity{}, syntaxError(e.orig, e.s, badToken(e.s, " "), err)
+		}
+		e.s = rest
+		e.skipSpace()
+		n := unitLen(e.s)
+		if n == 0 {
+			break
+		}
+		hasUnit = true
+		e.s = e.s[n:]
+		e.skipSpace()
+	}
+	text := strings.TrimRightFunc(start[:len(start)-len(e.s)], unicode.IsSpace)
+
+	if !hasUnit {
+		num, _, err := parseValue(text, e.sys, &e.cfg)
+		if err != nil {
+			return quantity{}, syntaxError(e.orig, start, text, err)
+		}
+		return quantity{val: num.num / num.den}, nil
+	}
+	val, dim, parts, err := ParseDetailed[float64](text, e.sys, e.opts...)
+	if err != nil {
+		return quantity{}, rebaseSyntaxError(err, e.orig, len(e.orig)-len(start))
+	}
+	q := quantity{val: val, dim: dim}
+	for _, p := range parts {
+		if !p.Unit.Linear() {
+			q.nonLinear = p.Prefix.Symbol + p.Unit.Symbol
+		}
+	}
+	return q, nil
+}
+
+// unitLen returns the length of the unit symbol at the beginning of s, which ends at
+// whitespace, an operator, a parenthesis or the next number.
+func unitLen(s string) int {
+	for i, r := range s {
+		if unicode.IsSpace(r) || unicode.IsDigit(r) || strings.ContainsRune("+-*/().", r) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+func (e *evaluator) skipSpace() {
+	for e.s != "" {
+		r, size := utf8.DecodeRuneInString(e.s)
+		if !unicode.IsSpace(r) {
+			return
+		}
+		e.s = e.s[size:]
+	}
+}
+
+// errorf reports an ErrInvalidExpression at the current position.
+func (e *evaluator) errorf(format string, args ...any) error {
+	return syntaxError(e.orig, e.s, badToken(e.s, " "), fmt.Errorf("%w: "+format, append([]any{ErrInvalidExpression}, args...)...))
+}
+
+// mulQuantity multiplies a and b. Non-SI dimensions cannot be multiplied with each other.
+func mulQuantity(a, b quantity) (quantity, error) {
+	if a.dim.Extra != "" && b.dim.Extra != "" {
+		return quantity{}, &MixedDimensionsError{First: a.dim, Second: b.dim}
+	}
+	return quantity{val: a.val * b.val, dim: a.dim.Mul(b.dim)}, nil
+}
+
+// divQuantity divides a by b. A non-SI dimension only cancels out against itself or
+// is divided by a quantity without one.
+func divQuantity(a, b quantity) (quantity, error) {
+	if b.val == 0 {
+		return quantity{}, fmt.Errorf("%w: division by zero", ErrInvalidExpression)
+	}
+	extra := a.dim.Extra
+	switch {
+	case b.dim.Extra == "":
+	case b.dim.Extra == a.dim.Extra:
+		extra = ""
+	default:
+		return quantity{}, &MixedDimensionsError{First: a.dim, Second: b.dim}
+	}
+	inv := b.dim.Pow(-1)
+	inv.Extra = ""
+	dim := a.dim.Mul(inv)
+	dim.Extra = extra
+	return quantity{val: a.val / b.val, dim: dim}, nil
+}
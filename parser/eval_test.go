@@ -0,0 +1,127 @@
+package parser_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func newEvalSystem() *unit.System {
+	sys := createTestSystem()
+	sys.Add("km", 1000, unit.DimLength)
+	sys.Add("B", 1, unit.DimStorage)
+	sys.Add("MB", 1e6, unit.DimStorage)
+	sys.Add("GB", 1e9, unit.DimStorage)
+	sys.Add("MiB", 1<<20, unit.DimStorage)
+	return sys
+}
+
+func TestEval(t *testing.T) {
+	sys := newEvalSystem()
+
+	tests := []struct {
+		input   string
+		want    float64
+		wantDim unit.Dimension
+	}{
+		{"2*512MiB", 1 << 30, unit.DimStorage},
+		{"1h + 30m", 5400, unit.DimTime},
+		{"(1GB - 100MB) / 4", 225e6, unit.DimStorage},
+		{"1h 30m - 90m", 0, unit.DimTime},
+		{"-(1h) + 2h", 3600, unit.DimTime},
+		{"1GB / 100MB", 10, unit.DimDimensionless},
+		{"1km / 1h", 1000.0 / 3600, unit.Dimension{L: 1, T: -1}},
+		{"2 * 3 + 4", 10, unit.DimDimensionless},
+		{"2 * (3 + 4)", 14, unit.DimDimensionless},
+		{"1e3 ms", 1, unit.DimTime},
+		{" 10s/2 ", 5, unit.DimTime},
+	}
+	for _, tt := range tests {
+		got, dim, err := parser.Eval(tt.input, sys)
+		if err != nil {
+			t.Errorf("Eval(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-9 || !dim.Equals(tt.wantDim) {
+			t.Errorf("Eval(%q) = %v, %v, want %v, %v", tt.input, got, dim, tt.want, tt.wantDim)
+		}
+	}
+}
+
+func TestEval_Errors(t *testing.T) {
+	sys := newEvalSystem()
+
+	tests := []struct {
+		input  string
+		want   error
+		offset int
+	}{
+		{"1h + 1km", nil, 3},
+		{"(1h + 2h", parser.ErrInvalidExpression, 0},
+		{"1h +", parser.ErrInvalidExpression, 4},
+		{"1h )", parser.ErrInvalidExpression, 3},
+		{"1h / 0", parser.ErrInvalidExpression, 3},
+		{"2 * 5xyz", nil, 5},
+		{"1GB * 1GB", nil, 4},
+		{"", parser.ErrInvalidExpression, 0},
+	}
+	for _, tt := range tests {
+		_, _, err := parser.Eval(tt.input, sys)
+		var syntaxErr *parser.SyntaxError
+		if !errors.As(err, &syntaxErr) || syntaxErr.Offset != tt.offset || (tt.want != nil && !errors.Is(err, tt.want)) {
+			t.Errorf("Eval(%q) error = %v, want %v at offset %d", tt.input, err, tt.want, tt.offset)
+		}
+	}
+
+	var mixed *parser.MixedDimensionsError
+	if _, _, err := parser.Eval("1h + 1km", sys); !errors.As(err, &mixed) {
+		t.Errorf("Eval(1h + 1km) error = %v, want *MixedDimensionsError", err)
+	}
+	var unknown *parser.UnknownUnitError
+	if _, _, err := parser.Eval("2 * 5xyz", sys); !errors.As(err, &unknown) {
+		t.Errorf("Eval(2 * 5xyz) error = %v, want *UnknownUnitError", err)
+	}
+}
+
+func TestEval_NonLinearUnits(t *testing.T) {
+	sys := newEvalSystem()
+	sys.Add("°C", 1, unit.DimTemp, unit.WithOffset(273.15))
+	sys.AddFunc("dB",
+		func(v float64) float64 { return math.Pow(10, v/10) },
+		func(r float64) float64 { return 10 * math.Log10(r) },
+		unit.DimDimensionless)
+
+	// A bare operand is converted as by Parse.
+	if got, _, err := parser.Eval("20°C", sys); err != nil || math.Abs(got-293.15) > 1e-9 {
+		t.Errorf("Eval(20°C) = %v, %v, want 293.15", got, err)
+	}
+	if got, _, err := parser.Eval("(10dB)", sys); err != nil || math.Abs(got-10) > 1e-9 {
+		t.Errorf("Eval((10dB)) = %v, %v, want 10", got, err)
+	}
+
+	tests := []struct {
+		input  string
+		symbol string
+		offset int
+	}{
+		{"20°C + 30°C", "°C", 6},
+		{"2 * 20°C", "°C", 2},
+		{"20°C / 2", "°C", 6},
+		{"-20°C", "°C", 0},
+		{"10dB - 3dB", "dB", 5},
+	}
+	for _, tt := range tests {
+		_, _, err := parser.Eval(tt.input, sys)
+		var nonLinear *parser.NonLinearOperandError
+		var syntaxErr *parser.SyntaxError
+		if !errors.As(err, &nonLinear) || nonLinear.Symbol != tt.symbol || !errors.As(err, &syntaxErr) || syntaxErr.Offset != tt.offset {
+			t.Errorf("Eval(%q) error = %v, want *NonLinearOperandError for %s at offset %d", tt.input, err, tt.symbol, tt.offset)
+		}
+		if !errors.Is(err, parser.ErrInvalidExpression) {
+			t.Errorf("Eval(%q) error = %v, want ErrInvalidExpression", tt.input, err)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// FormatOption configures Format.
+type FormatOption func(*formatOptions)
+
+type formatOptions struct {
+	dim       *unit.Dimension
+	units     []string
+	precision int
+	layout    string
+	compound  bool
+	prefixes  unit.PrefixPolicy
+	unitSep   string
+}
+
+// WithDimension selects the dimension to format in.
+// Required when the System holds units of several dimensions.
+func WithDimension(dim unit.Dimension) FormatOption {
+	return func(o *formatOptions) {
+		o.dim = &dim
+	}
+}
+
+// WithUnits restricts the candidate units (e.g. "KiB", "MiB", "GiB" for IEC-only output).
+func WithUnits(symbols ...string) FormatOption {
+	return func(o *formatOptions) {
+		o.units = symbols
+	}
+}
+
+// WithPrecision fixes the number of decimals. By default the shortest exact form is used.
+func WithPrecision(decimals int) FormatOption {
+	return func(o *formatOptions) {
+		o.precision = decimals
+	}
+}
+
+// WithLayout renders the result through FormatTemplate (e.g. "{value:%.2f} {unit}").
+func WithLayout(layout string) FormatOption {
+	return func(o *formatOptions) {
+		o.layout = layout
+	}
+}
+
+// WithCompound renders the value in several parts from the largest unit down ("1h30m"),
+// as read by a System with AllowMultiPart. Only the last part keeps a fraction.
+func WithCompound() FormatOption {
+	return func(o *formatOptions) {
+		o.compound = true
+	}
+}
+
+// WithPrefixPolicy restricts the prefixes Format picks on its own, e.g. to IEC prefixes
+// with unit.PrefixBinary.
+func WithPrefixPolicy(policy unit.PrefixPolicy) FormatOption {
+	return func(o *formatOptions) {
+		o.prefixes = policy
+	}
+}
+
+// WithUnitSeparator writes sep between each value and its unit ("1.5 GiB").
+func WithUnitSeparator(sep string) FormatOption {
+	return func(o *formatOptions) {
+		o.unitSep = sep
+	}
+}
+
+// Format renders a value in base units as a human-readable string, the inverse of Parse.
+//
+// It picks the largest registered unit (with prefix) whose scale does not exceed the
+// magnitude of val, so that e.g. 5400e9 ns formats as "1.5h" and 8192 bits as "1KB".
+// Values smaller than every unit use the smallest one; zero uses the base unit if any.
+// Aliases are rendered with their display symbol (see unit.System.SetDisplaySymbol).
+// A preferred unit of the dimension (see unit.System.SetPreferredUnit) is used instead
+// unless WithUnits or WithCompound is given. Non-linear units (see unit.WithOffset and
+// unit.System.AddFunc) are only picked this way or through WithUnits, never automatically.
+//
+// Negative values are written so that Parse reads them back: a compound value gets a
+// single leading sign under unit.SignLeading ("-1h30m") and a sign per part under
+// unit.SignPerPart ("-1h-30m"). Systems that do not allow negatives reject them.
+//
+// The System's SystemConfig.Format sets the defaults of the options.
+func Format[N Number](val N, sys *unit.System, opts ...FormatOption) (string, error) {
+	defaults := sys.Config.Format
+	o := formatOptions{precision: -1, prefixes: defaults.PrefixPolicy, unitSep: defaults.UnitSeparator}
+	if defaults.FixedPrecision {
+		o.precision = defaults.Precision
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	candidates, err := formatCandidates(sys, &o)
+	if err != nil {
+		return "", err
+	}
+
+	v := float64(val)
+	mag, exact := exactMagnitude(val)
+	if v < 0 && sys.Config.NegativePolicy != unit.AllowNegative {
+		return "", fmt.Errorf("%w: %g cannot be formatted for a System that does not allow negatives", ErrNegative, v)
+	}
+	if o.compound && v != 0 {
+		for _, c := range candidates {
+			if !c.Linear() {
+				return "", fmt.Errorf("unit %s is not linear and cannot be used in a compound format", c.Symbol)
+			}
+		}
+		return formatCompound(v, mag, exact, sys, candidates, &o)
+	}
+	best := candidates[0]
+	for _, c := range candidates {
+		if v == 0 {
+			if c.Scale == 1 {
+				best = c
+				break
+			}
+			continue
+		}
+		if scaleFits(c.Scale, v, mag, exact) && c.Scale > best.Scale {
+			best = c
+		}
+	}
+
+	scaled := roundTo(cleanFloat(best.FromBaseValue(v)), o.precision)
+	if o.layout != "" {
+		return FormatTemplate(o.layout, scaled, best.Symbol)
+	}
+	if n, ok := integralScale(best.Scale); exact && ok && best.Linear() {
+		return signOfValue(v) + formatQuotient(mag, n, o.precision) + o.unitSep + best.Symbol, nil
+	}
+	return strconv.FormatFloat(scaled, 'f', o.precision, 64) + o.unitSep + best.Symbol, nil
+}
+
+// exactMagnitude returns the magnitude of an integer val beyond maxExactFloat, where
+// float64(val) drops digits, so Format can divide it exactly. ok is false otherwise.
+func exactMagnitude[N Number](val N) (uint64, bool) {
+	if !isIntegerType[N]() {
+		return 0, false
+	}
+	var mag uint64
+	if val < 0 {
+		mag = -uint64(int64(val)) // two's complement, so math.MinInt64 works too
+	} else {
+		mag = uint64(val)
+	}
+	return mag, mag >= maxExactFloat
+}
+
+// integralScale returns scale as an integer if it is one.
+func integralScale(scale float64) (uint64, bool) {
+	if scale < 1 || scale >= 1<<64 || scale != math.Trunc(scale) {
+		return 0, false
+	}
+	return uint64(scale), true
+}
+
+// scaleFits reports whether a unit of the given scale does not exceed the magnitude of v,
+// comparing integers exactly when exact is set (see exactMagnitude).
+func scaleFits(scale, v float64, mag uint64, exact bool) bool {
+	if n, ok := integralScale(scale); exact && ok {
+		return n <= mag
+	}
+	return scale <= math.Abs(v)
+}
+
+// signOfValue returns "-" for negative v.
+func signOfValue(v float64) string {
+	if v < 0 {
+		return "-"
+	}
+	return ""
+}
+
+// formatQuotient renders mag/scale with the given number of decimals (shortest if
+// negative), taking the integer part by integer division so no digit is lost.
+func formatQuotient(mag, scale uint64, decimals int) string {
+	q, r := mag/scale, mag%scale
+	frac := float64(r) / float64(scale)
+	var fs string
+	if decimals < 0 {
+		fs = strconv.FormatFloat(cleanFloat(frac), 'f', -1, 64)
+	} else {
+		fs = strconv.FormatFloat(frac, 'f', decimals, 64)
+	}
+	if fs[0] == '1' {
+		// The fraction rounded up to the next integer.
+		q++
+	}
+	return strconv.FormatUint(q, 10) + fs[1:]
+}
+
+// formatCompound renders v greedily in the candidates (smallest scale first), placing
+// signs according to the System's SignPolicy.
+// Exact integers (see exactMagnitude) are split by integer division while the scales are integral.
+func formatCompound(v float64, mag uint64, exact bool, sys *unit.System, candidates []unit.DisplayUnit, o *formatOptions) (string, error) {
+	if !sys.Config.AllowMultiPart {
+		return "", errors.New("compound format needs a System with AllowMultiPart")
+	}
+	if o.layout != "" {
+		return "", errors.New("WithLayout cannot be combined with WithCompound")
+	}
+
+	sign := ""
+	if v < 0 {
+		sign = "-"
+	}
+	var b strings.Builder
+	if sys.Config.SignPolicy == unit.SignLeading {
+		b.WriteString(sign)
+		sign = ""
+	}
+	rest, parts := math.Abs(v), 0
+	partSep := "" // written before every part but the first
+	for i := len(candidates) - 1; i >= 0; i-- {
+		c := candidates[i]
+		if scale, ok := integralScale(c.Scale); exact && ok {
+			if i == 0 {
+				b.WriteString(partSep + sign + formatQuotient(mag, scale, o.precision) + o.unitSep + c.Symbol)
+				break
+			}
+			n := mag / scale
+			mag %= scale
+			if n > 0 {
+				b.WriteString(partSep + sign + strconv.FormatUint(n, 10) + o.unitSep + c.Symbol)
+				parts++
+				partSep = o.unitSep
+			}
+			if mag == 0 {
+				break
+			}
+			continue
+		}
+		if exact {
+			// The rest is small enough for float64 below a non-integral scale.
+			exact, rest = false, float64(mag)
+		}
+		var n float64
+		if i == 0 {
+			n = roundTo(cleanFloat(rest/c.Scale), o.precision)
+		} else {
+			n = math.Floor(cleanFloat(rest / c.Scale))
+			rest = cleanFloat(rest - n*c.Scale)
+		}
+		if n == 0 && (i > 0 || parts > 0) {
+			continue
+		}
+		prec := -1
+		if i == 0 {
+			prec = o.precision
+		}
+		b.WriteString(partSep + sign + strconv.FormatFloat(n, 'f', prec, 64) + o.unitSep + c.Symbol)
+		parts++
+		partSep = o.unitSep
+	}
+	return b.String(), nil
+}
+
+// roundTo rounds f to the given number of decimals (none if negative). A zero result
+// is returned as +0, so that e.g. -0.001 with two decimals renders as "0.00", not "-0.00".
+func roundTo(f float64, decimals int) float64 {
+	if decimals >= 0 {
+		f = math.Round(f*math.Pow10(decimals)) / math.Pow10(decimals)
+	}
+	if f == 0 {
+		return 0
+	}
+	return f
+}
+
+// formatCandidates returns the display units Format may choose from, smallest scale first.
+func formatCandidates(sys *unit.System, o *formatOptions) ([]unit.DisplayUnit, error) {
+	all := sys.DisplayUnits()
+
+	if len(o.units) > 0 {
+		var picked []unit.DisplayUnit
+		for _, symbol := range o.units {
+			u, prefixScale, found := sys.Resolve(symbol)
+			if !found {
+				return nil, unknownUnit(sys, symbol)
+			}
+			_, fromBase := u.Funcs()
+			picked = append(picked, unit.DisplayUnit{Symbol: symbol, Scale: prefixScale * u.Scale, Offset: u.Offset, Dimension: u.Dimension, FromBase: fromBase})
+		}
+		all = picked
+	}
+
+	dim := o.dim
+	if dim == nil {
+		for i := range all {
+			if !all[i].Dimension.Equals(all[0].Dimension) {
+				return nil, errors.New("system has several dimensions, use WithDimension")
+			}
+		}
+		if len(all) > 0 {
+			dim = &all[0].Dimension
+		}
+	}
+
+	if dim != nil && len(o.units) == 0 && !o.compound {
+		if symbol, ok := sys.PreferredUnit(*dim); ok {
+			if r, found := sys.ResolveFull(symbol); found {
+				_, fromBase := r.Unit.Funcs()
+				return []unit.DisplayUnit{{Symbol: symbol, Scale: r.Scale(), Offset: r.Unit.Offset, Dimension: *dim, FromBase: fromBase}}, nil
+			}
+		}
+	}
+
+	// Non-linear units ("°C", "dB") are only used when asked for by name.
+	var out []unit.DisplayUnit
+	for _, c := range all {
+		if len(o.units) == 0 && (!c.Linear() || !o.prefixes.Allows(c)) {
+			continue
+		}
+		if dim != nil && c.Dimension.Equals(*dim) && c.Scale > 0 {
+			out = append(out, c)
+		}
+	}
+	if len(out) == 0 {
+		return nil, errors.New("no units available to format with")
+	}
+
+	// Smallest scale first; Format only replaces a candidate with a strictly larger one,
+	// so among equal scales the earlier (shorter, or explicitly listed first) symbol wins.
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Scale < out[j].Scale
+	})
+	return out, nil
+}
+
+// cleanFloat drops binary noise beyond 15 significant digits (e.g. 1.2000000000000002 -> 1.2).
+func cleanFloat(f float64) float64 {
+	clean, err := strconv.ParseFloat(strconv.FormatFloat(f, 'g', 15, 64), 64)
+	if err != nil {
+		return f
+	}
+	return clean
+}
@@ -0,0 +1,370 @@
+package parser_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func createFormatTimeSystem() *unit.System {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true})
+	sys.Add("ns", 1, unit.DimTime)
+	sys.Add("us", 1e3, unit.DimTime)
+	sys.AddAlias("µs", "us")
+	sys.Add("ms", 1e6, unit.DimTime)
+	sys.Add("s", 1e9, unit.DimTime)
+	sys.Add("m", 60e9, unit.DimTime)
+	sys.Add("h", 3600e9, unit.DimTime)
+	sys.SetDisplaySymbol("µs")
+	return sys
+}
+
+func createFormatStorageSystem() *unit.System {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("b", 1, unit.DimStorage)
+	sys.Add("B", 8, unit.DimStorage)
+	sys.AddAlias("Byte", "B")
+	for i, p := range []string{"Ki", "Mi", "Gi"} {
+		sys.AddPrefix(p, float64(int64(1)<<(10*(i+1))), "b", "B")
+	}
+	return sys
+}
+
+func TestFormat(t *testing.T) {
+	timeSys := createFormatTimeSystem()
+	storageSys := createFormatStorageSystem()
+
+	tests := []struct {
+		name string
+		sys  *unit.System
+		val  float64
+		opts []parser.FormatOption
+		want string
+	}{
+		{"Hours", timeSys, 5400e9, nil, "1.5h"},
+		{"Seconds", timeSys, 1e9, nil, "1s"},
+		{"Display symbol", timeSys, 1500, nil, "1.5µs"},
+		{"Below smallest unit", timeSys, 0.5, nil, "0.5ns"},
+		{"Zero uses base", timeSys, 0, nil, "0ns"},
+		{"Negative", timeSys, -90e9, nil, "-1.5m"},
+		{"Precision", timeSys, 1e9 / 3, []parser.FormatOption{parser.WithPrecision(2)}, "333.33ms"},
+		{"Layout", timeSys, 5400e9, []parser.FormatOption{parser.WithLayout("{value:%.2f} {unit}")}, "1.50 h"},
+		{"Restricted units", timeSys, 5400e9, []parser.FormatOption{parser.WithUnits("s", "m")}, "90m"},
+
+		{"Bytes", storageSys, 8, nil, "1B"},
+		{"KiB over Kib", storageSys, 8 * 1024, nil, "1KiB"},
+		{"Bits", storageSys, 1024, nil, "1Kib"},
+		{"Fraction", storageSys, 1.5 * 8 * (1 << 30), nil, "1.5GiB"},
+		{"Alias not rendered", storageSys, 16, []parser.FormatOption{parser.WithUnits("B")}, "2B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parser.Format(tt.val, tt.sys, tt.opts...)
+			if err != nil {
+				t.Fatalf("Format(%g) unexpected error: %v", tt.val, err)
+			}
+			if got != tt.want {
+				t.Errorf("Format(%g) = %q, want %q", tt.val, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat_Errors(t *testing.T) {
+	mixed := createTestSystem() // Time and length units
+	if _, err := parser.Format(1.0, mixed); err == nil {
+		t.Error("Format on a multi-dimension system expected error, got nil")
+	}
+	if got, err := parser.Format(1.0, mixed, parser.WithDimension(unit.DimLength)); err != nil || got != "1meter" {
+		t.Errorf("Format(WithDimension) = %q, %v; want %q", got, err, "1meter")
+	}
+	if _, err := parser.Format(1.0, mixed, parser.WithUnits("x")); err == nil {
+		t.Error("Format with unknown unit expected error, got nil")
+	}
+	if _, err := parser.Format(1.0, unit.NewSystem(unit.SystemConfig{})); err == nil {
+		t.Error("Format on an empty system expected error, got nil")
+	}
+}
+
+func TestFormat_RoundTrip(t *testing.T) {
+	sys := createFormatTimeSystem()
+	for _, want := range []int64{1, 999, 1500, 2e6, 45e9, 5400e9, 7 * 3600e9} {
+		s, err := parser.Format(want, sys)
+		if err != nil {
+			t.Fatalf("Format(%d) unexpected error: %v", want, err)
+		}
+		got, _, err := parser.Parse[int64](s, sys)
+		if err != nil || got != want {
+			t.Errorf("Parse(Format(%d) = %q) = %d, %v", want, s, got, err)
+		}
+	}
+}
+
+func TestFormat_Negative(t *testing.T) {
+	leading := createFormatTimeSystem()
+	leading.Config.SignPolicy = unit.SignLeading
+	perPart := createFormatTimeSystem()
+	storageSys := createFormatStorageSystem()
+
+	tests := []struct {
+		name string
+		sys  *unit.System
+		val  float64
+		opts []parser.FormatOption
+		want string
+	}{
+		{"Compound", leading, 5400e9, []parser.FormatOption{parser.WithCompound()}, "1h30m"},
+		{"Leading sign", leading, -5400e9, []parser.FormatOption{parser.WithCompound()}, "-1h30m"},
+		{"Sign per part", perPart, -5400e9, []parser.FormatOption{parser.WithCompound()}, "-1h-30m"},
+		{"Compound fraction", leading, -90.5e9, []parser.FormatOption{parser.WithCompound()}, "-1m30s500ms"},
+		{"Compound below smallest unit", leading, -0.5, []parser.FormatOption{parser.WithCompound()}, "-0.5ns"},
+		{"Negative storage", storageSys, -512 * 8 * (1 << 20), []parser.FormatOption{parser.WithUnits("KiB", "MiB", "GiB")}, "-512MiB"},
+		{"Negative zero", leading, math.Copysign(0, -1), nil, "0ns"},
+		{"Rounds to zero", leading, -1, []parser.FormatOption{parser.WithPrecision(2), parser.WithUnits("s")}, "0.00s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parser.Format(tt.val, tt.sys, tt.opts...)
+			if err != nil {
+				t.Fatalf("Format(%g) unexpected error: %v", tt.val, err)
+			}
+			if got != tt.want {
+				t.Errorf("Format(%g) = %q, want %q", tt.val, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat_NegativeRoundTrip(t *testing.T) {
+	leading := createFormatTimeSystem()
+	leading.Config.SignPolicy = unit.SignLeading
+	for _, sys := range []*unit.System{leading, createFormatTimeSystem()} {
+		// 7h + 1ns has no exact single-unit form, only a compound one.
+		for _, want := range []int64{-1, -1500, -45e9, -5400e9, -(7*3600e9 + 1)} {
+			for _, opts := range [][]parser.FormatOption{nil, {parser.WithCompound()}} {
+				if opts == nil && want == -(7*3600e9+1) {
+					continue
+				}
+				s, err := parser.Format(want, sys, opts...)
+				if err != nil {
+					t.Fatalf("Format(%d) unexpected error: %v", want, err)
+				}
+				got, _, err := parser.Parse[int64](s, sys)
+				if err != nil || got != want {
+					t.Errorf("Parse(Format(%d) = %q) = %d, %v", want, s, got, err)
+				}
+			}
+		}
+	}
+
+	rejecting := createFormatStorageSystem()
+	rejecting.Config.NegativePolicy = unit.RejectNegative
+	if _, err := parser.Format(-8.0, rejecting); !errors.Is(err, parser.ErrNegative) {
+		t.Errorf("Format(-8) with RejectNegative error = %v, want ErrNegative", err)
+	}
+	if _, err := parser.Format(-8.0, rejecting, parser.WithCompound()); err == nil {
+		t.Error("Format with WithCompound on a single-part system expected error, got nil")
+	}
+}
+
+func TestFormat_PreferredUnit(t *testing.T) {
+	sys := createTestSystem() // Time and length units
+	if err := sys.SetPreferredUnit(unit.DimTime, "s"); err != nil {
+		t.Fatalf("SetPreferredUnit(time, s) error: %v", err)
+	}
+	if err := sys.SetPreferredUnit(unit.DimLength, "mmeter"); err != nil {
+		t.Fatalf("SetPreferredUnit(length, mmeter) error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		val  float64
+		opts []parser.FormatOption
+		want string
+	}{
+		{"Preferred time", 7200, []parser.FormatOption{parser.WithDimension(unit.DimTime)}, "7200s"},
+		{"Preferred prefixed", 1.5, []parser.FormatOption{parser.WithDimension(unit.DimLength)}, "1500mmeter"},
+		{"WithUnits wins", 7200, []parser.FormatOption{parser.WithDimension(unit.DimTime), parser.WithUnits("h")}, "2h"},
+		{"WithCompound wins", 5400, []parser.FormatOption{parser.WithDimension(unit.DimTime), parser.WithCompound()}, "1h30m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parser.Format(tt.val, sys, tt.opts...)
+			if err != nil {
+				t.Fatalf("Format(%g) unexpected error: %v", tt.val, err)
+			}
+			if got != tt.want {
+				t.Errorf("Format(%g) = %q, want %q", tt.val, got, tt.want)
+			}
+		})
+	}
+
+	if err := sys.SetPreferredUnit(unit.DimTime, "meter"); err == nil {
+		t.Error("SetPreferredUnit(time, meter) expected dimension error")
+	}
+	if err := sys.SetPreferredUnit(unit.DimTime, "fortnight"); err == nil {
+		t.Error("SetPreferredUnit(time, fortnight) expected unknown unit error")
+	}
+}
+
+func TestFormat_OffsetUnits(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true, NegativePolicy: unit.AllowNegative})
+	sys.Add("K", 1, unit.DimTemp)
+	sys.Add("°C", 1, unit.DimTemp, unit.WithOffset(273.15))
+
+	tests := []struct {
+		val  float64
+		opts []parser.FormatOption
+		want string
+	}{
+		{293.15, nil, "293.15K"}, // never picked automatically
+		{293.15, []parser.FormatOption{parser.WithUnits("°C")}, "20°C"},
+		{233.15, []parser.FormatOption{parser.WithUnits("°C")}, "-40°C"},
+	}
+	for _, tt := range tests {
+		got, err := parser.Format(tt.val, sys, tt.opts...)
+		if err != nil || got != tt.want {
+			t.Errorf("Format(%g) = %q, %v, want %q", tt.val, got, err, tt.want)
+		}
+	}
+
+	if err := sys.SetPreferredUnit(unit.DimTemp, "°C"); err != nil {
+		t.Fatalf("SetPreferredUnit(°C) error: %v", err)
+	}
+	got, err := parser.Format(300.0, sys)
+	if err != nil || got != "26.85°C" {
+		t.Errorf("Format(300) with preferred °C = %q, %v, want 26.85°C", got, err)
+	}
+	if back, _, err := parser.Parse[float64](got, sys); err != nil || math.Abs(back-300) > 1e-9 {
+		t.Errorf("Parse(%q) = %v, %v, want 300", got, back, err)
+	}
+	if _, err := parser.Format(300.0, sys, parser.WithUnits("K", "°C"), parser.WithCompound()); err == nil {
+		t.Error("Format with compound offset units expected error, got nil")
+	}
+}
+
+func TestFormat_ExactIntegers(t *testing.T) {
+	timeSys := createFormatTimeSystem()
+
+	tests := []struct {
+		val  int64
+		opts []parser.FormatOption
+		want string
+	}{
+		{9007199254740993, []parser.FormatOption{parser.WithUnits("ns")}, "9007199254740993ns"},
+		{9007199254740993, []parser.FormatOption{parser.WithUnits("s")}, "9007199.254740993s"},
+		{math.MaxInt64, []parser.FormatOption{parser.WithUnits("s")}, "9223372036.854775807s"},
+		{math.MinInt64, []parser.FormatOption{parser.WithUnits("s")}, "-9223372036.854775808s"},
+		{9007199254740993, []parser.FormatOption{parser.WithUnits("s"), parser.WithPrecision(2)}, "9007199.25s"},
+		{9007199999999999, []parser.FormatOption{parser.WithUnits("s"), parser.WithPrecision(2)}, "9007200.00s"},
+		{math.MaxInt64, []parser.FormatOption{parser.WithCompound()}, "2562047h47m16s854ms775µs807ns"},
+		{-9007199254740993, []parser.FormatOption{parser.WithCompound()}, "-2501h-59m-59s-254ms-740µs-993ns"},
+	}
+	for _, tt := range tests {
+		got, err := parser.Format(tt.val, timeSys, tt.opts...)
+		if err != nil {
+			t.Errorf("Format(%d) unexpected error: %v", tt.val, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Format(%d) = %q, want %q", tt.val, got, tt.want)
+		}
+		if len(tt.opts) == 1 {
+			if back, _, err := parser.Parse[int64](got, timeSys); err != nil || back != tt.val {
+				t.Errorf("Parse(%q) = %d, %v; want %d", got, back, err, tt.val)
+			}
+		}
+	}
+}
+
+func TestFormat_SystemDefaults(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true})
+	sys.Add("B", 1, unit.DimStorage)
+	sys.AddPrefix("k", 1000, "B")
+	sys.AddPrefix("M", 1e6, "B")
+	sys.AddPrefix("Ki", 1024, "B")
+	sys.AddPrefix("Mi", 1<<20, "B")
+
+	tests := []struct {
+		name   string
+		format unit.FormatConfig
+		val    float64
+		opts   []parser.FormatOption
+		want   string
+	}{
+		{"No defaults", unit.FormatConfig{}, 1536, nil, "1.5KiB"},
+		{"Decimal prefixes", unit.FormatConfig{PrefixPolicy: unit.PrefixDecimal}, 1536, nil, "1.536kB"},
+		{"Binary prefixes", unit.FormatConfig{PrefixPolicy: unit.PrefixBinary}, 2e6, nil, "1.9073486328125MiB"},
+		{"No prefixes", unit.FormatConfig{PrefixPolicy: unit.PrefixNone}, 2e6, nil, "2000000B"},
+		{"Precision", unit.FormatConfig{Precision: 2, FixedPrecision: true}, 1000, nil, "1.00kB"},
+		{"Zero decimals", unit.FormatConfig{FixedPrecision: true}, 1536, nil, "2KiB"},
+		{"Unit separator", unit.FormatConfig{UnitSeparator: " "}, 1536, nil, "1.5 KiB"},
+		{"Compound separator", unit.FormatConfig{UnitSeparator: " ", PrefixPolicy: unit.PrefixDecimal}, 1001500, []parser.FormatOption{parser.WithCompound()}, "1 MB 1 kB 500 B"},
+		{"Option overrides", unit.FormatConfig{PrefixPolicy: unit.PrefixDecimal, UnitSeparator: " "}, 1536, []parser.FormatOption{parser.WithPrefixPolicy(unit.PrefixAny), parser.WithUnitSeparator("")}, "1.5KiB"},
+		{"Precision option", unit.FormatConfig{Precision: 2, FixedPrecision: true}, 1536, []parser.FormatOption{parser.WithPrecision(1)}, "1.5KiB"},
+		{"Named units", unit.FormatConfig{PrefixPolicy: unit.PrefixNone}, 2048, []parser.FormatOption{parser.WithUnits("KiB")}, "2KiB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sys.Config.Format = tt.format
+			got, err := parser.Format(tt.val, sys, tt.opts...)
+			if err != nil {
+				t.Fatalf("Format(%g) unexpected error: %v", tt.val, err)
+			}
+			if got != tt.want {
+				t.Errorf("Format(%g) = %q, want %q", tt.val, got, tt.want)
+			}
+		})
+	}
+
+	// Separated compound values read back.
+	if back, _, err := parser.Parse[float64]("1 MB 1 kB 500 B", sys); err != nil || back != 1001500 {
+		t.Errorf("Parse(1 MB 1 kB 500 B) = %g, %v; want 1001500", back, err)
+	}
+}
+
+// meters implements fmt.Formatter through FormatState.
+type meters float64
+
+func (m meters) Format(state fmt.State, verb rune) {
+	parser.FormatState(state, verb, float64(m), lengthSystem)
+}
+
+var lengthSystem = func() *unit.System {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("m", 1, unit.DimLength)
+	sys.AddPrefix("k", 1000, "m")
+	sys.AddPrefix("c", 0.01, "m")
+	return sys
+}()
+
+func TestFormatState(t *testing.T) {
+	tests := []struct {
+		format string
+		val    meters
+		want   string
+	}{
+		{"%v", 1500, "1.5km"},
+		{"%s", 0.25, "25cm"},
+		{"%.2v", 1500, "1.50km"},
+		{"%.1f", 1234, "1.2km"},
+		{"%f", 1500, "1.500000km"},
+		{"%q", 1500, `"1.5km"`},
+		{"%8v|", 1500, "   1.5km|"},
+		{"%-8v|", 1500, "1.5km   |"},
+		{"%#v", 1500, "1.5km (1500m, " + unit.DimLength.String() + ")"},
+		{"%g", 1500, "1500"},
+		{"%e", 1500, "1.500000e+03"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, tt.val); got != tt.want {
+			t.Errorf("Sprintf(%q, %g) = %q, want %q", tt.format, float64(tt.val), got, tt.want)
+		}
+	}
+}
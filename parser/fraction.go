@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"unicode/utf8"
+)
+
+// vulgarFractions maps Unicode vulgar fraction characters to numerator and denominator.
+var vulgarFractions = map[rune][2]uint64{
+	'¼': {1, 4}, '½': {1, 2}, '¾': {3, 4},
+	'⅐': {1, 7}, '⅑': {1, 9}, '⅒': {1, 10},
+	'⅓': {1, 3}, '⅔': {2, 3},
+	'⅕': {1, 5}, '⅖': {2, 5}, '⅗': {3, 5}, '⅘': {4, 5},
+	'⅙': {1, 6}, '⅚': {5, 6},
+	'⅛': {1, 8}, '⅜': {3, 8}, '⅝': {5, 8}, '⅞': {7, 8},
+}
+
+// scanFraction reads a fraction at the beginning of s: "1/2", "½", or a mixed number
+// such as "1 1/2" or "1½". It reports ok=false, without error, if s starts with a plain
+// number, which is then left to scanNumber. The result is exact; n is the bytes consumed.
+func scanFraction(s string) (r rat, n int, ok bool, err error) {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		r.neg = s[i] == '-'
+		i++
+	}
+
+	// Leading integer: either the whole part of a mixed number or the numerator.
+	d := digitRun(s[i:])
+	lead := s[i : i+d]
+	i += d
+
+	if d > 0 && i < len(s) && s[i] == '/' {
+		// Simple fraction "3/4".
+		den := digitRun(s[i+1:])
+		if den == 0 {
+			return rat{}, 0, false, nil
+		}
+		r, err = newFraction(r.neg, "0", lead, s[i+1:i+1+den])
+		return r, i + 1 + den, err == nil, err
+	}
+
+	// Mixed number: the whole part may be followed by spaces ("1 1/2", "1 ½").
+	j := i
+	for d > 0 && j < len(s) && (s[j] == ' ' || s[j] == '\t') {
+		j++
+	}
+	vr, size := utf8.DecodeRuneInString(s[j:])
+	if f, isVulgar := vulgarFractions[vr]; isVulgar {
+		whole := lead
+		if whole == "" {
+			whole = "0"
+		}
+		r, err = newFraction(r.neg, whole, strconv.FormatUint(f[0], 10), strconv.FormatUint(f[1], 10))
+		return r, j + size, err == nil, err
+	}
+	if j > i {
+		num := digitRun(s[j:])
+		if num > 0 && j+num < len(s) && s[j+num] == '/' {
+			if den := digitRun(s[j+num+1:]); den > 0 {
+				end := j + num + 1 + den
+				r, err = newFraction(r.neg, lead, s[j:j+num], s[j+num+1:end])
+				return r, end, err == nil, err
+			}
+		}
+	}
+	return rat{}, 0, false, nil
+}
+
+// newFraction builds the rational whole + num/den from decimal digit strings.
+func newFraction(neg bool, whole, num, den string) (rat, error) {
+	w, errW := strconv.ParseUint(whole, 10, 64)
+	n, errN := strconv.ParseUint(num, 10, 64)
+	d, errD := strconv.ParseUint(den, 10, 64)
+	if err := errors.Join(errW, errN, errD); err != nil {
+		return rat{}, fmt.Errorf("%w: %w", errIntOverflow, err)
+	}
+	if d == 0 {
+		return rat{}, fmt.Errorf("%w: zero denominator in %s/%s", ErrInvalidNumber, num, den)
+	}
+	hi, lo := bits.Mul64(w, d)
+	total, carry := bits.Add64(lo, n, 0)
+	if hi != 0 || carry != 0 {
+		return rat{}, errIntOverflow
+	}
+	return rat{neg: neg, num: total, den: d}, nil
+}
+
+// float64 returns the signed numerator and the denominator as floats, so callers can
+// scale the numerator before dividing ("1/3 h" = 3600/3 s exactly).
+func (r rat) float64() (num, den float64) {
+	num = float64(r.num)
+	if r.neg {
+		num = -num
+	}
+	return num, float64(r.den)
+}
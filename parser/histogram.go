@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// Histogram counts quantity strings into buckets bounded by quantities of one dimension,
+// e.g. sizes into "1KiB", "1MiB", "1GiB" buckets. It is not safe for concurrent use.
+type Histogram struct {
+	sys    *unit.System
+	opts   []ParseOption
+	dim    unit.Dimension
+	bounds []float64 // ascending upper bounds in base units
+	counts []int
+}
+
+// NewHistogram parses the ascending bucket upper bounds. Bucket i counts values v with
+// bounds[i-1] < v <= bounds[i]; a final bucket counts values above the last bound.
+// All bounds must share one dimension, which Add then requires of every value.
+func NewHistogram(sys *unit.System, bounds []string, opts ...ParseOption) (*Histogram, error) {
+	if len(bounds) == 0 {
+		return nil, fmt.Errorf("histogram: no bucket bounds")
+	}
+	h := &Histogram{sys: sys, opts: opts, bounds: make([]float64, len(bounds)), counts: make([]int, len(bounds)+1)}
+	for i, b := range bounds {
+		v, dim, err := Parse[float64](b, sys, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("histogram bound %q: %w", b, err)
+		}
+		if i == 0 {
+			h.dim = dim
+		} else if !dim.Equals(h.dim) {
+			return nil, fmt.Errorf("histogram bound %q: %w", b, &MixedDimensionsError{First: h.dim, Second: dim})
+		} else if v <= h.bounds[i-1] {
+			return nil, fmt.Errorf("histogram bound %q is not above %q", b, bounds[i-1])
+		}
+		h.bounds[i] = v
+	}
+	return h, nil
+}
+
+// Add parses s and increments the count of its bucket. Values of another dimension
+// are rejected with a *DimensionError and not counted.
+func (h *Histogram) Add(s string) error {
+	v, dim, err := Parse[float64](s, h.sys, h.opts...)
+	if err != nil {
+		return err
+	}
+	if !dim.Equals(h.dim) {
+		return &DimensionError{Want: h.dim, Got: dim}
+	}
+	h.counts[sort.SearchFloat64s(h.bounds, v)]++
+	return nil
+}
+
+// Counts returns the counts per bucket; it has one more entry than the bounds,
+// the last one counting values above the highest bound.
+func (h *Histogram) Counts() []int {
+	return append([]int(nil), h.counts...)
+}
+
+// Bounds returns the bucket upper bounds in base units.
+func (h *Histogram) Bounds() []float64 {
+	return append([]float64(nil), h.bounds...)
+}
@@ -0,0 +1,63 @@
+package parser_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func newHistogramSystem() *unit.System {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("B", 1, unit.DimStorage)
+	sys.Add("s", 1, unit.DimTime)
+	sys.AddPrefix("Ki", 1024, "B")
+	sys.AddPrefix("Mi", 1<<20, "B")
+	return sys
+}
+
+func TestHistogram(t *testing.T) {
+	sys := newHistogramSystem()
+	h, err := parser.NewHistogram(sys, []string{"1KiB", "1MiB"})
+	if err != nil {
+		t.Fatalf("NewHistogram unexpected error: %v", err)
+	}
+
+	for _, s := range []string{"10B", "1KiB", "1025B", "512KiB", "1MiB", "2MiB"} {
+		if err := h.Add(s); err != nil {
+			t.Errorf("Add(%q) unexpected error: %v", s, err)
+		}
+	}
+	var dimErr *parser.DimensionError
+	if err := h.Add("5s"); !errors.As(err, &dimErr) {
+		t.Errorf("Add(%q) error = %v, want *DimensionError", "5s", err)
+	}
+	if err := h.Add("5xyz"); err == nil {
+		t.Errorf("Add(%q) expected error, got nil", "5xyz")
+	}
+
+	if got, want := h.Counts(), []int{2, 3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Counts() = %v, want %v", got, want)
+	}
+	if got, want := h.Bounds(), []float64{1024, 1 << 20}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Bounds() = %v, want %v", got, want)
+	}
+}
+
+func TestNewHistogramErrors(t *testing.T) {
+	sys := newHistogramSystem()
+	tests := [][]string{
+		nil,
+		{"1MiB", "1KiB"},
+		{"1KiB", "1KiB"},
+		{"1KiB", "5s"},
+		{"1KiB", "lots"},
+	}
+	for _, bounds := range tests {
+		if _, err := parser.NewHistogram(sys, bounds); err == nil {
+			t.Errorf("NewHistogram(%q) expected error, got nil", bounds)
+		}
+	}
+}
@@ -0,0 +1,464 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// errIntOverflow reports that an exact integer computation exceeded int64.
+var errIntOverflow = fmt.Errorf("%w: value overflows int64", ErrOverflow)
+
+// pow10 holds the powers of ten representable in an int64.
+var pow10 = [...]uint64{
+	1, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18,
+}
+
+// ParseInt parses a string into an int64 count of base units using integer arithmetic only.
+//
+// Parts are read and checked like in Parse; only their arithmetic differs. Numbers are
+// read as exact decimals, without strconv.ParseFloat, and unit/prefix scales and offsets
+// are taken from the bits of their float64 as decimal fractions (n/10^k), without float
+// operations. So values beyond 2^53 (e.g. "9007199254740993ns") stay exact, and the
+// result does not depend on floating point hardware, which suits TinyGo and embedded
+// targets. Scales and offsets that are not decimal fractions (e.g. 1/3600) and function
+// units (see unit.System.AddFunc) are an error of the part. Values are converted to
+// float64 only to be passed to float64 APIs: constraints (see unit.Constraint),
+// ParseObserver and the Value of errors.
+//
+// Like Parse[int64], fractional results are handled by SystemConfig.PrecisionPolicy
+// (a precision-loss error by default); results beyond the int64 range are an overflow
+// error. Epsilon does not apply, since the arithmetic is exact.
+//
+// Options override the System configuration for this call only (see ParseOption).
+func ParseInt(s string, sys *unit.System, opts ...ParseOption) (int64, unit.Dimension, error) {
+	l := partLoop{exact: true}
+	if err := l.init(s, sys, opts, nil); err != nil {
+		return 0, unit.Dimension{}, err
+	}
+	cfg := &l.cfg
+
+	var total int64
+	dim, err := l.run(func(p scannedPart) error {
+		// Scale exactly: Value * PrefixScale * UnitScale + Offset
+		partN, err := exactInt64(p.r, p.scale, p.offset, cfg.PrecisionPolicy)
+		neg := p.r.neg != p.scale.neg
+		var sum int64
+		if err == nil {
+			sum, err = addInt64(total, partN)
+			neg = partN < 0
+		}
+		if errors.Is(err, ErrOverflow) && cfg.OverflowPolicy == unit.OverflowSaturate {
+			sum, err = saturatedInt64(neg), nil
+		}
+		if err != nil {
+			return err
+		}
+		total = sum
+		return nil
+	})
+	if err != nil {
+		return 0, dim, err
+	}
+	if err := l.finish(float64(total)); err != nil {
+		return 0, dim, err
+	}
+	return total, dim, nil
+}
+
+// scanRat reads the number at the beginning of s as an exact rational and returns the
+// bytes it spans. On error, it also returns the token to report if it is known.
+func scanRat(s string, sys *unit.System, cfg *unit.SystemConfig) (rat, int, string, error) {
+	val, n, isExact, err := scanExact(s, cfg)
+	if err != nil || isExact {
+		return val, n, "", err
+	}
+	tok, n, err := scanNumber(s, sys, cfg)
+	if err != nil {
+		return rat{}, 0, "", err
+	}
+	val, err = parseDecimal(tok)
+	return val, n, tok, err
+}
+
+// maxExactFloat is 2^53, beyond which float64 cannot represent every integer.
+const maxExactFloat = 1 << 53
+
+// exactPart recomputes a part of Parse with integer arithmetic if N is an integer type
+// and the float64 part value v is beyond maxExactFloat. s starts with the number, neg is
+// its sign after the sign policies. ok is false if the part cannot be computed exactly
+// (e.g. a scale of 1/3600), so the float64 value has to do; err is a precision loss.
+func exactPart[N Number](s string, sys *unit.System, cfg *unit.SystemConfig, neg bool, v float64, unitStr string, u unit.Unit, prefixScale float64) (N, bool, error) {
+	if !isIntegerType[N]() || math.Abs(v) < maxExactFloat {
+		return 0, false, nil
+	}
+	r, _, _, err := scanRat(s, sys, cfg)
+	if err != nil {
+		return 0, false, nil
+	}
+	r.neg = neg
+	scale, offset, err := exactScale(unitStr, u, prefixScale)
+	if err != nil {
+		return 0, false, nil
+	}
+	p, err := exactInt64(r, scale, offset, cfg.PrecisionPolicy)
+	if err != nil {
+		if errors.Is(err, ErrOverflow) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	n := N(p)
+	if int64(n) != p || (n < 0) != (p < 0) {
+		return 0, false, nil
+	}
+	return n, true, nil
+}
+
+// exactScale returns the scale of unit u, prefix included, and its offset as exact
+// rationals. Function units and scales that are not decimal fractions have none.
+func exactScale(unitStr string, u unit.Unit, prefixScale float64) (scale, offset rat, err error) {
+	if toBase, _ := u.Funcs(); toBase != nil {
+		return rat{}, rat{}, fmt.Errorf("unit %s converts through a function and has no exact scale", unitStr)
+	}
+	prefix, ok := ratFromScale(prefixScale)
+	if !ok {
+		return rat{}, rat{}, fmt.Errorf("prefix scale of unit %s is not a decimal fraction", unitStr)
+	}
+	if scale, ok = ratFromScale(u.Scale); !ok {
+		return rat{}, rat{}, fmt.Errorf("scale of unit %s is not a decimal fraction", unitStr)
+	}
+	if offset, ok = ratFromScale(u.Offset); !ok {
+		return rat{}, rat{}, fmt.Errorf("offset of unit %s is not a decimal fraction", unitStr)
+	}
+	scale, err = prefix.mul(scale)
+	return scale, offset, err
+}
+
+// exactInt64 converts r, written in a unit of the given scale and offset (see
+// exactScale), into an int64 count of base units rounded by policy.
+func exactInt64(r, scale, offset rat, policy unit.PrecisionPolicy) (int64, error) {
+	v, err := r.mul(scale)
+	if err == nil {
+		v, err = v.add(offset)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return v.round(policy).int64()
+}
+
+// rat is a non-normalized rational number neg * num / den with den > 0.
+type rat struct {
+	neg      bool
+	num, den uint64
+}
+
+// parseDecimal converts a numeric token (see scanNumber) into an exact rational.
+func parseDecimal(tok string) (rat, error) {
+	r := rat{den: 1}
+	i := 0
+	if i < len(tok) && (tok[i] == '+' || tok[i] == '-') {
+		r.neg = tok[i] == '-'
+		i++
+	}
+
+	// Collect mantissa digits without the dot; exp counts fractional digits.
+	start := i
+	var mantissa []byte
+	exp := 0
+	seenDot := false
+	for ; i < len(tok); i++ {
+		c := tok[i]
+		if c == '.' {
+			seenDot = true
+			continue
+		}
+		if c < '0' || c > '9' {
+			break
+		}
+		mantissa = append(mantissa, c)
+		if seenDot {
+			exp--
+		}
+	}
+	if len(mantissa) == 0 {
+		return rat{}, ErrInvalidNumber
+	}
+	// Trailing zeros only shift the exponent, so "1.000…0" cannot overflow.
+	for len(mantissa) > 0 && mantissa[len(mantissa)-1] == '0' {
+		mantissa = mantissa[:len(mantissa)-1]
+		exp++
+	}
+	for _, c := range mantissa {
+		hi, lo := bits.Mul64(r.num, 10)
+		if hi != 0 || lo+uint64(c-'0') < lo {
+			return rat{}, fmt.Errorf("number %s: %w", tok[start:i], errIntOverflow)
+		}
+		r.num = lo + uint64(c-'0')
+	}
+
+	// Exponent part
+	if i < len(tok) {
+		i++ // 'e' or 'E'
+		expNeg := false
+		if i < len(tok) && (tok[i] == '+' || tok[i] == '-') {
+			expNeg = tok[i] == '-'
+			i++
+		}
+		if i == len(tok) {
+			return rat{}, ErrInvalidNumber
+		}
+		e := 0
+		for ; i < len(tok); i++ {
+			e = e*10 + int(tok[i]-'0')
+			if e > 1000 {
+				return rat{}, errIntOverflow
+			}
+		}
+		if expNeg {
+			e = -e
+		}
+		exp += e
+	}
+
+	if r.num == 0 {
+		// Keep the sign of "-0" for SignedZero.
+		return rat{neg: r.neg, den: 1}, nil
+	}
+	for ; exp > 0; exp-- {
+		hi, lo := bits.Mul64(r.num, 10)
+		if hi != 0 {
+			return rat{}, errIntOverflow
+		}
+		r.num = lo
+	}
+	if -exp >= len(pow10) {
+		// Too many fractional digits for any int64 result; report an approximation.
+		v := float64(r.num)
+		for ; exp < 0; exp++ {
+			v /= 10
+		}
+		if r.neg {
+			v = -v
+		}
+		return rat{}, &PrecisionLossError{Value: v}
+	}
+	r.den = pow10[-exp]
+	return r, nil
+}
+
+// ratFromScale converts a float64 scale into the decimal fraction n/10^k with the
+// smallest k that rounds back to it, if there is one with k < len(pow10); so 1e-3 is
+// 1/1000 rather than its binary value. It works on the bits of f with integer
+// arithmetic only.
+func ratFromScale(f float64) (rat, bool) {
+	b := math.Float64bits(f)
+	r := rat{neg: b>>63 != 0, den: 1}
+	exp, mant := int(b>>52&0x7ff), b&(1<<52-1)
+	switch {
+	case exp == 0x7ff:
+		return rat{}, false // Inf, NaN
+	case exp == 0 && mant == 0:
+		return rat{den: 1}, true
+	case exp == 0:
+		exp = 1 // subnormal
+	default:
+		mant |= 1 << 52
+	}
+
+	// f = mant / 2^shift
+	shift := 1075 - exp
+	if shift <= 0 {
+		if -shift > 64-53 {
+			return rat{}, false
+		}
+		r.num = mant << -shift
+		return r, true
+	}
+	if shift >= 128 {
+		return rat{}, false
+	}
+	// The neighbours of f are 2^-shift away, except below a power of two, where the
+	// gap is half as wide.
+	narrowBelow := mant == 1<<52 && exp > 1
+	for _, p := range pow10 {
+		// n = round(mant*p / 2^shift) rounds back to f if n/p is within half a gap of f:
+		// |n*2^shift - mant*p| < p/2.
+		hi, lo := bits.Mul64(mant, p)
+		var n, remHi, remLo uint64
+		if shift < 64 {
+			if hi>>shift != 0 {
+				break
+			}
+			n, remLo = lo>>shift|hi<<(64-shift), lo&(1<<shift-1)
+		} else {
+			n, remHi, remLo = hi>>(shift-64), hi&(1<<(shift-64)-1), lo
+		}
+		halfHi, halfLo := uint64(0), uint64(0)
+		if shift-1 < 64 {
+			halfLo = 1 << (shift - 1)
+		} else {
+			halfHi = 1 << (shift - 65)
+		}
+		distHi, distLo, limit := remHi, remLo, (p-1)/2
+		if remHi > halfHi || (remHi == halfHi && remLo >= halfLo) {
+			// Round up: the distance is 2^shift - rem.
+			if n == math.MaxUint64 {
+				break
+			}
+			n++
+			var borrow uint64
+			distLo, borrow = bits.Sub64(halfLo<<1, remLo, 0)
+			distHi, _ = bits.Sub64(halfHi<<1|halfLo>>63, remHi, borrow)
+		} else if narrowBelow {
+			limit = (p - 1) / 4
+		}
+		if n != 0 && distHi == 0 && distLo <= limit {
+			g := gcd(n, p)
+			r.num, r.den = n/g, p/g
+			return r, true
+		}
+	}
+	return rat{}, false
+}
+
+// mul multiplies two rationals, reducing before multiplying to avoid spurious overflow.
+func (r rat) mul(o rat) (rat, error) {
+	if r.num == 0 || o.num == 0 {
+		return rat{den: 1}, nil
+	}
+	r, o = r.reduce(), o.reduce()
+	g1, g2 := gcd(r.num, o.den), gcd(o.num, r.den)
+	hiN, num := bits.Mul64(r.num/g1, o.num/g2)
+	hiD, den := bits.Mul64(r.den/g2, o.den/g1)
+	if hiN != 0 || hiD != 0 {
+		return rat{}, errIntOverflow
+	}
+	g := gcd(num, den)
+	return rat{neg: r.neg != o.neg, num: num / g, den: den / g}, nil
+}
+
+// reduce divides the numerator and denominator of r by their greatest common divisor.
+func (r rat) reduce() rat {
+	g := gcd(r.num, r.den)
+	r.num, r.den = r.num/g, r.den/g
+	return r
+}
+
+// add adds two rationals over their least common denominator.
+func (r rat) add(o rat) (rat, error) {
+	if o.num == 0 {
+		return r, nil
+	}
+	if r.num == 0 {
+		return o, nil
+	}
+	g := gcd(r.den, o.den)
+	hiA, a := bits.Mul64(r.num, o.den/g)
+	hiB, b := bits.Mul64(o.num, r.den/g)
+	hiD, den := bits.Mul64(r.den, o.den/g)
+	if hiA != 0 || hiB != 0 || hiD != 0 {
+		return rat{}, errIntOverflow
+	}
+	sum := rat{neg: r.neg, den: den}
+	switch {
+	case r.neg == o.neg:
+		var carry uint64
+		if sum.num, carry = bits.Add64(a, b, 0); carry != 0 {
+			return rat{}, errIntOverflow
+		}
+	case a >= b:
+		sum.num = a - b
+	default:
+		sum.num, sum.neg = b-a, o.neg
+	}
+	if sum.num == 0 {
+		return rat{den: 1}, nil
+	}
+	g = gcd(sum.num, sum.den)
+	sum.num, sum.den = sum.num/g, sum.den/g
+	return sum, nil
+}
+
+// greater reports whether |r| > |o|.
+func (r rat) greater(o rat) bool {
+	hiA, loA := bits.Mul64(r.num, o.den)
+	hiB, loB := bits.Mul64(o.num, r.den)
+	return hiA > hiB || (hiA == hiB && loA > loB)
+}
+
+// round rounds r to an integer according to policy; PrecisionStrict leaves it unchanged.
+func (r rat) round(policy unit.PrecisionPolicy) rat {
+	rem := r.num % r.den
+	if rem == 0 || policy == unit.PrecisionStrict {
+		return r
+	}
+	n := r.num / r.den
+	switch policy {
+	case unit.PrecisionRoundNearest:
+		if rem >= r.den-rem {
+			n++
+		}
+	case unit.PrecisionFloor:
+		if r.neg {
+			n++
+		}
+	case unit.PrecisionCeil:
+		if !r.neg {
+			n++
+		}
+	}
+	return rat{neg: r.neg, num: n, den: 1}
+}
+
+// int64 converts an integral rational to int64.
+func (r rat) int64() (int64, error) {
+	if r.num%r.den != 0 {
+		v := float64(r.num) / float64(r.den)
+		if r.neg {
+			v = -v
+		}
+		return 0, &PrecisionLossError{Value: v}
+	}
+	n := r.num / r.den
+	if r.neg {
+		if n > 1<<63 {
+			return 0, errIntOverflow
+		}
+		return int64(-n), nil
+	}
+	if n > 1<<63-1 {
+		return 0, errIntOverflow
+	}
+	return int64(n), nil
+}
+
+// saturatedInt64 returns the int64 bound on the side given by neg.
+func saturatedInt64(neg bool) int64 {
+	if neg {
+		return math.MinInt64
+	}
+	return math.MaxInt64
+}
+
+// addInt64 adds two int64 values, reporting overflow.
+func addInt64(a, b int64) (int64, error) {
+	c := a + b
+	if (b > 0 && c < a) || (b < 0 && c > a) {
+		return 0, errIntOverflow
+	}
+	return c, nil
+}
+
+// gcd returns the greatest common divisor of a and b.
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
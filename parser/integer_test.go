@@ -0,0 +1,156 @@
+package parser_test
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func TestParseInt(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true})
+	sys.Add("ns", 1, unit.DimTime)
+	sys.Add("s", 1e9, unit.DimTime)
+	sys.Add("h", 3600e9, unit.DimTime)
+	sys.Add("third", 1.0/3, unit.DimTime)
+	sys.AddPrefix("m", 1e-3, "s")
+	sys.AddPrefix("u", 1e-6, "s")
+	sys.AddPrefix("d", 0.1, "s")
+
+	tests := []struct {
+		name    string
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"Simple", "10ns", 10, false},
+		{"Prefixed", "1500ms", 1500000000, false},
+		{"Decimal", "1.5s", 1500000000, false},
+		{"Beyond 2^53", "9007199254740993ns", 9007199254740993, false},
+		{"Exponent", "1e3us", 1000000, false},
+		{"Inexact binary scale", "7ds", 700000000, false},
+		{"Negative exponent", "2500e-3s", 2500000000, false},
+		{"Trailing zeros", "1.000000000000000000000000s", 1000000000, false},
+		{"Negative", "-1.5s", -1500000000, false},
+		{"MultiPart", "1h 30s 5ns", 3600e9 + 30e9 + 5, false},
+		{"Max int64", "9223372036854775807ns", math.MaxInt64, false},
+		{"Min int64", "-9223372036854775808ns", math.MinInt64, false},
+
+		{"Fraction of base", "0.5ns", 0, true},
+		{"Tiny fraction", "1.0000000005s", 0, true},
+		{"Part overflow", "9223372036854775808ns", 0, true},
+		{"Sum overflow", "9223372036854775807ns 1ns", 0, true},
+		{"Huge mantissa", "123456789012345678901234567890ns", 0, true},
+		{"Non-decimal scale", "3third", 0, true},
+		{"Unknown unit", "1x", 0, true},
+		{"Missing unit", "1", 0, true},
+		{"Invalid number", "abc", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, dim, err := parser.ParseInt(tt.input, sys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseInt(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseInt(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+			if !dim.Equals(unit.DimTime) {
+				t.Errorf("ParseInt(%q) dimension = %s, want %s", tt.input, dim, unit.DimTime)
+			}
+		})
+	}
+}
+
+func TestParseInt_NonDecimalScaleCollected(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true})
+	sys.Add("s", 1e9, unit.DimTime)
+	sys.Add("third", 1.0/3, unit.DimTime)
+
+	var r recorder
+	_, _, err := parser.ParseInt("3third 1x", sys, parser.CollectErrors(), parser.WithObserver(&r))
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != 2 {
+		t.Fatalf("ParseInt error = %v, want both parts reported", err)
+	}
+	var syntaxErr *parser.SyntaxError
+	if !errors.As(joined.Unwrap()[0], &syntaxErr) || syntaxErr.Offset != 0 {
+		t.Errorf("ParseInt first error = %v, want a *SyntaxError at offset 0", joined.Unwrap()[0])
+	}
+	if want := []string{"error @0", "error @8"}; !slices.Equal(r.events, want) {
+		t.Errorf("observer events = %v, want %v", r.events, want)
+	}
+}
+
+func TestParseInt_Policies(t *testing.T) {
+	newSys := func(cfg unit.SystemConfig) *unit.System {
+		cfg.AllowMultiPart = true
+		sys := unit.NewSystem(cfg)
+		sys.Add("ns", 1, unit.DimTime)
+		sys.Add("s", 1e9, unit.DimTime)
+		sys.Add("h", 3600e9, unit.DimTime)
+		sys.AddPrefix("m", 1e-3, "s")
+		sys.Add("mK", 1, unit.DimTemp)
+		sys.Add("°C", 1000, unit.DimTemp, unit.WithOffset(273150))
+		return sys
+	}
+
+	tests := []struct {
+		name    string
+		cfg     unit.SystemConfig
+		input   string
+		want    int64
+		wantErr error
+	}{
+		{"Leading sign", unit.SystemConfig{SignPolicy: unit.SignLeading}, "-1h 30s", -3630e9, nil},
+		{"Reject negative", unit.SystemConfig{NegativePolicy: unit.RejectNegative}, "1s -1ns", 0, parser.ErrNegative},
+		{"Reject signed zero", unit.SystemConfig{NegativePolicy: unit.RejectNegative, SignedZero: true}, "-0s", 0, parser.ErrNegative},
+		{"Unsigned zero", unit.SystemConfig{NegativePolicy: unit.RejectNegative}, "-0s", 0, nil},
+		{"Absolute negative", unit.SystemConfig{NegativePolicy: unit.AbsoluteNegative}, "-1.5s", 15e8, nil},
+		{"Descending", unit.SystemConfig{RequireDescendingOrder: true}, "1h 1s 1ms", 3601001e6, nil},
+		{"Ascending", unit.SystemConfig{RequireDescendingOrder: true}, "1ms 1s", 0, parser.ErrUnitOrder},
+		{"Duplicate", unit.SystemConfig{RejectDuplicateUnits: true}, "1s 1000ms 1s", 0, parser.ErrDuplicateUnit},
+		{"Offset", unit.SystemConfig{}, "-40.5°C", 232650, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := parser.ParseInt(tt.input, newSys(tt.cfg))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ParseInt(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseInt(%q) = %d, %v, want %d", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInt_FloatAPIs(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true})
+	sys.Add("ns", 1, unit.DimTime)
+	sys.Add("s", 1e9, unit.DimTime, unit.WithConstraint(unit.ValueRange(0, 60)))
+	sys.AddPrefix("m", 1e-3, "s")
+
+	var r recorder
+	got, _, err := parser.ParseInt("1.5s 250ms", sys, parser.WithObserver(&r))
+	if err != nil || got != 1750000000 {
+		t.Fatalf("ParseInt = %d, %v, want 1750000000", got, err)
+	}
+	if want := []string{"part 1.5 s", "part 250 ms"}; !slices.Equal(r.events, want) {
+		t.Errorf("observer events = %v, want %v", r.events, want)
+	}
+	var constraintErr *parser.ConstraintError
+	if _, _, err := parser.ParseInt("61s", sys); !errors.As(err, &constraintErr) || constraintErr.Value != 61 {
+		t.Errorf("ParseInt(61s) error = %v, want a *ConstraintError for 61", err)
+	}
+}
@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// ParseFunc has the signature of Parse for a fixed target type, so parse steps
+// can be decorated uniformly. Parse[N] itself is a ParseFunc[N].
+type ParseFunc[N Number] func(s string, sys *unit.System, opts ...ParseOption) (N, unit.Dimension, error)
+
+// Middleware wraps a ParseFunc with a cross-cutting concern such as validation,
+// normalization, caching or metrics.
+type Middleware[N Number] func(next ParseFunc[N]) ParseFunc[N]
+
+// Chain decorates parse with middlewares. The first middleware is the outermost,
+// i.e. it sees the input first and the result last:
+//
+//	parseDuration := parser.Chain(parser.Parse[time.Duration],
+//		parser.Normalize[time.Duration](strings.TrimSpace),
+//		parser.RequireDimension[time.Duration](unit.DimTime, "time duration"))
+func Chain[N Number](parse ParseFunc[N], middlewares ...Middleware[N]) ParseFunc[N] {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		parse = middlewares[i](parse)
+	}
+	return parse
+}
+
+// DimensionError is reported by RequireDimension when the parsed quantity has another dimension.
+type DimensionError struct {
+	Want, Got unit.Dimension
+	Name      string // human-readable name of Want, e.g. "time duration"
+}
+
+func (e *DimensionError) Error() string {
+	if e.Name != "" {
+		return fmt.Sprintf("parsed quantity is not a %s", e.Name)
+	}
+	return fmt.Sprintf("parsed quantity has dimension %s, want %s", e.Got, e.Want)
+}
+
+// RequireDimension rejects results whose dimension is not dim with a *DimensionError.
+// name describes dim in the error message and may be empty.
+func RequireDimension[N Number](dim unit.Dimension, name string) Middleware[N] {
+	return func(next ParseFunc[N]) ParseFunc[N] {
+		return func(s string, sys *unit.System, opts ...ParseOption) (N, unit.Dimension, error) {
+			val, got, err := next(s, sys, opts...)
+			if err != nil {
+				return 0, got, err
+			}
+			if !got.Equals(dim) {
+				return 0, got, &DimensionError{Want: dim, Got: got, Name: name}
+			}
+			return val, got, nil
+		}
+	}
+}
+
+// Normalize rewrites the input before parsing, e.g. with strings.TrimSpace or a
+// function mapping localized unit names to registered symbols.
+func Normalize[N Number](fn func(string) string) Middleware[N] {
+	return func(next ParseFunc[N]) ParseFunc[N] {
+		return func(s string, sys *unit.System, opts ...ParseOption) (N, unit.Dimension, error) {
+			return next(fn(s), sys, opts...)
+		}
+	}
+}
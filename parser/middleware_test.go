@@ -0,0 +1,57 @@
+package parser_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func TestChain(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true})
+	sys.Add("s", 1, unit.DimTime)
+	sys.Add("m", 1, unit.DimLength)
+
+	var calls []string
+	trace := func(name string) parser.Middleware[float64] {
+		return func(next parser.ParseFunc[float64]) parser.ParseFunc[float64] {
+			return func(s string, sys *unit.System, opts ...parser.ParseOption) (float64, unit.Dimension, error) {
+				calls = append(calls, name+" in")
+				v, dim, err := next(s, sys, opts...)
+				calls = append(calls, name+" out")
+				return v, dim, err
+			}
+		}
+	}
+
+	parse := parser.Chain(parser.Parse[float64],
+		trace("outer"),
+		parser.Normalize[float64](strings.ToLower),
+		parser.RequireDimension[float64](unit.DimTime, "duration"),
+		trace("inner"))
+
+	got, _, err := parse("1S 2S", sys)
+	if err != nil || got != 3 {
+		t.Errorf("parse(%q) = %v, %v, want 3", "1S 2S", got, err)
+	}
+	want := []string{"outer in", "inner in", "inner out", "outer out"}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("middleware order = %v, want %v", calls, want)
+	}
+
+	_, _, err = parse("5m", sys)
+	var dimErr *parser.DimensionError
+	if !errors.As(err, &dimErr) || !dimErr.Got.Equals(unit.DimLength) {
+		t.Errorf("parse(5m) error = %v, want DimensionError", err)
+	}
+	if err == nil || err.Error() != "parsed quantity is not a duration" {
+		t.Errorf("parse(5m) error = %q, want %q", err, "parsed quantity is not a duration")
+	}
+
+	// Options reach the wrapped Parse.
+	if _, _, err := parse("1s,2s", sys, parser.WithSeparators(" ")); err == nil {
+		t.Error("parse(1s,2s) with WithSeparators(\" \") expected error")
+	}
+}
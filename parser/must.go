@@ -0,0 +1,33 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// MustParse is like Parse but panics if s cannot be parsed. It simplifies safe
+// initialization of package-level variables holding quantities:
+//
+//	var maxUpload = parser.MustParse[int64]("10MiB", storage.System)
+func MustParse[N Number](s string, sys *unit.System, opts ...ParseOption) N {
+	v, _, err := Parse[N](s, sys, opts...)
+	if err != nil {
+		panic("parser: MustParse(" + strconv.Quote(s) + "): " + err.Error())
+	}
+	return v
+}
+
+// ParseOrDefault is like Parse but returns def if s is empty (or only whitespace) or
+// cannot be parsed, for configuration values where a fallback is acceptable.
+func ParseOrDefault[N Number](s string, sys *unit.System, def N, opts ...ParseOption) N {
+	if strings.TrimSpace(s) == "" {
+		return def
+	}
+	v, _, err := Parse[N](s, sys, opts...)
+	if err != nil {
+		return def
+	}
+	return v
+}
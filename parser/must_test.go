@@ -0,0 +1,46 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+)
+
+func TestMustParse(t *testing.T) {
+	sys := createTestSystem()
+
+	if got := parser.MustParse[int64]("1h 30m", sys); got != 5400 {
+		t.Errorf("MustParse(1h 30m) = %d, want 5400", got)
+	}
+
+	defer func() {
+		r := recover()
+		if msg, ok := r.(string); !ok || !strings.Contains(msg, `MustParse("5xyz")`) {
+			t.Errorf("MustParse(5xyz) panic = %v, want message naming the input", r)
+		}
+	}()
+	parser.MustParse[int64]("5xyz", sys)
+	t.Error("MustParse(5xyz) did not panic")
+}
+
+func TestParseOrDefault(t *testing.T) {
+	sys := createTestSystem()
+
+	tests := []struct {
+		input string
+		want  int64
+	}{
+		{"1h", 3600},
+		{"0s", 0},
+		{"", 30},
+		{"  ", 30},
+		{"5xyz", 30},
+		{"0.5s", 30},
+	}
+	for _, tt := range tests {
+		if got := parser.ParseOrDefault[int64](tt.input, sys, 30); got != tt.want {
+			t.Errorf("ParseOrDefault(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"errors"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// ParseObserver is notified while Parse or ParseInt reads an input, so tooling such as
+// syntax highlighting, telemetry or custom validation can follow the parse without
+// reimplementing the tokenizer (see WithObserver).
+type ParseObserver interface {
+	// OnPart is called for every accepted part with its number (sign and negative
+	// policies applied), the matched unit and the matched prefix (empty Symbol if none).
+	OnPart(value float64, u unit.Unit, prefix unit.Prefix)
+	// OnError is called for every failed part with the error and its byte offset in the
+	// input (0 if the error has no position). Without CollectErrors it is called at most once.
+	OnError(err error, offset int)
+}
+
+// DeprecationObserver is optionally implemented by a ParseObserver to be told about
+// parts written in a deprecated unit (see unit.System.Deprecate), e.g. to log a
+// migration warning. OnDeprecated is called right after OnPart.
+type DeprecationObserver interface {
+	OnDeprecated(symbol, replacement string)
+}
+
+// WithObserver notifies obs of the parts and errors of this call.
+func WithObserver(obs ParseObserver) ParseOption {
+	return func(o *parseOptions) {
+		o.observer = obs
+	}
+}
+
+// observePart notifies the observer, if any, of a part written in unitStr.
+func (o *parseOptions) observePart(sys *unit.System, unitStr string, u unit.Unit, value float64) {
+	if o.observer == nil {
+		return
+	}
+	var prefix unit.Prefix
+	if r, ok := sys.ResolveFull(unitStr); ok {
+		prefix = r.Prefix
+	}
+	o.observer.OnPart(value, u, prefix)
+	if do, ok := o.observer.(DeprecationObserver); ok {
+		if replacement, ok := sys.Deprecation(unitStr); ok {
+			do.OnDeprecated(unitStr, replacement)
+		}
+	}
+}
+
+// observeError notifies the observer, if any, of err and returns err.
+func (o *parseOptions) observeError(err error) error {
+	if o.observer == nil {
+		return err
+	}
+	offset := 0
+	var se *SyntaxError
+	if errors.As(err, &se) {
+		offset = se.Offset
+	}
+	o.observer.OnError(err, offset)
+	return err
+}
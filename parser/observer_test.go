@@ -0,0 +1,86 @@
+package parser_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// recorder is a ParseObserver that logs its calls.
+type recorder struct {
+	events []string
+}
+
+func (r *recorder) OnPart(value float64, u unit.Unit, prefix unit.Prefix) {
+	r.events = append(r.events, fmt.Sprintf("part %v %s%s", value, prefix.Symbol, u.Symbol))
+}
+
+func (r *recorder) OnError(err error, offset int) {
+	r.events = append(r.events, fmt.Sprintf("error @%d", offset))
+}
+
+func TestParseObserver(t *testing.T) {
+	sys := createTestSystem()
+
+	tests := []struct {
+		input string
+		opts  []parser.ParseOption
+		want  []string
+	}{
+		{"1h 2000ms", nil, []string{"part 1 h", "part 2000 ms"}},
+		{"1h 5x", nil, []string{"part 1 h", "error @4"}},
+		{"1h 5x 2q 3s", []parser.ParseOption{parser.CollectErrors()}, []string{"part 1 h", "error @4", "error @7", "part 3 s"}},
+	}
+	for _, tt := range tests {
+		for name, parse := range map[string]func(opts ...parser.ParseOption){
+			"Parse":    func(opts ...parser.ParseOption) { parser.Parse[float64](tt.input, sys, opts...) },
+			"ParseInt": func(opts ...parser.ParseOption) { parser.ParseInt(tt.input, sys, opts...) },
+		} {
+			var r recorder
+			parse(append(tt.opts, parser.WithObserver(&r))...)
+			if !slices.Equal(r.events, tt.want) {
+				t.Errorf("%s(%q) observed %q, want %q", name, tt.input, r.events, tt.want)
+			}
+		}
+	}
+}
+
+// deprecationRecorder is a recorder that also logs deprecated parts.
+type deprecationRecorder struct {
+	recorder
+}
+
+func (r *deprecationRecorder) OnDeprecated(symbol, replacement string) {
+	r.events = append(r.events, fmt.Sprintf("deprecated %s -> %s", symbol, replacement))
+}
+
+func TestParseDeprecated(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{CaseInsensitive: true, AllowMultiPart: true})
+	sys.Add("B", 1, unit.DimStorage)
+	sys.AddPrefix("M", 1<<20, "B")
+	sys.AddPrefix("Mi", 1<<20, "B")
+	sys.Deprecate("MB", "MiB")
+
+	total, _, parts, err := parser.ParseDetailed[int64]("1MB 1MiB", sys)
+	if err != nil || total != 2<<20 {
+		t.Fatalf("ParseDetailed(1MB 1MiB) = %d, %v, want %d", total, err, 2<<20)
+	}
+	if parts[0].Deprecated != "MiB" || parts[1].Deprecated != "" {
+		t.Errorf("ParseDetailed Deprecated = %q, %q, want %q, %q", parts[0].Deprecated, parts[1].Deprecated, "MiB", "")
+	}
+
+	want := []string{"part 1 MB", "deprecated MB -> MiB", "part 1 MiB"}
+	for name, parse := range map[string]func(opts ...parser.ParseOption){
+		"Parse":    func(opts ...parser.ParseOption) { parser.Parse[float64]("1MB 1MiB", sys, opts...) },
+		"ParseInt": func(opts ...parser.ParseOption) { parser.ParseInt("1MB 1MiB", sys, opts...) },
+	} {
+		var r deprecationRecorder
+		parse(parser.WithObserver(&r))
+		if !slices.Equal(r.events, want) {
+			t.Errorf("%s observed %q, want %q", name, r.events, want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package parser
+
+import "github.com/armourstill/str2quantity/unit"
+
+// ParseOption overrides the System configuration for a single Parse or ParseInt call,
+// so one shared System can serve inputs with different conventions.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	// config starts as a copy of the System's configuration.
+	config unit.SystemConfig
+	// collectErrors keeps parsing after a failed part (see CollectErrors).
+	collectErrors bool
+	// observer is notified of parts and errors (see WithObserver).
+	observer ParseObserver
+	// resolver looks up unit symbols; sys unless WithResolver is given.
+	resolver unit.Resolver
+	// sys is the System of the call, or a view of it resolving under config if
+	// WithConfig changed how symbols resolve (see unit.System.View).
+	sys *unit.System
+	// configEdited is set by WithConfig.
+	configEdited bool
+}
+
+// WithSeparators replaces SystemConfig.Separators for this call,
+// e.g. WithSeparators(" \t") for values embedded in CSV where ',' delimits fields.
+func WithSeparators(separators string) ParseOption {
+	return func(o *parseOptions) {
+		o.config.Separators = separators
+	}
+}
+
+// WithMultiPart replaces SystemConfig.AllowMultiPart for this call, e.g. to accept
+// "1h 30m" in one field of a form whose System expects single values.
+func WithMultiPart(allow bool) ParseOption {
+	return func(o *parseOptions) {
+		o.config.AllowMultiPart = allow
+	}
+}
+
+// WithExponentPolicy replaces SystemConfig.ExponentPolicy for this call.
+func WithExponentPolicy(policy unit.ExponentPolicy) ParseOption {
+	return func(o *parseOptions) {
+		o.config.ExponentPolicy = policy
+	}
+}
+
+// WithNegativePolicy replaces SystemConfig.NegativePolicy for this call, e.g.
+// WithNegativePolicy(unit.RejectNegative) for limits that must not be negative.
+func WithNegativePolicy(policy unit.NegativePolicy) ParseOption {
+	return func(o *parseOptions) {
+		o.config.NegativePolicy = policy
+	}
+}
+
+// WithSignPolicy replaces SystemConfig.SignPolicy for this call.
+func WithSignPolicy(policy unit.SignPolicy) ParseOption {
+	return func(o *parseOptions) {
+		o.config.SignPolicy = policy
+	}
+}
+
+// WithOverflowPolicy replaces SystemConfig.OverflowPolicy for this call.
+func WithOverflowPolicy(policy unit.OverflowPolicy) ParseOption {
+	return func(o *parseOptions) {
+		o.config.OverflowPolicy = policy
+	}
+}
+
+// WithMaxParts replaces SystemConfig.MaxParts for this call.
+func WithMaxParts(n int) ParseOption {
+	return func(o *parseOptions) {
+		o.config.MaxParts = n
+	}
+}
+
+// WithConfig edits the configuration of this call directly, for fields without a
+// dedicated option. This includes the fields deciding how symbols resolve, such as
+// AutoPlural, LongNames, RejectAmbiguousUnits and ResolutionPolicy (see
+// unit.System.View), unless WithResolver replaces the System's resolution.
+// CaseInsensitive and NormalizeUnicode are fixed when units are registered and are
+// left unchanged; use System.CloneWith instead.
+func WithConfig(edit func(*unit.SystemConfig)) ParseOption {
+	return func(o *parseOptions) {
+		caseInsensitive, normalizeUnicode := o.config.CaseInsensitive, o.config.NormalizeUnicode
+		edit(&o.config)
+		o.config.CaseInsensitive, o.config.NormalizeUnicode = caseInsensitive, normalizeUnicode
+		o.configEdited = true
+	}
+}
+
+// WithMaxInputLength replaces SystemConfig.MaxInputLength for this call, e.g. to apply
+// a tighter limit to user-supplied values than to trusted configuration.
+func WithMaxInputLength(n int) ParseOption {
+	return func(o *parseOptions) {
+		o.config.MaxInputLength = n
+	}
+}
+
+// WithStrictSyntax enables SystemConfig.StrictSyntax for this call, e.g. for a validator
+// sharing a System with lenient parsers.
+func WithStrictSyntax() ParseOption {
+	return func(o *parseOptions) {
+		o.config.StrictSyntax = true
+	}
+}
+
+// CollectErrors keeps scanning after a failed part, so a form can report every problem
+// in "1h 5x 2q" at once. The errors (each a *SyntaxError with its offset) are combined
+// with errors.Join; a single error is returned as is. Exceeding MaxParts and a second
+// part without AllowMultiPart still end the scan.
+func CollectErrors() ParseOption {
+	return func(o *parseOptions) {
+		o.collectErrors = true
+	}
+}
+
+// WithResolver looks unit symbols up through r instead of the System, e.g. a wrapper
+// adding a cache or tenant-specific units. The System still provides the configuration,
+// and its prefixes for exponent disambiguation (see unit.ExponentPolicy).
+func WithResolver(r unit.Resolver) ParseOption {
+	return func(o *parseOptions) {
+		o.resolver = r
+	}
+}
+
+// WithDefaultUnit replaces SystemConfig.DefaultUnit for this call, e.g. WithDefaultUnit("s")
+// for a config field documented as "timeout in seconds" that also accepts "5m".
+func WithDefaultUnit(symbol string) ParseOption {
+	return func(o *parseOptions) {
+		o.config.DefaultUnit = symbol
+	}
+}
+
+// WithPrecisionPolicy replaces SystemConfig.PrecisionPolicy for this call, e.g.
+// unit.PrecisionRoundNearest for callers that prefer rounding "1.5 bits" to 2 over
+// failing. Like the System's policy, it applies per part for integer targets.
+func WithPrecisionPolicy(policy unit.PrecisionPolicy) ParseOption {
+	return func(o *parseOptions) {
+		o.config.PrecisionPolicy = policy
+	}
+}
+
+// newParseOptions applies opts on top of the configuration of sys.
+func newParseOptions(sys *unit.System, opts []ParseOption) parseOptions {
+	if len(opts) == 0 {
+		// Separate path so the common case does not move o to the heap.
+		return parseOptions{config: sys.Config, resolver: sys, sys: sys}
+	}
+	o := parseOptions{config: sys.Config, resolver: sys, sys: sys}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.configEdited {
+		o.sys = sys.View(o.config)
+		if r, ok := o.resolver.(*unit.System); ok && r == sys {
+			o.resolver = o.sys
+		}
+	}
+	return o
+}
@@ -4,13 +4,18 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/armourstill/str2quantity/unit"
 )
 
+// defaultEpsilon is the tolerance used when SystemConfig.Epsilon is zero.
+const defaultEpsilon = 1e-12
+
 // Number constrains the types that can be returned by Parse.
 type Number interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 |
@@ -18,11 +23,14 @@ type Number interface {
 		~float32 | ~float64
 }
 
+// defaultSeparators are the relaxed separators used when SystemConfig.Separators is empty.
+const defaultSeparators = " \t\n\r,;|/"
+
 // safeSkipSeps skips allowed separators but preserves characters that start a valid number (digits, dot, signs).
+// Separators are matched rune by rune, so multi-byte separators (e.g. "、") are skipped whole.
 func safeSkipSeps(s string, separators string) string {
 	if separators == "" {
-		// Default relaxed separators
-		separators = " \t\n\r,;|/"
+		separators = defaultSeparators
 	}
 
 	for len(s) > 0 {
@@ -32,8 +40,9 @@ func safeSkipSeps(s string, separators string) string {
 			return s
 		}
 
-		if strings.ContainsRune(separators, rune(c)) {
-			s = s[1:]
+		r, size := utf8.DecodeRuneInString(s)
+		if strings.ContainsRune(separators, r) {
+			s = s[size:]
 			continue
 		}
 
@@ -49,66 +58,109 @@ func safeSkipSeps(s string, separators string) string {
 // Constraints:
 //  1. System base unit (Scale=1.0) must align with '1' of type N.
 //  2. Fractional values in integer type N will return error.
-func Parse[N Number](s string, sys *unit.System) (N, unit.Dimension, error) {
-	// Epsilon handles floating point noise (e.g. for pico/nano prefixes).
-	const epsilon = 1e-12
+//
+// For integer types N, parts beyond 2^53 are scaled with exact integer arithmetic
+// (like ParseInt) whenever the unit scales are decimal fractions.
+//
+// Options override the System configuration for this call only (see ParseOption).
+func Parse[N Number](s string, sys *unit.System, opts ...ParseOption) (N, unit.Dimension, error) {
+	return parse[N](s, sys, opts, nil)
+}
 
-	var total N
-	var detectedDim unit.Dimension
-	isDimSet := false
-	partsCount := 0
+// parseExtras requests results of parse beyond the total, for ParseDetailed, ParsePrefix and ParseWith.
+type parseExtras struct {
+	detailed bool // record parts
+	parts    []Part
+	exact    bool // record the parts as numberParts (see ParseWith)
+	numbers  []numberPart
+	prefix   bool   // stop before the first malformed part that follows a valid one
+	rest     string // the unparsed tail when prefix is set
+}
 
-	orig := s
+// Part describes one value-unit pair of a parsed quantity (e.g. "30m" in "1h30m").
+type Part struct {
+	Text     string      // the part as written, e.g. "30m"
+	Offset   int         // byte offset of Text within the input
+	Value    float64     // the number, after applying the sign and negative policies
+	Integral bool        // the number was written as an integer ("1", "1e3"), not "1.0", "1e-3" or "1/2"
+	Unit     unit.Unit   // the matched unit, with any superscript exponent applied
+	Prefix   unit.Prefix // the matched prefix; its Symbol is empty if none matched
+	Scale    float64     // prefix and unit scale combined: Value * Scale is the part in base units
 
-	// Initial skip
-	s = safeSkipSeps(s, sys.Config.Separators)
+	// Deprecated is the suggested replacement if the unit is written in a deprecated
+	// spelling (see unit.System.Deprecate), e.g. "MiB" for "MB"; empty otherwise.
+	Deprecated string
+}
 
-	for s != "" {
-		// Check multi-part restriction
-		if partsCount > 0 && !sys.Config.AllowMultiPart {
-			return 0, unit.Dimension{}, fmt.Errorf("multi-part format is not allowed for this unit system: %q", orig)
-		}
+// ParseDetailed parses like Parse and additionally returns the parts the total was
+// accumulated from, so that e.g. a UI can echo back how the input was understood.
+func ParseDetailed[N Number](s string, sys *unit.System, opts ...ParseOption) (N, unit.Dimension, []Part, error) {
+	x := parseExtras{detailed: true}
+	total, dim, err := parse[N](s, sys, opts, &x)
+	if err != nil {
+		return 0, dim, nil, err
+	}
+	return total, dim, x.parts, nil
+}
 
-		// 1. Parse number
-		val, nextStr, err := parseNumber(s)
-		if err != nil {
-			return 0, unit.Dimension{}, err
-		}
-		s = nextStr
+// ParsePrefix parses the quantity at the beginning of s and returns the unparsed rest,
+// for quantities embedded in larger grammars: ParsePrefix("5GB;burst=1GB", sys) returns
+// 5GB and ";burst=1GB". Parts are consumed as long as they are well-formed, and a unit
+// followed by other text ("5GB)") is shortened to the longest registered symbol.
+// Only a malformed first part is an error. With unit.SystemConfig.NormalizeDigits the
+// rest is taken from the normalized input.
+func ParsePrefix[N Number](s string, sys *unit.System, opts ...ParseOption) (N, unit.Dimension, string, error) {
+	x := parseExtras{prefix: true}
+	total, dim, err := parse[N](s, sys, opts, &x)
+	if err != nil {
+		return 0, dim, s, err
+	}
+	return total, dim, x.rest, nil
+}
 
-		// Skip separators between value and unit (e.g. "100 MB")
-		s = safeSkipSeps(s, sys.Config.Separators)
+// parse implements Parse. x may be nil or request extra results (see parseExtras).
+func parse[N Number](s string, sys *unit.System, opts []ParseOption, x *parseExtras) (N, unit.Dimension, error) {
+	var l partLoop
+	if err := l.init(s, sys, opts, x); err != nil {
+		return 0, unit.Dimension{}, err
+	}
+	cfg := &l.cfg
 
-		// 2. Parse unit string
-		unitStr, nextStr := parseUnit(s, sys.Config.Separators)
-		if unitStr == "" {
-			return 0, unit.Dimension{}, fmt.Errorf("missing unit in %q", orig)
-		}
-		s = nextStr
+	// Epsilon handles floating point noise (e.g. for pico/nano prefixes).
+	epsilon := cfg.Epsilon
+	if epsilon == 0 {
+		epsilon = defaultEpsilon
+	}
 
-		// 3. Resolve unit
-		u, scaleRatio, found := sys.Resolve(unitStr)
-		if !found {
-			return 0, unit.Dimension{}, fmt.Errorf("unknown unit: %s", unitStr)
+	var total N
+	dim, err := l.run(func(p scannedPart) error {
+		u := p.u
+		// Calculate the value in base units as float64 first.
+		partVal := p.val*p.prefixScale*u.Scale/p.den + u.Offset
+		if toBase, _ := u.Funcs(); toBase != nil {
+			partVal = toBase(p.val / p.den)
 		}
-
-		// 4. Dimension check
-		if !isDimSet {
-			detectedDim = u.Dimension
-			isDimSet = true
-		} else if !detectedDim.Equals(u.Dimension) {
-			return 0, unit.Dimension{}, fmt.Errorf("mixed dimensions: %s and %s", detectedDim, u.Dimension)
+		if err := checkFinite(partVal, cfg); err != nil {
+			return err
 		}
-
-		// 5. Accumulate value (Value * PrefixScale * UnitScale)
-		// Calculate the value in base units as float64 first.
-		partVal := val * scaleRatio * u.Scale
-
 		var partN N
-
-		// Step A: Check if it's effectively an integer (handling float noise like 29.999995 -> 30).
 		rounded := math.Round(partVal)
-		if math.Abs(rounded-partVal) <= epsilon {
+		if n, ok, err := exactPart[N](p.num, l.sys, cfg, p.neg, partVal, p.unitStr, u, p.prefixScale); u.Linear() && (ok || err != nil) {
+			// Integer target beyond 2^53, where float64 drops digits ("9007199254740993ns").
+			if err != nil {
+				return err
+			}
+			partN = n
+		} else if err := checkRange[N](rounded); err != nil {
+			if cfg.OverflowPolicy != unit.OverflowSaturate {
+				return err
+			}
+			partN = saturated[N](partVal)
+		} else if math.IsInf(partVal, 0) {
+			// Float target with AllowInfinity
+			partN = N(partVal)
+		} else if math.Abs(rounded-partVal) <= epsilon {
+			// Step A: Check if it's effectively an integer (handling float noise like 29.999995 -> 30).
 			// It is effectively an integer. Use the clean integer value to avoid truncating 29.999 to 29.
 			partN = N(rounded)
 		} else {
@@ -118,32 +170,385 @@ func Parse[N Number](s string, sys *unit.System) (N, unit.Dimension, error) {
 
 			// If N is float64, castN should be equal to partVal (diff ~ 0).
 			// If N is int64, castN will be truncated, so diff will be large.
-			if math.Abs(float64(castN)-partVal) > epsilon {
-				return 0, detectedDim, fmt.Errorf("precision loss: part value %g cannot be represented exactly in target type", partVal)
+			if math.Abs(float64(castN)-partVal) <= epsilon {
+				partN = castN
+			} else if !isIntegerType[N]() || cfg.PrecisionPolicy == unit.PrecisionStrict {
+				return &PrecisionLossError{Value: partVal}
+			} else {
+				partN = N(roundPart(partVal, cfg.PrecisionPolicy))
 			}
-			partN = castN
 		}
 
-		total += partN
-		partsCount++
+		sum, err := addChecked(total, partN)
+		if err != nil {
+			if cfg.OverflowPolicy != unit.OverflowSaturate {
+				return err
+			}
+			sum = saturated[N](float64(partN))
+		}
+		if err := checkFinite(float64(sum), cfg); err != nil {
+			return err
+		}
+		total = sum
+		return nil
+	})
+	if err != nil {
+		return 0, dim, err
+	}
+	if l.stopped {
+		return total, dim, nil
+	}
+	if err := l.finish(float64(total)); err != nil {
+		return 0, dim, err
+	}
+	return total, dim, nil
+}
 
-		// Loop end skip
-		s = safeSkipSeps(s, sys.Config.Separators)
+// seenUnits records the units of an input for SystemConfig.RejectDuplicateUnits.
+// Units are compared by dimension and scale, prefix included, so aliases match.
+// Inputs have few parts, so a slice beats a map.
+type seenUnits []seenUnit
+
+type seenUnit struct {
+	dim   unit.Dimension
+	scale partScale
+}
+
+// add records a unit and reports whether it was seen before.
+func (s *seenUnits) add(dim unit.Dimension, scale partScale) bool {
+	u := seenUnit{dim, scale}
+	if slices.Contains(*s, u) {
+		return true
+	}
+	*s = append(*s, u)
+	return false
+}
+
+// partScale is the scale of a part, prefix included, for RequireDescendingOrder and
+// RejectDuplicateUnits: a float64, or an exact rational for ParseInt (see partLoop.exact).
+type partScale struct {
+	f float64
+	r rat
+}
+
+// greater reports whether a is a larger scale than b.
+func (a partScale) greater(b partScale) bool {
+	if a.r.den == 0 {
+		return a.f > b.f
+	}
+	return a.r.greater(b.r)
+}
+
+// longestUnit returns the longest leading part of symbol that r resolves.
+func longestUnit(symbol string, r unit.Resolver) (string, bool) {
+	for n := len(symbol) - 1; n > 0; n-- {
+		if !utf8.RuneStart(symbol[n]) {
+			continue
+		}
+		if _, _, ok := r.Resolve(symbol[:n]); ok {
+			return symbol[:n], true
+		}
+	}
+	return "", false
+}
+
+// newPart describes the part between part and rest in orig, written in unit u (resolved from unitStr).
+func newPart(sys *unit.System, orig, part, rest, unitStr string, u unit.Unit, val float64, integral bool, prefixScale float64) Part {
+	p := Part{
+		Text:     part[:len(part)-len(rest)],
+		Offset:   len(orig) - len(part),
+		Value:    val,
+		Integral: integral,
+		Unit:     u,
+		Scale:    prefixScale * u.Scale,
+	}
+	if r, ok := sys.ResolveFull(unitStr); ok {
+		p.Prefix = r.Prefix
 	}
+	p.Deprecated, _ = sys.Deprecation(unitStr)
+	return p
+}
 
-	return total, detectedDim, nil
+// roundPart rounds a fractional part value according to a non-strict policy.
+func roundPart(v float64, policy unit.PrecisionPolicy) float64 {
+	switch policy {
+	case unit.PrecisionRoundNearest:
+		return math.Round(v)
+	case unit.PrecisionFloor:
+		return math.Floor(v)
+	case unit.PrecisionCeil:
+		return math.Ceil(v)
+	}
+	return math.Trunc(v)
+}
+
+// defaultMaxParts is the part limit used when SystemConfig.MaxParts is zero.
+const defaultMaxParts = 100
+
+// tooManyParts reports whether another part after partsCount ones exceeds cfg.MaxParts.
+func tooManyParts(partsCount int, cfg *unit.SystemConfig) bool {
+	limit := cfg.MaxParts
+	if limit == 0 {
+		limit = defaultMaxParts
+	}
+	return limit > 0 && partsCount >= limit
+}
+
+// isIntegerType reports whether N is an integer type.
+func isIntegerType[N Number]() bool {
+	half := 0.5
+	return N(half) == 0
+}
+
+// number is a numeric token read by parseValue, as numerator and denominator.
+// The denominator is 1 unless the token is a fraction (see scanExact).
+type number struct {
+	num, den float64
+	integral bool // written without decimal point, negative exponent or fraction
+}
+
+// parseValue extracts the number at the beginning of s.
+func parseValue(s string, sys *unit.System, cfg *unit.SystemConfig) (number, string, error) {
+	r, n, ok, err := scanExact(s, cfg)
+	if err != nil {
+		return number{}, s, err
+	}
+	if ok {
+		num, den := r.float64()
+		return number{num: num, den: den, integral: den == 1}, s[n:], nil
+	}
+	val, integral, rest, err := parseNumber(s, sys, cfg)
+	return number{num: val, den: 1, integral: integral}, rest, err
 }
 
 // parseNumber extracts a float number from the beginning of the string.
 // Supports integers, floats, and scientific notation (e.g. 1.2, 1e5).
-// TODO: Potentially return a flag indicating if the input was syntactically an integer (no dot, no negative exponent).
-// This could guide stricter precision checks or optimizations downstream, distinguishing
-// "1" (syntax integer) from "1.0" (syntax float) or "0.9999999999999999" (float noise).
-func parseNumber(s string) (float64, string, error) {
+// It also reports whether the number was syntactically an integer (no dot, no negative
+// exponent), which tells "1" from "1.0" or "0.9999999999999999" (float noise).
+func parseNumber(s string, sys *unit.System, cfg *unit.SystemConfig) (float64, bool, string, error) {
+	tok, n, err := scanNumber(s, sys, cfg)
+	if err != nil {
+		return 0, false, s, err
+	}
+
+	val, err := strconv.ParseFloat(tok, 64)
+	if err != nil {
+		switch {
+		case errors.Is(err, strconv.ErrSyntax):
+			err = ErrInvalidNumber
+		case math.IsInf(val, 0):
+			// Beyond the float64 range ("1e400")
+			if err = checkFinite(val, cfg); err == nil {
+				return val, isIntegerSyntax(tok), s[n:], nil
+			}
+		}
+		return 0, false, s, err
+	}
+
+	return val, isIntegerSyntax(tok), s[n:], nil
+}
+
+// isIntegerSyntax reports whether a numeric token (see scanNumber) has neither a
+// decimal point nor a negative exponent.
+func isIntegerSyntax(tok string) bool {
+	if strings.IndexByte(tok, '.') >= 0 {
+		return false
+	}
+	if i := strings.IndexAny(tok, "eE"); i >= 0 && i+1 < len(tok) && tok[i+1] == '-' {
+		return false
+	}
+	return true
+}
+
+// scanNumber returns the numeric token at the beginning of s and the number of bytes it spans,
+// applying the configured ExponentPolicy. Digit separators ('_', DigitGroupSeparator) are
+// removed from the token and a localized DecimalSeparator becomes '.', so the token can
+// differ in length from the text it was read from.
+func scanNumber(s string, sys *unit.System, cfg *unit.SystemConfig) (string, int, error) {
+	if len(s) > 1 && signOf(s) != 0 && signOf(s[1:]) != 0 {
+		return "", 0, fmt.Errorf("%w: multiple signs in %q", ErrInvalidNumber, s[:2])
+	}
+	if cfg.StrictSyntax {
+		if err := checkStrictStart(s); err != nil {
+			return "", 0, err
+		}
+	}
+	src, removed := s, 0
+	if cfg.AllowUnderscoreDigits {
+		stripped, n, err := stripUnderscores(src)
+		if err != nil {
+			return "", 0, err
+		}
+		src, removed = stripped+src[n:], n-len(stripped)
+	}
+	if cfg.DigitGroupSeparator != 0 {
+		ungrouped, n, err := ungroupDigits(src, cfg.DigitGroupSeparator)
+		if err != nil {
+			return "", 0, err
+		}
+		src, removed = ungrouped+src[n:], removed+n-len(ungrouped)
+	}
+	if dec := cfg.DecimalSeparator; dec != 0 && dec != '.' {
+		if dec == cfg.DigitGroupSeparator {
+			return "", 0, fmt.Errorf("decimal separator %q is also the digit group separator", dec)
+		}
+		mantissa, n, err := localizeDecimal(src, dec)
+		if err != nil {
+			return "", 0, err
+		}
+		src, removed = mantissa+src[n:], removed+n-len(mantissa)
+	}
+
+	policy := cfg.ExponentPolicy
+	end := numberEnd(src, policy != unit.PreferPrefix)
+	if end == 0 {
+		return "", 0, ErrInvalidNumber
+	}
+
+	tok := src[:end]
+	if cfg.StrictSyntax && strings.ContainsAny(tok, "eE") {
+		return "", 0, fmt.Errorf("%w: scientific notation %q is not allowed in strict syntax", ErrInvalidNumber, clipRight(tok))
+	}
+	if policy == unit.RejectAmbiguousExponent {
+		if i := strings.IndexAny(tok, "eE"); i >= 0 && isUnitOrPrefix(tok[i:i+1], sys) {
+			return "", 0, fmt.Errorf("ambiguous exponent in %q: %q is also a unit prefix", clipRight(tok), tok[i:i+1])
+		}
+	}
+	return tok, end + removed, nil
+}
+
+// checkStrictStart rejects the number starts that StrictSyntax forbids: '+' and a
+// '.' without integer digits.
+func checkStrictStart(s string) error {
+	if signOf(s) == '+' {
+		return fmt.Errorf("%w: leading '+' is not allowed in strict syntax", ErrInvalidNumber)
+	}
+	if signOf(s) == '-' {
+		s = s[1:]
+	}
+	if strings.HasPrefix(s, ".") {
+		return fmt.Errorf("%w: leading '.' is not allowed in strict syntax", ErrInvalidNumber)
+	}
+	return nil
+}
+
+// ungroupDigits reads an optionally signed integer with digit groups ("1,000,000") at the
+// beginning of s. It returns the integer without separators and the bytes consumed in s.
+// A separator only counts as grouping when a digit follows it; every group after the
+// first must then have exactly three digits and the first one at most three.
+func ungroupDigits(s string, sep rune) (string, int, error) {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	first := digitRun(s[i:])
+	i += first
+
+	var b strings.Builder
+	sepLen := utf8.RuneLen(sep)
+	for strings.HasPrefix(s[i:], string(sep)) && digitRun(s[i+sepLen:]) > 0 {
+		if b.Len() == 0 {
+			if first == 0 || first > 3 {
+				return "", 0, fmt.Errorf("%w: digit group %q is longer than 3 digits", ErrInvalidNumber, clipRight(s[:i]))
+			}
+			b.WriteString(s[:i])
+		}
+		n := digitRun(s[i+sepLen:])
+		if n != 3 {
+			return "", 0, fmt.Errorf("%w: digit group %q must have 3 digits", ErrInvalidNumber, s[i+sepLen:i+sepLen+n])
+		}
+		b.WriteString(s[i+sepLen : i+sepLen+n])
+		i += sepLen + n
+	}
+	if b.Len() == 0 {
+		return s[:i], i, nil
+	}
+	return b.String(), i, nil
+}
+
+// localizeDecimal reads the integer part of a number at the beginning of s and, if the
+// decimal separator dec follows it directly and is itself followed by a digit, replaces it
+// with '.'. It returns the rewritten prefix and the bytes consumed in s.
+// A '.' after the integer part is rejected, since it is not the decimal mark.
+func localizeDecimal(s string, dec rune) (string, int, error) {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	i += digitRun(s[i:])
+	if i < len(s) && s[i] == '.' {
+		return "", 0, fmt.Errorf("%w: the decimal separator is %q, not '.'", ErrInvalidNumber, dec)
+	}
+	if sep := string(dec); strings.HasPrefix(s[i:], sep) && digitRun(s[i+len(sep):]) > 0 {
+		return s[:i] + ".", i + len(sep), nil
+	}
+	return s[:i], i, nil
+}
+
+// stripUnderscores reads the mantissa of a number with Go-style digit separators ("1_500.25")
+// at the beginning of s. It returns the mantissa without underscores and the bytes consumed.
+// Like in Go literals, each underscore must sit between two digits.
+func stripUnderscores(s string) (string, int, error) {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	var b strings.Builder
+	b.WriteString(s[:i])
+	for ; i < len(s); i++ {
+		c := s[i]
+		if c == '_' {
+			if i == 0 || !isDigit(s[i-1]) || i+1 == len(s) || !isDigit(s[i+1]) {
+				return "", 0, fmt.Errorf("%w: '_' must separate digits in %q", ErrInvalidNumber, s[:i+1])
+			}
+			continue
+		}
+		if !isDigit(c) && c != '.' {
+			break
+		}
+		b.WriteByte(c)
+	}
+	return b.String(), i, nil
+}
+
+// signOf returns the sign character ('+' or '-') at the beginning of s, or 0.
+func signOf(s string) byte {
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		return s[0]
+	}
+	return 0
+}
+
+// isDigit reports whether c is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// digitRun returns the number of ASCII digits at the beginning of s.
+func digitRun(s string) int {
+	n := 0
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	return n
+}
+
+// isUnitOrPrefix reports whether symbol is registered as a unit or a prefix.
+func isUnitOrPrefix(symbol string, sys *unit.System) bool {
+	if _, _, ok := sys.Resolve(symbol); ok {
+		return true
+	}
+	_, ok := sys.LookupPrefix(symbol)
+	return ok
+}
+
+// numberEnd returns the length of the numeric token at the beginning of s.
+// An exponent is only consumed if allowExp is set and 'e'/'E' is followed by digits,
+// so "1EB" leaves "EB" for the unit parser.
+func numberEnd(s string, allowExp bool) int {
 	end := 0
 	allowSign := true
 	allowDot := true
-	allowE := true
 
 	for end < len(s) {
 		c := s[end]
@@ -153,10 +558,12 @@ func parseNumber(s string) (float64, string, error) {
 		} else if c == '.' && allowDot {
 			allowDot = false
 			allowSign = false
-		} else if (c == 'e' || c == 'E') && allowE && end > 0 { // e must not be start
-			allowE = false
-			allowDot = false // no dots after e
-			allowSign = true // sign allowed after e
+		} else if (c == 'e' || c == 'E') && allowExp && end > 0 { // e must not be start
+			n := exponentLen(s[end:])
+			if n == 0 {
+				break
+			}
+			return end + n
 		} else if (c == '+' || c == '-') && allowSign {
 			allowSign = false
 		} else {
@@ -164,38 +571,45 @@ func parseNumber(s string) (float64, string, error) {
 		}
 		end++
 	}
+	return end
+}
 
-	if end == 0 {
-		return 0, s, errors.New("invalid number")
+// exponentLen returns the length of an exponent ("e5", "E-3") at the beginning of s, or 0.
+func exponentLen(s string) int {
+	n := 1 // 'e' or 'E'
+	if n < len(s) && (s[n] == '+' || s[n] == '-') {
+		n++
 	}
-
-	val, err := strconv.ParseFloat(s[:end], 64)
-	if err != nil {
-		return 0, s, err
+	digits := 0
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+		digits++
 	}
-
-	return val, s[end:], nil
+	if digits == 0 {
+		return 0
+	}
+	return n
 }
 
 // parseUnit extracts the unit string.
 // It stops when it encounters a digit, various signs, or a configured separator.
 func parseUnit(s string, separators string) (string, string) {
 	if separators == "" {
-		separators = " \t\n\r,;|/"
+		separators = defaultSeparators
 	}
 
 	end := 0
 	for end < len(s) {
-		c := s[end]
+		r, size := utf8.DecodeRuneInString(s[end:])
 		// Stop at digits, dot, plus, minus (start of next number)
-		if unicode.IsDigit(rune(c)) || c == '.' || c == '+' || c == '-' {
+		if unicode.IsDigit(r) || r == '.' || r == '+' || r == '-' {
 			break
 		}
 		// Stop at separators
-		if strings.ContainsRune(separators, rune(c)) {
+		if strings.ContainsRune(separators, r) {
 			break
 		}
-		end++
+		end += size
 	}
 	return s[:end], s[end:]
 }
@@ -1,7 +1,10 @@
 package parser_test
 
 import (
+	"errors"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/armourstill/str2quantity/parser"
 	"github.com/armourstill/str2quantity/unit"
@@ -90,6 +93,38 @@ func TestParse_Separators(t *testing.T) {
 	}
 }
 
+func TestParse_UnicodeSeparators(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{
+		AllowMultiPart: true,
+		Separators:     "、؛— Â",
+	})
+	sys.Add("h", 1, unit.DimTime)
+	sys.Add("µs", 1, unit.DimTime)
+
+	tests := []struct {
+		input   string
+		wantVal float64
+	}{
+		{"1h、2h", 3},
+		{"1h؛ 2h", 3},
+		{"1h—2h", 3},
+		// 'Â' (U+00C2) shares its leading byte with 'µ' (U+00B5) and must not split the unit.
+		{"1µs", 1},
+		{"1hÂ2µs", 3},
+	}
+
+	for _, tt := range tests {
+		got, _, err := parser.Parse[float64](tt.input, sys)
+		if err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.wantVal {
+			t.Errorf("Parse(%q) = %g, want %g", tt.input, got, tt.wantVal)
+		}
+	}
+}
+
 func TestParse_TrickySeparators(t *testing.T) {
 	// User set '3' as separator (bad practice, but testing robustness)
 	sys := unit.NewSystem(unit.SystemConfig{
@@ -126,3 +161,925 @@ func TestParse_MultiPartRestriction(t *testing.T) {
 		t.Error("Multi part should fail but succeeded")
 	}
 }
+
+func TestParse_ExponentPolicy(t *testing.T) {
+	base := unit.NewSystem(unit.SystemConfig{})
+	base.Add("B", 1, unit.DimStorage)
+	base.Add("s", 1, unit.DimTime)
+	base.AddPrefix("E", 1e18, "B")
+
+	preferPrefix := base.Clone()
+	preferPrefix.Config.ExponentPolicy = unit.PreferPrefix
+	reject := base.Clone()
+	reject.Config.ExponentPolicy = unit.RejectAmbiguousExponent
+
+	tests := []struct {
+		name    string
+		sys     *unit.System
+		input   string
+		wantVal float64
+		wantErr bool
+	}{
+		{"Exponent", base, "1e5B", 1e5, false},
+		{"Exa without digits", base, "1EB", 1e18, false},
+		{"Decimal exa", base, "1.5EB", 1.5e18, false},
+		{"Signed exponent", base, "2E-1B", 0.2, false},
+
+		{"Prefix: no exponent", preferPrefix, "1e5B", 0, true},
+		{"Prefix: exa", preferPrefix, "2EB", 2e18, false},
+
+		{"Reject: ambiguous", reject, "1E5B", 0, true},
+		{"Reject: lowercase e is not a prefix", reject, "1e5s", 1e5, false},
+		{"Reject: exa", reject, "1EB", 1e18, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := parser.Parse[float64](tt.input, tt.sys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.wantVal {
+				t.Errorf("Parse(%q) = %g, want %g", tt.input, got, tt.wantVal)
+			}
+		})
+	}
+}
+
+func TestParseNegativePolicy(t *testing.T) {
+	newSys := func(policy unit.NegativePolicy) *unit.System {
+		sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true, NegativePolicy: policy})
+		sys.Add("m", 1, unit.DimLength)
+		return sys
+	}
+
+	tests := []struct {
+		policy  unit.NegativePolicy
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{unit.AllowNegative, "-5m", -5, false},
+		{unit.AllowNegative, "5m -2m", 3, false},
+		{unit.RejectNegative, "5m", 5, false},
+		{unit.RejectNegative, "-5m", 0, true},
+		{unit.RejectNegative, "5m -2m", 0, true},
+		{unit.RejectNegative, "-0m", 0, false},
+		{unit.AbsoluteNegative, "-5m", 5, false},
+		{unit.AbsoluteNegative, "5m -2m", 7, false},
+	}
+
+	for _, tt := range tests {
+		sys := newSys(tt.policy)
+		got, _, err := parser.Parse[float64](tt.input, sys)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) policy %d error = %v, wantErr %v", tt.input, tt.policy, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("Parse(%q) policy %d = %v, want %v", tt.input, tt.policy, got, tt.want)
+		}
+		if tt.wantErr && !errors.Is(err, parser.ErrNegative) {
+			t.Errorf("Parse(%q) policy %d error = %v, want ErrNegative", tt.input, tt.policy, err)
+		}
+
+		gotInt, _, err := parser.ParseInt(tt.input, sys)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && float64(gotInt) != tt.want) {
+			t.Errorf("ParseInt(%q) policy %d = %d, %v, want %v, wantErr %v", tt.input, tt.policy, gotInt, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseWithSeparators(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true})
+	sys.Add("m", 1, unit.DimLength)
+	sys.Add("cm", 0.01, unit.DimLength)
+
+	// The System allows ',' between parts; a CSV caller disables it per call.
+	if got, _, err := parser.Parse[float64]("1m, 50cm", sys); err != nil || got != 1.5 {
+		t.Errorf("Parse(%q) = %v, %v, want 1.5", "1m, 50cm", got, err)
+	}
+	if _, _, err := parser.Parse[float64]("1m, 50cm", sys, parser.WithSeparators(" ")); err == nil {
+		t.Errorf("Parse(%q, WithSeparators(\" \")) expected error", "1m, 50cm")
+	}
+	if got, _, err := parser.ParseInt("1m_2m", sys, parser.WithSeparators("_")); err != nil || got != 3 {
+		t.Errorf("ParseInt(%q, WithSeparators(\"_\")) = %d, %v, want 3", "1m_2m", got, err)
+	}
+	if sys.Config.Separators != "" {
+		t.Errorf("WithSeparators modified the System: %q", sys.Config.Separators)
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("m", 1, unit.DimLength)
+	sys.Add("cm", 0.01, unit.DimLength)
+
+	tests := []struct {
+		input   string
+		opts    []parser.ParseOption
+		want    float64
+		wantErr bool
+	}{
+		{"1m 50cm", nil, 0, true},
+		{"1m 50cm", []parser.ParseOption{parser.WithMultiPart(true)}, 1.5, false},
+		{"1m 1m 1m", []parser.ParseOption{parser.WithMultiPart(true), parser.WithMaxParts(2)}, 0, true},
+		{"-1m", nil, -1, false},
+		{"-1m", []parser.ParseOption{parser.WithNegativePolicy(unit.RejectNegative)}, 0, true},
+		{"-1m", []parser.ParseOption{parser.WithNegativePolicy(unit.AbsoluteNegative)}, 1, false},
+		{"1m -50cm", []parser.ParseOption{parser.WithMultiPart(true), parser.WithSignPolicy(unit.SignLeading)}, 0, true},
+		{"1,5m", []parser.ParseOption{parser.WithConfig(func(c *unit.SystemConfig) { c.DecimalSeparator = ',' })}, 1.5, false},
+	}
+	for _, tt := range tests {
+		got, _, err := parser.Parse[float64](tt.input, sys, tt.opts...)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+			t.Errorf("Parse(%q) with %d options = %v, %v, want %v (error %t)", tt.input, len(tt.opts), got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	if _, _, err := parser.ParseInt("300m", sys, parser.WithOverflowPolicy(unit.OverflowSaturate)); err != nil {
+		t.Errorf("ParseInt with WithOverflowPolicy unexpected error: %v", err)
+	}
+	if got, _, _ := parser.Parse[int8]("300m", sys, parser.WithOverflowPolicy(unit.OverflowSaturate)); got != 127 {
+		t.Errorf("Parse[int8](300m) with OverflowSaturate = %d, want 127", got)
+	}
+
+	// CaseInsensitive depends on how units were registered and stays fixed.
+	if _, _, err := parser.Parse[float64]("1M", sys, parser.WithConfig(func(c *unit.SystemConfig) { c.CaseInsensitive = true })); err == nil {
+		t.Error("Parse(1M) with CaseInsensitive via WithConfig expected error")
+	}
+	if sys.Config.AllowMultiPart || sys.Config.DecimalSeparator != 0 {
+		t.Errorf("options modified the System: %+v", sys.Config)
+	}
+
+	// Resolution fields apply to the call, for Parse and ParseInt alike.
+	words := unit.NewSystem(unit.SystemConfig{})
+	words.Add("second", 1, unit.DimTime)
+	plural := parser.WithConfig(func(c *unit.SystemConfig) { c.AutoPlural = true })
+	if got, _, err := parser.Parse[float64]("2 seconds", words, plural); err != nil || got != 2 {
+		t.Errorf("Parse(2 seconds) with AutoPlural via WithConfig = %v, %v, want 2", got, err)
+	}
+	if got, _, err := parser.ParseInt("2 seconds", words, plural); err != nil || got != 2 {
+		t.Errorf("ParseInt(2 seconds) with AutoPlural via WithConfig = %v, %v, want 2", got, err)
+	}
+	if _, _, err := parser.Parse[float64]("2 seconds", words); err == nil || words.Config.AutoPlural {
+		t.Errorf("Parse(2 seconds) after WithConfig = %v, AutoPlural %t; want error, false", err, words.Config.AutoPlural)
+	}
+}
+
+func TestParseDigitGroupSeparator(t *testing.T) {
+	newSys := func(sep rune) *unit.System {
+		sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true, DigitGroupSeparator: sep})
+		sys.Add("B", 1, unit.DimStorage)
+		sys.AddPrefix("k", 1000, "B")
+		return sys
+	}
+
+	tests := []struct {
+		sep     rune
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{',', "1,000,000 B", 1000000, false},
+		{',', "-12,345B", -12345, false},
+		{',', "1,000.5kB", 1000500, false},
+		{',', "1,000 B, 24B", 1024, false},
+		{',', "1000B", 1000, false},
+		{',', "1,00 B", 0, true},
+		{',', "1,0000 B", 0, true},
+		{',', "1000,000 B", 0, true},
+		{' ', "1 000 000 B", 1000000, false},
+		{' ', "1 000 kB", 1000000, false},
+		{' ', "1 000 B", 1000, false},
+		{0, "1,000 B", 0, true}, // ',' separates parts: "1" has no unit
+	}
+
+	for _, tt := range tests {
+		sys := newSys(tt.sep)
+		got, _, err := parser.Parse[int64](tt.input, sys)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+			t.Errorf("Parse(%q) sep %q = %d, %v, want %d, wantErr %v", tt.input, tt.sep, got, err, tt.want, tt.wantErr)
+		}
+		gotInt, _, err := parser.ParseInt(tt.input, sys)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && gotInt != tt.want) {
+			t.Errorf("ParseInt(%q) sep %q = %d, %v, want %d, wantErr %v", tt.input, tt.sep, gotInt, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseUnderscoreDigits(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true, AllowUnderscoreDigits: true})
+	sys.Add("ns", 1, unit.DimTime)
+	sys.Add("s", 1e9, unit.DimTime)
+	sys.AddPrefix("m", 1e-3, "s")
+
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"1_500ms", 1500e6, false},
+		{"1_000_000ns", 1000000, false},
+		{"-2_500.000_5ms", -2500000500, false},
+		{"1_0e3ns", 10000, false},
+		{"1s 2_0ns", 1e9 + 20, false},
+		{"1__0ns", 0, true},
+		{"10_ns", 0, true},
+		{"1._5s", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, _, err := parser.Parse[int64](tt.input, sys)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+			t.Errorf("Parse(%q) = %d, %v, want %d, wantErr %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+		gotInt, _, err := parser.ParseInt(tt.input, sys)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && gotInt != tt.want) {
+			t.Errorf("ParseInt(%q) = %d, %v, want %d, wantErr %v", tt.input, gotInt, err, tt.want, tt.wantErr)
+		}
+	}
+
+	sys.Config.AllowUnderscoreDigits = false
+	if _, _, err := parser.Parse[int64]("1_500ms", sys); err == nil {
+		t.Error("Parse(1_500ms) without AllowUnderscoreDigits expected error")
+	}
+}
+
+func TestParseDecimalSeparator(t *testing.T) {
+	newSys := func(group rune) *unit.System {
+		sys := unit.NewSystem(unit.SystemConfig{
+			AllowMultiPart:      true,
+			DecimalSeparator:    ',',
+			DigitGroupSeparator: group,
+		})
+		sys.Add("B", 1, unit.DimStorage)
+		sys.AddPrefix("k", 1000, "B")
+		return sys
+	}
+
+	tests := []struct {
+		group   rune
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{0, "1,5 kB", 1500, false},
+		{0, "-0,25kB", -250, false},
+		{0, "1,5kB, 500B", 2000, false}, // ',' not between digits separates parts
+		{0, "2kB,500B", 2500, false},
+		{0, "1,5e3B", 1500, false},
+		{0, "1.5kB", 0, true},
+		{'.', "1.000,5 kB", 1000500, false},
+		{'.', "1.000.000 B", 1000000, false},
+		{' ', "1 000,5 B", 1000.5, false},
+		{',', "1,5 B", 0, true}, // ambiguous configuration
+	}
+
+	for _, tt := range tests {
+		sys := newSys(tt.group)
+		got, _, err := parser.Parse[float64](tt.input, sys)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+			t.Errorf("Parse(%q) group %q = %v, %v, want %v, wantErr %v", tt.input, tt.group, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	if got, _, err := parser.ParseInt("1,5 kB", newSys(0)); err != nil || got != 1500 {
+		t.Errorf("ParseInt(%q) = %d, %v, want 1500", "1,5 kB", got, err)
+	}
+}
+
+func TestParseFractions(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true, AllowFractions: true})
+	sys.Add("s", 1, unit.DimTime)
+	sys.Add("h", 3600, unit.DimTime)
+	sys.Add("cup", 240, unit.Dimension{L: 3})
+
+	tests := []struct {
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{"1/2 h", 1800, false},
+		{"1 1/2 h", 5400, false},
+		{"1 1/2h 30s", 5430, false},
+		{"½h", 1800, false},
+		{"1½ h", 5400, false},
+		{"2 ¾h", 9900, false},
+		{"-1/4h", -900, false},
+		{"1/3 h", 1200, false}, // exact: 3600/3
+		{"1.5h", 5400, false},  // plain numbers still work
+		{"1h 2h", 10800, false},
+		{"1/0 h", 0, true},
+		{"1 1/0h", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, _, err := parser.Parse[float64](tt.input, sys)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+			t.Errorf("Parse(%q) = %v, %v, want %v, wantErr %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+		gotInt, _, err := parser.ParseInt(tt.input, sys)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && float64(gotInt) != tt.want) {
+			t.Errorf("ParseInt(%q) = %d, %v, want %v, wantErr %v", tt.input, gotInt, err, tt.want, tt.wantErr)
+		}
+	}
+
+	if _, _, err := parser.Parse[float64]("½h", unit.NewSystem(unit.SystemConfig{})); err == nil {
+		t.Error("Parse(½h) without AllowFractions expected error")
+	}
+}
+
+func TestParseSignPolicy(t *testing.T) {
+	newSys := func(cfg unit.SystemConfig) *unit.System {
+		cfg.AllowMultiPart = true
+		sys := unit.NewSystem(cfg)
+		sys.Add("s", 1, unit.DimTime)
+		sys.Add("m", 60, unit.DimTime)
+		return sys
+	}
+
+	tests := []struct {
+		name    string
+		cfg     unit.SystemConfig
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{"PerPart leading minus", unit.SystemConfig{}, "-1m30s", -30, false},
+		{"PerPart later minus", unit.SystemConfig{}, "1m -30s", 30, false},
+		{"PerPart plus", unit.SystemConfig{}, "+5m", 300, false},
+		{"PerPart double sign", unit.SystemConfig{}, "+-3s", 0, true},
+		{"PerPart double minus", unit.SystemConfig{}, "1m --3s", 0, true},
+		{"Leading minus", unit.SystemConfig{SignPolicy: unit.SignLeading}, "-1m30s", -90, false},
+		{"Leading plus", unit.SystemConfig{SignPolicy: unit.SignLeading}, "+1m30s", 90, false},
+		{"Leading later sign", unit.SystemConfig{SignPolicy: unit.SignLeading}, "1m-30s", 0, true},
+		{"Leading later plus", unit.SystemConfig{SignPolicy: unit.SignLeading}, "1m +30s", 0, true},
+		{"Leading negative zero", unit.SystemConfig{SignPolicy: unit.SignLeading}, "-0m30s", -30, false},
+		{"Reject zero", unit.SystemConfig{NegativePolicy: unit.RejectNegative}, "-0s", 0, false},
+		{"Reject signed zero", unit.SystemConfig{NegativePolicy: unit.RejectNegative, SignedZero: true}, "-0s", 0, true},
+	}
+
+	for _, tt := range tests {
+		sys := newSys(tt.cfg)
+		got, _, err := parser.Parse[float64](tt.input, sys)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+			t.Errorf("%s: Parse(%q) = %v, %v, want %v, wantErr %v", tt.name, tt.input, got, err, tt.want, tt.wantErr)
+		}
+		gotInt, _, err := parser.ParseInt(tt.input, sys)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && float64(gotInt) != tt.want) {
+			t.Errorf("%s: ParseInt(%q) = %d, %v, want %v, wantErr %v", tt.name, tt.input, gotInt, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseRadixLiterals(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true, AllowRadixLiterals: true})
+	sys.Add("b", 1, unit.DimStorage)
+	sys.Add("bits", 1, unit.DimStorage)
+	sys.Add("B", 8, unit.DimStorage)
+	sys.AddPrefix("Ki", 1024, "B")
+
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"0x1000 B", 0x1000 * 8, false},
+		{"0X1f KiB", 0x1f * 8192, false},
+		{"0b1010 bits", 10, false},
+		{"0o17B", 15 * 8, false},
+		{"-0x10 b", -16, false},
+		{"0b", 0, false},   // zero bits, not a literal
+		{"0B", 0, false},   // zero Bytes
+		{"0x10B", 0, true}, // 'B' is a hex digit: 0x10B has no unit
+		{"0x7fffffffffffffff b", math.MaxInt64, false},
+		{"0x1ffffffffffffffff b", 0, true},
+		{"10B 0x10 b", 96, false},
+	}
+
+	for _, tt := range tests {
+		got, _, err := parser.ParseInt(tt.input, sys)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+			t.Errorf("ParseInt(%q) = %d, %v, want %d, wantErr %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+		if tt.want > 1<<53 {
+			continue // beyond float64 precision
+		}
+		gotF, _, err := parser.Parse[int64](tt.input, sys)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && gotF != tt.want) {
+			t.Errorf("Parse(%q) = %d, %v, want %d, wantErr %v", tt.input, gotF, err, tt.want, tt.wantErr)
+		}
+	}
+
+	sys.Config.AllowRadixLiterals = false
+	if _, _, err := parser.ParseInt("0x10 B", sys); err == nil {
+		t.Error("ParseInt(0x10 B) without AllowRadixLiterals expected error")
+	}
+}
+
+func TestParseNormalizeDigits(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true, NormalizeDigits: true})
+	sys.Add("m", 1, unit.DimLength)
+	sys.AddPrefix("k", 1000, "m")
+
+	tests := []struct {
+		input string
+		want  int64
+	}{
+		{"１２３m", 123},   // full-width
+		{"١٢٣m", 123},   // Arabic-Indic
+		{"۴۵km", 45000}, // Extended Arabic-Indic
+		{"१.५km", 1500}, // Devanagari
+		{"𝟗m", 9},       // Mathematical bold digit (run of several digit blocks)
+		{"1km ２０m", 1020},
+	}
+
+	for _, tt := range tests {
+		got, _, err := parser.Parse[int64](tt.input, sys)
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %d, %v, want %d", tt.input, got, err, tt.want)
+		}
+		gotInt, _, err := parser.ParseInt(tt.input, sys)
+		if err != nil || gotInt != tt.want {
+			t.Errorf("ParseInt(%q) = %d, %v, want %d", tt.input, gotInt, err, tt.want)
+		}
+	}
+
+	sys.Config.NormalizeDigits = false
+	if _, _, err := parser.Parse[int64]("１２３m", sys); err == nil {
+		t.Error("Parse(１２３m) without NormalizeDigits expected error")
+	}
+}
+
+func TestParseDetailed(t *testing.T) {
+	sys := createTestSystem()
+
+	total, dim, parts, err := parser.ParseDetailed[float64]("1h 30m 500ms", sys)
+	if err != nil {
+		t.Fatalf("ParseDetailed unexpected error: %v", err)
+	}
+	if total != 5400.5 || !dim.Equals(unit.DimTime) {
+		t.Errorf("ParseDetailed total = %v %v, want 5400.5 time", total, dim)
+	}
+
+	want := []struct {
+		text   string
+		offset int
+		value  float64
+		unit   string
+		prefix string
+		scale  float64
+	}{
+		{"1h", 0, 1, "h", "", 3600},
+		{"30m", 3, 30, "m", "", 60},
+		{"500ms", 7, 500, "s", "m", 0.001},
+	}
+	if len(parts) != len(want) {
+		t.Fatalf("ParseDetailed parts = %+v, want %d parts", parts, len(want))
+	}
+	for i, w := range want {
+		p := parts[i]
+		if p.Text != w.text || p.Offset != w.offset || p.Value != w.value || p.Unit.Symbol != w.unit || p.Prefix.Symbol != w.prefix || p.Scale != w.scale {
+			t.Errorf("ParseDetailed part %d = %+v, want %+v", i, p, w)
+		}
+	}
+
+	// Integral tells integers from numbers written with fractional syntax.
+	_, _, parts, err = parser.ParseDetailed[float64]("1h 1.0h 1e3s 1e-3s 2.5m", sys)
+	if err != nil {
+		t.Fatalf("ParseDetailed unexpected error: %v", err)
+	}
+	wantIntegral := []bool{true, false, true, false, false}
+	for i, p := range parts {
+		if p.Integral != wantIntegral[i] {
+			t.Errorf("ParseDetailed part %q Integral = %t, want %t", p.Text, p.Integral, wantIntegral[i])
+		}
+	}
+
+	if _, _, parts, err := parser.ParseDetailed[float64]("1h 5x", sys); err == nil || parts != nil {
+		t.Errorf("ParseDetailed(%q) = %v, %v, want error and no parts", "1h 5x", parts, err)
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	sys := createTestSystem()
+	single := createTestSystem()
+	single.Config.AllowMultiPart = false
+
+	tests := []struct {
+		sys      *unit.System
+		input    string
+		wantVal  float64
+		wantRest string
+	}{
+		{sys, "1h30m", 5400, ""},
+		{sys, "5s;burst=1s", 5, ";burst=1s"},
+		{sys, "1h 30m rest", 5400, " rest"},
+		{sys, "1h 5x", 3600, " 5x"},
+		{sys, "1h 1meter", 3600, " 1meter"},
+		{sys, "2s)", 2, ")"},
+		{sys, "5s ", 5, " "},
+		{single, "1h30m", 3600, "30m"},
+	}
+
+	for _, tt := range tests {
+		got, _, rest, err := parser.ParsePrefix[float64](tt.input, tt.sys)
+		if err != nil {
+			t.Errorf("ParsePrefix(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.wantVal || rest != tt.wantRest {
+			t.Errorf("ParsePrefix(%q) = %v, %q, want %v, %q", tt.input, got, rest, tt.wantVal, tt.wantRest)
+		}
+	}
+
+	for _, input := range []string{"x5s", "5", "5x"} {
+		if _, _, _, err := parser.ParsePrefix[float64](input, sys); err == nil {
+			t.Errorf("ParsePrefix(%q) expected error, got nil", input)
+		}
+	}
+}
+
+func TestParseDefaultUnit(t *testing.T) {
+	sys := createTestSystem()
+	sys.Config.DefaultUnit = "s"
+
+	tests := []struct {
+		input   string
+		opts    []parser.ParseOption
+		wantVal int64
+		wantErr bool
+	}{
+		{"30", nil, 30, false},
+		{"1m 30", nil, 90, false},
+		{"5m", nil, 300, false},
+		{"2", []parser.ParseOption{parser.WithDefaultUnit("h")}, 7200, false},
+		{"2000", []parser.ParseOption{parser.WithDefaultUnit("ms")}, 2, false},
+		{"2", []parser.ParseOption{parser.WithDefaultUnit("")}, 0, true},
+		{"2", []parser.ParseOption{parser.WithDefaultUnit("parsec")}, 0, true},
+		{"1 1meter", nil, 0, true},
+	}
+
+	for _, tt := range tests {
+		got, dim, err := parser.Parse[float64](tt.input, sys, tt.opts...)
+		gotInt, _, errInt := parser.ParseInt(tt.input, sys, tt.opts...)
+		if tt.wantErr {
+			if err == nil || errInt == nil {
+				t.Errorf("Parse/ParseInt(%q) expected error, got %v, %v", tt.input, err, errInt)
+			}
+			continue
+		}
+		if err != nil || errInt != nil {
+			t.Errorf("Parse/ParseInt(%q) unexpected error: %v, %v", tt.input, err, errInt)
+			continue
+		}
+		if got != float64(tt.wantVal) || gotInt != tt.wantVal || !dim.Equals(unit.DimTime) {
+			t.Errorf("Parse/ParseInt(%q) = %v, %v, want %v", tt.input, got, gotInt, tt.wantVal)
+		}
+	}
+
+	var unknown *parser.UnknownUnitError
+	if _, _, err := parser.Parse[float64]("2", sys, parser.WithDefaultUnit("parsec")); !errors.As(err, &unknown) {
+		t.Errorf("Parse with unknown default unit error = %v, want *UnknownUnitError", err)
+	}
+}
+
+func TestParseUnitFirst(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true, AllowUnitFirst: true})
+	sys.Add("B", 1, unit.DimStorage)
+	sys.Add("$", 1, unit.DimDimensionless)
+	sys.AddPrefix("G", 1e9, "B")
+	sys.AddPrefix("M", 1e6, "B")
+
+	tests := []struct {
+		input   string
+		wantVal int64
+		wantErr bool
+	}{
+		{"GB 5", 5e9, false},
+		{"5 GB", 5e9, false},
+		{"GB5", 5e9, false},
+		{"$5", 5, false},
+		{"$-5", -5, false},
+		{"GB 5, 200MB", 5.2e9, false},
+		{"GB 5 MB 200", 5.2e9, false},
+		{"GB", 0, true},
+		{"GB x", 0, true},
+		{"xB 5", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, _, err := parser.Parse[int64](tt.input, sys)
+		gotInt, _, errInt := parser.ParseInt(tt.input, sys)
+		if tt.wantErr {
+			if err == nil || errInt == nil {
+				t.Errorf("Parse/ParseInt(%q) expected error, got %v, %v", tt.input, err, errInt)
+			}
+			continue
+		}
+		if err != nil || errInt != nil {
+			t.Errorf("Parse/ParseInt(%q) unexpected error: %v, %v", tt.input, err, errInt)
+			continue
+		}
+		if got != tt.wantVal || gotInt != tt.wantVal {
+			t.Errorf("Parse/ParseInt(%q) = %v, %v, want %v", tt.input, got, gotInt, tt.wantVal)
+		}
+	}
+
+	sys.Config.AllowUnitFirst = false
+	if _, _, err := parser.Parse[int64]("GB 5", sys); !errors.Is(err, parser.ErrInvalidNumber) {
+		t.Errorf("Parse(%q) without AllowUnitFirst error = %v, want ErrInvalidNumber", "GB 5", err)
+	}
+}
+
+func TestParsePrecisionPolicy(t *testing.T) {
+	tests := []struct {
+		input   string
+		policy  unit.PrecisionPolicy
+		want    int64
+		wantErr bool
+	}{
+		{"1.5ns", unit.PrecisionStrict, 0, true},
+		{"1.5ns", unit.PrecisionRoundNearest, 2, false},
+		{"1.4ns", unit.PrecisionRoundNearest, 1, false},
+		{"-1.5ns", unit.PrecisionRoundNearest, -2, false},
+		{"1.9ns", unit.PrecisionTruncate, 1, false},
+		{"-1.9ns", unit.PrecisionTruncate, -1, false},
+		{"1s 0.5ns 0.5ns", unit.PrecisionTruncate, 1e9, false}, // Per part
+	}
+
+	for _, tt := range tests {
+		sys := newErrorsSystem(true)
+		sys.Config.PrecisionPolicy = tt.policy
+
+		got, _, err := parser.Parse[int64](tt.input, sys)
+		gotInt, _, errInt := parser.ParseInt(tt.input, sys)
+		if tt.wantErr {
+			var loss *parser.PrecisionLossError
+			if !errors.As(err, &loss) || !errors.As(errInt, &loss) {
+				t.Errorf("Parse/ParseInt(%q, %d) error = %v, %v, want PrecisionLossError", tt.input, tt.policy, err, errInt)
+			}
+			continue
+		}
+		if err != nil || errInt != nil || got != tt.want || gotInt != tt.want {
+			t.Errorf("Parse/ParseInt(%q, %d) = %v, %v (%v, %v), want %v", tt.input, tt.policy, got, gotInt, err, errInt, tt.want)
+		}
+	}
+
+	// Float targets keep the fraction regardless of the policy.
+	sys := newErrorsSystem(true)
+	sys.Config.PrecisionPolicy = unit.PrecisionTruncate
+	if got, _, err := parser.Parse[float64]("1.5ns", sys); err != nil || got != 1.5 {
+		t.Errorf("Parse[float64](1.5ns) = %v, %v, want 1.5", got, err)
+	}
+}
+
+func TestParseEpsilon(t *testing.T) {
+	sys := newErrorsSystem(true)
+	if _, _, err := parser.Parse[int64]("29.999999ns", sys); err == nil {
+		t.Error("Parse[int64](29.999999ns) with the default epsilon expected error, got nil")
+	}
+	sys.Config.Epsilon = 1e-3
+	if got, _, err := parser.Parse[int64]("29.999999ns", sys); err != nil || got != 30 {
+		t.Errorf("Parse[int64](29.999999ns) with epsilon 1e-3 = %v, %v, want 30", got, err)
+	}
+}
+
+func TestParseWithPrecisionPolicy(t *testing.T) {
+	sys := newErrorsSystem(true)
+
+	tests := []struct {
+		input   string
+		policy  unit.PrecisionPolicy
+		want    int64
+		wantErr bool
+	}{
+		{"1.5ns", unit.PrecisionStrict, 0, true},
+		{"1.5ns", unit.PrecisionRoundNearest, 2, false},
+		{"-1.5ns", unit.PrecisionRoundNearest, -2, false},
+		{"1.5ns", unit.PrecisionFloor, 1, false},
+		{"-1.5ns", unit.PrecisionFloor, -2, false},
+		{"1.2ns", unit.PrecisionCeil, 2, false},
+		{"-1.2ns", unit.PrecisionCeil, -1, false},
+		{"3ns", unit.PrecisionCeil, 3, false},
+		{"1.7ns", unit.PrecisionTruncate, 1, false},
+		{"-1.7ns", unit.PrecisionTruncate, -1, false},
+	}
+
+	for _, tt := range tests {
+		got, _, err := parser.Parse[int64](tt.input, sys, parser.WithPrecisionPolicy(tt.policy))
+		gotInt, _, errInt := parser.ParseInt(tt.input, sys, parser.WithPrecisionPolicy(tt.policy))
+		if tt.wantErr {
+			if err == nil || errInt == nil {
+				t.Errorf("Parse/ParseInt(%q, %d) expected error, got %v, %v", tt.input, tt.policy, got, gotInt)
+			}
+			continue
+		}
+		if err != nil || errInt != nil || got != tt.want || gotInt != tt.want {
+			t.Errorf("Parse/ParseInt(%q, %d) = %v, %v (%v, %v), want %v", tt.input, tt.policy, got, gotInt, err, errInt, tt.want)
+		}
+	}
+
+	// The option overrides the System's policy.
+	sys.Config.PrecisionPolicy = unit.PrecisionTruncate
+	if _, _, err := parser.Parse[int64]("1.5ns", sys, parser.WithPrecisionPolicy(unit.PrecisionStrict)); err == nil {
+		t.Error("Parse(1.5ns, PrecisionStrict) on a truncating System expected error, got nil")
+	}
+}
+
+func TestParseExactInteger(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true})
+	sys.Add("ns", 1, unit.DimTime)
+	sys.Add("s", 1e9, unit.DimTime)
+	sys.Add("h", 3600e9, unit.DimTime)
+
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"9007199254740993ns", 9007199254740993, false},
+		{"-9007199254740993ns", -9007199254740993, false},
+		{"9007199.254740993s", 9007199254740993, false},
+		{"2501h 1ns", 9003600000000001, false},
+		{"9223372036854775807ns", math.MaxInt64, false},
+		{"9007199254740993.5ns", 0, true}, // precision loss, invisible in float64
+	}
+	for _, tt := range tests {
+		got, _, err := parser.Parse[int64](tt.input, sys)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Parse[int64](%q) = %d, %v, want %d (error %t)", tt.input, got, err, tt.want, tt.wantErr)
+		}
+		if d, _, err := parser.Parse[time.Duration](tt.input, sys); err == nil && int64(d) != tt.want {
+			t.Errorf("Parse[time.Duration](%q) = %d, want %d", tt.input, d, tt.want)
+		}
+	}
+
+	if got, _, err := parser.Parse[int64]("9007199254740993.5ns", sys, parser.WithPrecisionPolicy(unit.PrecisionFloor)); err != nil || got != 9007199254740993 {
+		t.Errorf("Parse[int64] with PrecisionFloor = %d, %v, want 9007199254740993", got, err)
+	}
+}
+
+func TestParseOffsetUnits(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true, NegativePolicy: unit.AllowNegative})
+	sys.Add("K", 1, unit.DimTemp)
+	sys.Add("°C", 1, unit.DimTemp, unit.WithOffset(273.15))
+	sys.Add("°F", 5.0/9, unit.DimTemp, unit.WithOffset(459.67*5/9))
+	sys.AddPrefix("m", 1e-3, "K", "°C")
+
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"20°C", 293.15},
+		{"-40°C", 233.15},
+		{"-40°F", 233.15},
+		{"300K", 300},
+		{"1K 500mK", 1.5},
+	}
+	for _, tt := range tests {
+		got, _, err := parser.Parse[float64](tt.input, sys)
+		if err != nil || math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Parse(%q) = %v, %v, want %v", tt.input, got, err, tt.want)
+		}
+	}
+
+	if got, _, err := parser.ParseInt("26.85°C", sys); err != nil || got != 300 {
+		t.Errorf("ParseInt(26.85°C) = %d, %v, want 300", got, err)
+	}
+	if got, _, err := parser.ParseInt("-273.15°C", sys); err != nil || got != 0 {
+		t.Errorf("ParseInt(-273.15°C) = %d, %v, want 0", got, err)
+	}
+	if got, _, err := parser.ParseBig("20°C", sys); err != nil || got.RatString() != "5863/20" {
+		t.Errorf("ParseBig(20°C) = %v, %v, want 5863/20", got, err)
+	}
+
+	for _, input := range []string{"20°C 5°C", "1K 20°C", "20°C 1K", "5m°C"} {
+		if _, _, err := parser.Parse[float64](input, sys); err == nil {
+			t.Errorf("Parse(%q) expected error, got nil", input)
+		}
+	}
+	if _, _, err := parser.Parse[float64]("20°C 5°C", sys); !errors.Is(err, parser.ErrMultiPart) {
+		t.Errorf("Parse(20°C 5°C) error = %v, want ErrMultiPart", err)
+	}
+}
+
+func TestParseFuncUnits(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true, NegativePolicy: unit.AllowNegative})
+	concentration := unit.Dimension{L: -3, N: 1}
+	sys.Add("mol/L", 1, concentration)
+	sys.AddFunc("pH",
+		func(v float64) float64 { return math.Pow(10, -v) },
+		func(c float64) float64 { return -math.Log10(c) },
+		concentration)
+	sys.Add("x", 1, unit.DimDimensionless)
+	sys.AddFunc("dB",
+		func(v float64) float64 { return math.Pow(10, v/10) },
+		func(r float64) float64 { return 10 * math.Log10(r) },
+		unit.DimDimensionless)
+
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"20dB", 100},
+		{"-10dB", 0.1},
+		{"3dB", 1.9952623149688795},
+		{"7pH", 1e-7},
+	}
+	for _, tt := range tests {
+		got, _, err := parser.Parse[float64](tt.input, sys)
+		if err != nil || math.Abs(got-tt.want) > 1e-12*tt.want {
+			t.Errorf("Parse(%q) = %v, %v, want %v", tt.input, got, err, tt.want)
+		}
+	}
+
+	// Function units convert in float64, which ParseInt does not use.
+	var syntaxErr *parser.SyntaxError
+	if _, _, err := parser.ParseInt("30dB", sys); !errors.As(err, &syntaxErr) {
+		t.Errorf("ParseInt(30dB) error = %v, want a *SyntaxError", err)
+	}
+	if got, _, err := parser.ParseBig("20dB", sys); err != nil || got.RatString() != "100" {
+		t.Errorf("ParseBig(20dB) = %v, %v, want 100", got, err)
+	}
+	for _, input := range []string{"20dB 3dB", "2x 3dB"} {
+		if _, _, err := parser.Parse[float64](input, sys); !errors.Is(err, parser.ErrMultiPart) {
+			t.Errorf("Parse(%q) error = %v, want ErrMultiPart", input, err)
+		}
+	}
+
+	got, err := parser.Format(100.0, sys, parser.WithUnits("dB"))
+	if err != nil || got != "20dB" {
+		t.Errorf("Format(100, dB) = %q, %v, want 20dB", got, err)
+	}
+	got, err = parser.Format(100.0, sys, parser.WithDimension(unit.DimDimensionless))
+	if err != nil || got != "100x" {
+		t.Errorf("Format(100) = %q, %v, want 100x (function units are not picked automatically)", got, err)
+	}
+}
+
+func TestParseWithResolver(t *testing.T) {
+	sys := createTestSystem()
+	lookups := 0
+	r := unit.ResolverFunc(func(symbol string) (unit.Unit, float64, bool) {
+		lookups++
+		if symbol == "min" {
+			return sys.Resolve("m")
+		}
+		return sys.Resolve(symbol)
+	})
+
+	got, _, err := parser.Parse[float64]("1h 5min", sys, parser.WithResolver(r))
+	if err != nil || got != 3900 {
+		t.Errorf("Parse(1h 5min) with resolver = %v, %v, want 3900", got, err)
+	}
+	if lookups != 2 {
+		t.Errorf("resolver called %d times, want 2", lookups)
+	}
+	if n, _, err := parser.ParseInt("5min", sys, parser.WithResolver(r)); err != nil || n != 300 {
+		t.Errorf("ParseInt(5min) with resolver = %d, %v, want 300", n, err)
+	}
+	if _, _, err := parser.Parse[float64]("5min", sys); err == nil {
+		t.Error("Parse(5min) without resolver expected unknown unit error, got nil")
+	}
+}
+
+func TestParse_CompositeUnits(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true})
+	sys.Add("m", 1, unit.DimLength)
+	sys.Add("s", 1, unit.DimTime)
+	sys.Add("h", 3600, unit.DimTime)
+	sys.Add("B", 8, unit.DimStorage)
+	sys.AddPrefix("k", 1000, "m")
+	sys.AddPrefix("M", 1e6, "B")
+	sys.AddComposite("MB/s")
+	sys.AddComposite("km/h")
+
+	tests := []struct {
+		input   string
+		want    float64
+		wantDim unit.Dimension
+	}{
+		{"5MB/s", 4e7, unit.Dimension{T: -1, Extra: "storage"}},
+		{"36 km/h", 10, unit.Dimension{L: 1, T: -1}},
+		{"9.81m/s^2", 9.81, unit.Dimension{L: 1, T: -2}}, // Resolved without registration
+		{"2m/s 3m/s", 5, unit.Dimension{L: 1, T: -1}},    // Several composite parts
+		{"1m/2m", 3, unit.DimLength},                     // '/' still separates parts
+		{"2 m*s", 2, unit.Dimension{L: 1, T: 1}},
+	}
+	for _, tt := range tests {
+		got, dim, err := parser.Parse[float64](tt.input, sys)
+		if err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-9 || !dim.Equals(tt.wantDim) {
+			t.Errorf("Parse(%q) = %v %s, want %v %s", tt.input, got, dim, tt.want, tt.wantDim)
+		}
+	}
+
+	if _, _, err := parser.Parse[float64]("5MB/x", sys); err == nil {
+		t.Error("Parse(5MB/x) expected error, got nil")
+	}
+}
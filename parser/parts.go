@@ -0,0 +1,393 @@
+package parser
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// partLoop reads the value-unit parts of an input for Parse and ParseInt. It applies
+// every rule that does not depend on the numeric target (syntax, sign and negative
+// policies, unit resolution, dimension, order and constraint checks) and reports
+// errors through the observer and CollectErrors. Adding a part to the total is left
+// to the caller (see run).
+type partLoop struct {
+	o          parseOptions
+	cfg        unit.SystemConfig
+	sys        *unit.System
+	x          *parseExtras
+	orig       string // the input, after NormalizeDigits
+	s          string // the rest of the input
+	end        string // the input after the last accepted part
+	errs       []error
+	partsCount int
+	stopped    bool // a ParsePrefix call ended before a malformed part
+	exact      bool // read numbers and scales as exact rationals only, for ParseInt
+}
+
+// scannedPart is a part read by partLoop, to be added to the total.
+type scannedPart struct {
+	num         string  // the input from the number on, to re-read it exactly
+	val, den    float64 // the number after the sign and negative policies, as a fraction
+	neg         bool    // the sign of val, also for zero
+	unitStr     string  // the unit as written, or the default unit
+	u           unit.Unit
+	prefixScale float64
+
+	// In exact mode, the number as an exact rational, with the sign of neg, and the
+	// scale (prefix included) and offset of u (see exactScale). val and den are zero.
+	r, scale, offset rat
+}
+
+// init applies the options and prepares s for run. It fails on inputs that are
+// rejected as a whole.
+func (l *partLoop) init(s string, sys *unit.System, opts []ParseOption, x *parseExtras) error {
+	l.o = newParseOptions(sys, opts)
+	l.sys, l.cfg, l.x = l.o.sys, l.o.config, x
+	if err := checkInputLength(s, &l.cfg); err != nil {
+		return err
+	}
+	if l.cfg.NormalizeDigits {
+		s = normalizeDigits(s)
+	}
+	l.orig = s
+	l.s = safeSkipSeps(s, l.cfg.Separators)
+	if l.s == "" && l.cfg.StrictSyntax {
+		return ErrEmptyInput
+	}
+	return nil
+}
+
+// fail handles the error of part: it is returned, or recorded under CollectErrors
+// while the loop moves on to the next part.
+func (l *partLoop) fail(part string, err error) error {
+	l.o.observeError(err)
+	if !l.o.collectErrors {
+		return err
+	}
+	l.errs = append(l.errs, err)
+	l.s = skipFailedPart(part, l.s, l.cfg.Separators)
+	l.partsCount++
+	return nil
+}
+
+// stop reports whether a ParsePrefix call ends at the last complete part
+// instead of failing on the malformed part after it.
+func (l *partLoop) stop() bool {
+	if l.x == nil || !l.x.prefix || l.partsCount == 0 {
+		return false
+	}
+	l.x.rest, l.stopped = l.end, true
+	return true
+}
+
+// run reads the parts of the input and passes each to add, which adds it to the total
+// or returns the error of the part. It returns the dimension of the parts, and the
+// errors collected under CollectErrors. A ParsePrefix call may stop early (see stopped).
+func (l *partLoop) run(add func(p scannedPart) error) (unit.Dimension, error) {
+	cfg, sys, orig, seps := &l.cfg, l.sys, l.orig, l.cfg.Separators
+	var detectedDim unit.Dimension
+	isDimSet := false
+	negQuantity := false // leading '-' under unit.SignLeading
+	var seen seenUnits
+	var prevScale partScale // scale of the previous part under RequireDescendingOrder
+	hasPrevScale := false
+	nonLinear := false // whether a part was written in a non-linear unit
+
+	for l.s != "" {
+		part := l.s
+
+		// Check multi-part restriction
+		if l.partsCount > 0 && !cfg.AllowMultiPart {
+			if l.stop() {
+				return detectedDim, nil
+			}
+			return unit.Dimension{}, joinPartErrors(append(l.errs, l.o.observeError(syntaxError(orig, part, badToken(part, seps), ErrMultiPart))))
+		}
+		if tooManyParts(l.partsCount, cfg) {
+			return unit.Dimension{}, joinPartErrors(append(l.errs, l.o.observeError(syntaxError(orig, part, badToken(part, seps), ErrTooManyParts))))
+		}
+
+		// 1. Parse number; fractions keep their denominator until the value is scaled.
+		num, r, nextStr, err := l.readNumber(l.s)
+		var unitStr, unitPos string
+		unitFirst := false
+		if err != nil && cfg.AllowUnitFirst {
+			// Unit-first part ("GB 5", "$5")
+			if unitStr, nextStr = parseUnit(l.s, seps); unitStr != "" {
+				unitFirst, unitPos, l.s = true, l.s, safeSkipSeps(nextStr, seps)
+				num, r, nextStr, err = l.readNumber(l.s)
+			}
+		}
+		if err != nil {
+			if l.stop() {
+				return detectedDim, nil
+			}
+			if err := l.fail(part, syntaxError(orig, l.s, badToken(l.s, seps), err)); err != nil {
+				return unit.Dimension{}, err
+			}
+			continue
+		}
+		val, den := num.num, num.den
+		neg, zero := math.Signbit(val), val == 0
+		if l.exact {
+			neg, zero = r.neg, r.num == 0
+		}
+		numStart := l.s
+		numTok := l.s[:len(l.s)-len(nextStr)]
+		l.s = nextStr
+
+		if cfg.SignPolicy == unit.SignLeading {
+			if l.partsCount == 0 {
+				negQuantity = signOf(numTok) == '-'
+			} else if signOf(numTok) != 0 {
+				if l.stop() {
+					return detectedDim, nil
+				}
+				if !unitFirst {
+					l.s = skipUnit(l.s, seps)
+				}
+				if err := l.fail(part, syntaxError(orig, part, numTok, errSignPosition)); err != nil {
+					return unit.Dimension{}, err
+				}
+				continue
+			} else if negQuantity {
+				val, neg = -val, !neg
+			}
+		}
+		if neg && (!zero || cfg.SignedZero) {
+			switch cfg.NegativePolicy {
+			case unit.RejectNegative:
+				if !unitFirst {
+					l.s = skipUnit(l.s, seps)
+				}
+				if err := l.fail(part, syntaxError(orig, part, numTok, ErrNegative)); err != nil {
+					return unit.Dimension{}, err
+				}
+				continue
+			case unit.AbsoluteNegative:
+				val, neg = -val, false
+			}
+		}
+		r.neg = neg
+
+		// 2. Parse unit string, unless it came first
+		if !unitFirst {
+			// Skip separators between value and unit (e.g. "100 MB")
+			l.s = safeSkipSeps(l.s, seps)
+
+			unitStr, nextStr = parseUnit(l.s, seps)
+			unitStr, nextStr = compositeUnit(unitStr, nextStr, seps, l.o.resolver)
+			if unitStr == "" && cfg.DefaultUnit != "" {
+				unitStr = cfg.DefaultUnit
+			}
+			if unitStr == "" {
+				if l.stop() {
+					return detectedDim, nil
+				}
+				if err := l.fail(part, syntaxError(orig, part, numTok, ErrMissingUnit)); err != nil {
+					return unit.Dimension{}, err
+				}
+				continue
+			}
+			unitPos = l.s
+			l.s = nextStr
+		}
+
+		// 3. Resolve unit
+		u, scaleRatio, found := l.o.resolver.Resolve(unitStr)
+		if !found && !unitFirst && l.x != nil && l.x.prefix {
+			if shorter, ok := longestUnit(unitStr, l.o.resolver); ok {
+				unitStr, l.s = shorter, unitPos[len(shorter):]
+				u, scaleRatio, found = l.o.resolver.Resolve(unitStr)
+			}
+		}
+		if !found {
+			if l.stop() {
+				return detectedDim, nil
+			}
+			if err := l.fail(part, syntaxError(orig, unitPos, unitStr, unknownUnit(sys, unitStr))); err != nil {
+				return unit.Dimension{}, err
+			}
+			continue
+		}
+
+		// 4. Dimension check
+		if !isDimSet {
+			detectedDim = u.Dimension
+			isDimSet = true
+		} else if !detectedDim.Equals(u.Dimension) {
+			if l.stop() {
+				return detectedDim, nil
+			}
+			if err := l.fail(part, syntaxError(orig, unitPos, unitStr, &MixedDimensionsError{First: detectedDim, Second: u.Dimension})); err != nil {
+				return unit.Dimension{}, err
+			}
+			continue
+		}
+
+		// Non-linear units ("20°C", "3dB") stand alone: their values do not add up.
+		if !u.Linear() || nonLinear {
+			if l.partsCount > 0 {
+				if err := l.fail(part, syntaxError(orig, unitPos, unitStr, errNonLinearMultiPart)); err != nil {
+					return detectedDim, err
+				}
+				continue
+			}
+			nonLinear = true
+		}
+
+		var scale, offset rat
+		if l.exact {
+			if scale, offset, err = exactScale(unitStr, u, scaleRatio); err != nil {
+				if err := l.fail(part, syntaxError(orig, part, part[:len(part)-len(l.s)], err)); err != nil {
+					return detectedDim, err
+				}
+				continue
+			}
+		}
+
+		ps := partScale{r: scale}
+		if !l.exact && (cfg.RequireDescendingOrder || cfg.RejectDuplicateUnits) {
+			ps.f = scaleRatio * u.Scale
+		}
+		if cfg.RequireDescendingOrder {
+			if hasPrevScale && ps.greater(prevScale) {
+				if err := l.fail(part, syntaxError(orig, unitPos, unitStr, ErrUnitOrder)); err != nil {
+					return detectedDim, err
+				}
+				continue
+			}
+			prevScale, hasPrevScale = ps, true
+		}
+		if cfg.RejectDuplicateUnits {
+			if seen.add(u.Dimension, ps) {
+				if err := l.fail(part, syntaxError(orig, unitPos, unitStr, ErrDuplicateUnit)); err != nil {
+					return detectedDim, err
+				}
+				continue
+			}
+		}
+
+		if l.exact && (len(u.Constraints()) > 0 || l.o.observer != nil) {
+			// Constraints and observers take float64 values; convert only for them.
+			val, den = r.float64()
+		}
+		if len(u.Constraints()) > 0 {
+			inUnit := val * scaleRatio / den
+			if err := u.Check(inUnit); err != nil {
+				if err := l.fail(part, syntaxError(orig, part, part[:len(part)-len(l.s)], &ConstraintError{Symbol: unitStr, Value: inUnit, Err: err})); err != nil {
+					return detectedDim, err
+				}
+				continue
+			}
+		}
+
+		// 5. Accumulate value (Value * PrefixScale * UnitScale)
+		p := scannedPart{num: numStart, val: val, den: den, neg: neg, unitStr: unitStr, u: u, prefixScale: scaleRatio, r: r, scale: scale, offset: offset}
+		if err := add(p); err != nil {
+			if err := l.fail(part, syntaxError(orig, part, part[:len(part)-len(l.s)], err)); err != nil {
+				return detectedDim, err
+			}
+			continue
+		}
+		l.partsCount++
+		l.o.observePart(sys, unitStr, u, val/den)
+		if l.x != nil && l.x.detailed {
+			l.x.parts = append(l.x.parts, newPart(sys, orig, part, l.s, unitStr, u, val/den, num.integral, scaleRatio))
+		}
+		if l.x != nil && l.x.exact {
+			tok, err := exactToken(numStart, sys, cfg, p.neg)
+			if err != nil {
+				if err := l.fail(part, syntaxError(orig, numStart, numTok, err)); err != nil {
+					return detectedDim, err
+				}
+				continue
+			}
+			if toBase, _ := u.Funcs(); toBase != nil {
+				// Function units have no exact form; pass on the converted float64.
+				tok, scaleRatio = strconv.FormatFloat(toBase(val/den), 'g', -1, 64), 1
+			}
+			l.x.numbers = append(l.x.numbers, numberPart{tok: tok, prefixScale: scaleRatio, unitScale: u.Scale, offset: u.Offset})
+		}
+		l.end = l.s
+
+		// Loop end skip
+		l.s = safeSkipSeps(l.s, seps)
+	}
+	if len(l.errs) > 0 {
+		return unit.Dimension{}, joinPartErrors(l.errs)
+	}
+	return detectedDim, nil
+}
+
+// readNumber reads the number at the beginning of s, like parseValue. In exact mode, it
+// is read as an exact rational only, and the returned number is zero.
+func (l *partLoop) readNumber(s string) (number, rat, string, error) {
+	if !l.exact {
+		num, rest, err := parseValue(s, l.sys, &l.cfg)
+		return num, rat{}, rest, err
+	}
+	r, n, _, err := scanRat(s, l.sys, &l.cfg)
+	if err != nil {
+		return number{}, rat{}, s, err
+	}
+	return number{den: 1, integral: r.den == 1}, r, s[n:], nil
+}
+
+// finish checks total, in base units, against the constraints of the System once run
+// has read the whole input.
+func (l *partLoop) finish(total float64) error {
+	if err := l.sys.Check(total); err != nil {
+		return l.o.observeError(&ConstraintError{Value: total, Err: err})
+	}
+	if l.x != nil {
+		l.x.rest = l.end
+	}
+	return nil
+}
+
+// compositeOperators join the factors of a composite unit (see unit.System.AddComposite).
+const compositeOperators = "/*·⋅"
+
+// compositeUnit extends unitStr, as read by parseUnit, over composite operators and
+// exponents ("MB/s", "m/s^2") as long as the longer symbol resolves, so composites
+// parse even though '/' separates parts by default. It returns the longest symbol
+// that resolves, or unitStr, and the rest of the input after it.
+func compositeUnit(unitStr, rest, seps string, r unit.Resolver) (string, string) {
+	if unitStr == "" {
+		return unitStr, rest
+	}
+	best, bestRest := unitStr, rest
+	cur, s := unitStr, rest
+	for {
+		if strings.HasSuffix(cur, "^") {
+			n := 0
+			if n < len(s) && s[n] == '-' {
+				n++
+			}
+			for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+				n++
+			}
+			if n == 0 || s[n-1] == '-' {
+				break
+			}
+			cur, s = cur+s[:n], s[n:]
+		} else if op, size := utf8.DecodeRuneInString(s); size > 0 && strings.ContainsRune(compositeOperators, op) {
+			tok, next := parseUnit(s[size:], seps)
+			if tok == "" {
+				break
+			}
+			cur, s = cur+s[:size]+tok, next
+		} else {
+			break
+		}
+		if _, _, ok := r.Resolve(cur); ok {
+			best, bestRest = cur, s
+		}
+	}
+	return best, bestRest
+}
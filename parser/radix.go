@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// scanRadix reads an optionally signed integer literal with a 0x, 0o or 0b prefix
+// ("0x1000", "-0b1010") at the beginning of s. It reports ok=false if s does not start
+// with such a literal, so "0b" alone stays zero bits. Hex digits extend as far as
+// possible: "0x10B" is 267, so a unit starting with a-f needs a separator ("0x10 B").
+func scanRadix(s string) (r rat, n int, ok bool, err error) {
+	i := 0
+	if sign := signOf(s); sign != 0 {
+		r.neg = sign == '-'
+		i++
+	}
+	if len(s) < i+3 || s[i] != '0' {
+		return rat{}, 0, false, nil
+	}
+
+	var base int
+	var digits string
+	switch s[i+1] {
+	case 'x', 'X':
+		base, digits = 16, "0123456789abcdefABCDEF"
+	case 'o', 'O':
+		base, digits = 8, "01234567"
+	case 'b', 'B':
+		base, digits = 2, "01"
+	default:
+		return rat{}, 0, false, nil
+	}
+	start := i + 2
+	end := start
+	for end < len(s) && strings.IndexByte(digits, s[end]) >= 0 {
+		end++
+	}
+	if end == start {
+		return rat{}, 0, false, nil
+	}
+
+	v, err := strconv.ParseUint(s[start:end], base, 64)
+	if err != nil {
+		return rat{}, 0, false, fmt.Errorf("%w: %s", errIntOverflow, s[:end])
+	}
+	return rat{neg: r.neg, num: v, den: 1}, end, true, nil
+}
+
+// scanExact reads the number forms that are parsed as exact rationals instead of
+// decimal tokens: fractions (AllowFractions) and radix literals (AllowRadixLiterals).
+// It reports ok=false if s starts with neither, or if both are disabled.
+func scanExact(s string, cfg *unit.SystemConfig) (rat, int, bool, error) {
+	if cfg.StrictSyntax {
+		if err := checkStrictStart(s); err != nil {
+			return rat{}, 0, false, err
+		}
+	}
+	if cfg.AllowRadixLiterals {
+		if r, n, ok, err := scanRadix(s); ok || err != nil {
+			return r, n, ok, err
+		}
+	}
+	if cfg.AllowFractions {
+		return scanFraction(s)
+	}
+	return rat{}, 0, false, nil
+}
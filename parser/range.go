@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// ErrInvalidRange is reported by ParseRange for inputs without a range separator and
+// for ranges whose minimum exceeds the maximum.
+var ErrInvalidRange = errors.New("invalid range")
+
+// rangeSeparators are unambiguous range separators, tried before '-'.
+var rangeSeparators = []string{"..", "—", "–"}
+
+// ParseRange parses a range such as "1-2 GB", "10..20s" or "100MB—1GB" and returns its
+// endpoints, each parsed like Parse. A minimum without a unit takes the unit of the
+// maximum ("1-2 GB" = 1GB..2GB). Endpoints must have the same dimension and min <= max.
+//
+// Besides "..", '—' and '–', a '-' between the endpoints separates them; each '-' is
+// tried in turn, so signs and exponents still work ("-5--2s", "1e-3s-1s").
+func ParseRange[N Number](s string, sys *unit.System, opts ...ParseOption) (N, N, unit.Dimension, error) {
+	o := newParseOptions(sys, opts)
+	if err := checkInputLength(s, &o.config); err != nil {
+		return 0, 0, unit.Dimension{}, err
+	}
+
+	var firstErr error
+	for _, at := range rangeSplits(s) {
+		lo, hi, dim, err := parseRangeAt[N](s, at[0], at[1], sys, opts)
+		if err == nil || errors.Is(err, ErrInvalidRange) {
+			return lo, hi, dim, err
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr == nil {
+		firstErr = syntaxError(s, s, badToken(s, " "), fmt.Errorf("%w: no range separator", ErrInvalidRange))
+	}
+	return 0, 0, unit.Dimension{}, firstErr
+}
+
+// rangeSplits returns the candidate separators in s as [start, end) byte offsets.
+func rangeSplits(s string) [][2]int {
+	for _, sep := range rangeSeparators {
+		if i := strings.Index(s, sep); i >= 0 {
+			return [][2]int{{i, i + len(sep)}}
+		}
+	}
+	var splits [][2]int
+	lead := len(s) - len(strings.TrimLeft(s, " \t"))
+	for i := lead + 1; i < len(s); i++ {
+		if s[i] == '-' {
+			splits = append(splits, [2]int{i, i + 1})
+		}
+	}
+	return splits
+}
+
+// parseRangeAt parses s as a range separated by s[i:j].
+func parseRangeAt[N Number](s string, i, j int, sys *unit.System, opts []ParseOption) (N, N, unit.Dimension, error) {
+	if strings.TrimSpace(s[:i]) == "" || strings.TrimSpace(s[j:]) == "" {
+		return 0, 0, unit.Dimension{}, syntaxError(s, s[i:], s[i:j], fmt.Errorf("%w: missing endpoint", ErrInvalidRange))
+	}
+	hi, dimHi, err := Parse[N](s[j:], sys, opts...)
+	if err != nil {
+		return 0, 0, unit.Dimension{}, rebaseSyntaxError(err, s, j)
+	}
+	lo, dimLo, err := Parse[N](s[:i], sys, opts...)
+	if errors.Is(err, ErrMissingUnit) {
+		if sym := lastUnit(s[j:], sys, opts); sym != "" {
+			lo, dimLo, err = Parse[N](s[:i], sys, append(opts[:len(opts):len(opts)], WithDefaultUnit(sym))...)
+		}
+	}
+	if err != nil {
+		return 0, 0, unit.Dimension{}, rebaseSyntaxError(err, s, 0)
+	}
+	if !dimLo.Equals(dimHi) {
+		return 0, 0, unit.Dimension{}, &MixedDimensionsError{First: dimLo, Second: dimHi}
+	}
+	if lo > hi {
+		return 0, 0, unit.Dimension{}, fmt.Errorf("%w: minimum %q exceeds maximum %q", ErrInvalidRange, strings.TrimSpace(s[:i]), strings.TrimSpace(s[j:]))
+	}
+	return lo, hi, dimHi, nil
+}
+
+// lastUnit returns the symbol of the last unit in s.
+func lastUnit(s string, sys *unit.System, opts []ParseOption) string {
+	toks, err := Tokenize(s, sys, opts...)
+	if err != nil {
+		return ""
+	}
+	for k := len(toks) - 1; k >= 0; k-- {
+		if toks[k].Kind == TokenUnit {
+			return toks[k].Text
+		}
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package parser_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func TestParseRange(t *testing.T) {
+	sys := newEvalSystem()
+
+	tests := []struct {
+		input   string
+		lo, hi  int64
+		wantDim unit.Dimension
+	}{
+		{"1-2 GB", 1e9, 2e9, unit.DimStorage},
+		{"10..20s", 10, 20, unit.DimTime},
+		{"100MB—1GB", 1e8, 1e9, unit.DimStorage},
+		{"100MB – 1GB", 1e8, 1e9, unit.DimStorage},
+		{"1h - 1h 30m", 3600, 5400, unit.DimTime},
+		{"-5s-5s", -5, 5, unit.DimTime},
+		{"-5--2s", -5, -2, unit.DimTime},
+		{"-5..-2s", -5, -2, unit.DimTime},
+		{"1e3ms-2s", 1, 2, unit.DimTime},
+		{"5s..5s", 5, 5, unit.DimTime},
+	}
+	for _, tt := range tests {
+		lo, hi, dim, err := parser.ParseRange[int64](tt.input, sys)
+		if err != nil {
+			t.Errorf("ParseRange(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if lo != tt.lo || hi != tt.hi || !dim.Equals(tt.wantDim) {
+			t.Errorf("ParseRange(%q) = %d, %d, %v, want %d, %d, %v", tt.input, lo, hi, dim, tt.lo, tt.hi, tt.wantDim)
+		}
+	}
+}
+
+func TestParseRange_Errors(t *testing.T) {
+	sys := newEvalSystem()
+
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{"5s", parser.ErrInvalidRange},
+		{"2GB-1GB", parser.ErrInvalidRange},
+		{"1-2", parser.ErrMissingUnit},
+		{"1s..", parser.ErrInvalidRange},
+	}
+	for _, tt := range tests {
+		if _, _, _, err := parser.ParseRange[float64](tt.input, sys); !errors.Is(err, tt.want) {
+			t.Errorf("ParseRange(%q) error = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+
+	var mixed *parser.MixedDimensionsError
+	if _, _, _, err := parser.ParseRange[float64]("1h-1km", sys); !errors.As(err, &mixed) {
+		t.Errorf("ParseRange(1h-1km) error = %v, want *MixedDimensionsError", err)
+	}
+	var unknown *parser.UnknownUnitError
+	var syntaxErr *parser.SyntaxError
+	_, _, _, err := parser.ParseRange[float64]("1s..5xyz", sys)
+	if !errors.As(err, &unknown) || !errors.As(err, &syntaxErr) || syntaxErr.Offset != 5 {
+		t.Errorf("ParseRange(1s..5xyz) error = %v, want unknown unit at offset 5", err)
+	}
+}
@@ -0,0 +1,29 @@
+package parser
+
+import (
+	"errors"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// Ratio parses two quantities of the same dimension and returns a / b,
+// e.g. Ratio("3.2GiB", "8GiB", storage.System) = 0.4.
+//
+// It lives in the parser package (rather than on unit.System) because it parses.
+func Ratio(a, b string, sys *unit.System, opts ...ParseOption) (float64, error) {
+	va, dimA, err := Parse[float64](a, sys, opts...)
+	if err != nil {
+		return 0, err
+	}
+	vb, dimB, err := Parse[float64](b, sys, opts...)
+	if err != nil {
+		return 0, err
+	}
+	if !dimA.Equals(dimB) {
+		return 0, &MixedDimensionsError{First: dimA, Second: dimB}
+	}
+	if vb == 0 {
+		return 0, errors.New("ratio: denominator is zero")
+	}
+	return va / vb, nil
+}
@@ -0,0 +1,49 @@
+package parser_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func TestRatio(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true})
+	sys.Add("B", 8, unit.DimStorage)
+	sys.Add("s", 1, unit.DimTime)
+	sys.Add("min", 60, unit.DimTime)
+	sys.AddPrefix("Gi", 1<<30, "B")
+	sys.AddPrefix("Mi", 1<<20, "B")
+
+	tests := []struct {
+		a, b    string
+		want    float64
+		wantErr bool
+	}{
+		{"3.2GiB", "8GiB", 0.4, false},
+		{"512MiB", "1GiB", 0.5, false},
+		{"90s", "1min", 1.5, false},
+		{"1min 30s", "3min", 0.5, false},
+		{"1GiB", "0B", 0, true},
+		{"1GiB", "5x", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parser.Ratio(tt.a, tt.b, sys)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Ratio(%q, %q) error = %v, wantErr %v", tt.a, tt.b, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("Ratio(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	_, err := parser.Ratio("1GiB", "1s", sys)
+	var mixed *parser.MixedDimensionsError
+	if !errors.As(err, &mixed) {
+		t.Errorf("Ratio(1GiB, 1s) error = %v, want MixedDimensionsError", err)
+	}
+}
@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// RoundTo rounds a value in base units to the nearest multiple of the named unit,
+// e.g. RoundTo(bits, "MiB", storage.System) or RoundTo(d, "s", time.System).
+// Halfway values round away from zero. The step may carry a prefix ("MiB", "ms").
+// Affine and function units are rounded in their own terms: 293.4 K rounded to "°C"
+// is 293.15 K (20 °C).
+//
+// A result beyond the range of N is an error matching ErrOverflow, or the bound of N
+// under unit.OverflowSaturate. An affine or function unit whose whole values are not
+// whole in base units reports a *PrecisionLossError for integer N.
+//
+// The computation is done in float64, so integer values beyond 2^53 may lose precision.
+func RoundTo[N Number](val N, symbol string, sys *unit.System) (N, error) {
+	u, prefixScale, found := sys.Resolve(symbol)
+	if !found {
+		return 0, unknownUnit(sys, symbol)
+	}
+	step := prefixScale * u.Scale
+	if step <= 0 || math.IsInf(step, 0) || math.IsNaN(step) {
+		return 0, fmt.Errorf("unit %s has no usable scale for rounding", symbol)
+	}
+
+	var r float64
+	if u.Linear() {
+		r = math.Round(float64(val)/step) * step
+	} else {
+		r = u.ToBaseValue(math.Round(u.FromBaseValue(float64(val))))
+		if isIntegerType[N]() && r != math.Trunc(r) {
+			return 0, &PrecisionLossError{Value: r}
+		}
+	}
+	if err := checkRange[N](r); err != nil {
+		if sys.Config.OverflowPolicy != unit.OverflowSaturate {
+			return 0, err
+		}
+		return saturated[N](r), nil
+	}
+	return N(r), nil
+}
@@ -0,0 +1,95 @@
+package parser_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func TestRoundTo(t *testing.T) {
+	timeSys := unit.NewSystem(unit.SystemConfig{})
+	timeSys.Add("ns", 1, unit.DimTime)
+	timeSys.Add("s", 1e9, unit.DimTime)
+	timeSys.Add("m", 60e9, unit.DimTime)
+	timeSys.AddPrefix("m", 1e-3, "s")
+
+	durations := []struct {
+		val    time.Duration
+		symbol string
+		want   time.Duration
+	}{
+		{1499 * time.Millisecond, "s", time.Second},
+		{1500 * time.Millisecond, "s", 2 * time.Second},
+		{-1500 * time.Millisecond, "s", -2 * time.Second},
+		{1234567 * time.Nanosecond, "ms", time.Millisecond},
+		{89 * time.Second, "m", time.Minute},
+		{90 * time.Second, "m", 2 * time.Minute},
+	}
+	for _, tt := range durations {
+		got, err := parser.RoundTo(tt.val, tt.symbol, timeSys)
+		if err != nil {
+			t.Errorf("RoundTo(%v, %q) unexpected error: %v", tt.val, tt.symbol, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("RoundTo(%v, %q) = %v, want %v", tt.val, tt.symbol, got, tt.want)
+		}
+	}
+
+	storageSys := unit.NewSystem(unit.SystemConfig{})
+	storageSys.Add("B", 8, unit.DimStorage)
+	storageSys.AddPrefix("Mi", 1<<20, "B")
+
+	// 1.6 MiB in bits rounds up to 2 MiB.
+	got, err := parser.RoundTo(int64(16*(1<<20)*8/10), "MiB", storageSys)
+	if err != nil {
+		t.Fatalf("RoundTo unexpected error: %v", err)
+	}
+	if want := int64(2 * (1 << 20) * 8); got != want {
+		t.Errorf("RoundTo(1.6MiB, MiB) = %d, want %d", got, want)
+	}
+
+	if _, err := parser.RoundTo(1.0, "x", storageSys); err == nil {
+		t.Error("RoundTo with unknown unit expected error, got nil")
+	}
+}
+
+func TestRoundTo_Overflow(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("ns", 1, unit.DimTime)
+	sys.Add("s", 1e9, unit.DimTime)
+	sys.Add("hs", 100, unit.DimTime)
+
+	if got, err := parser.RoundTo(int64(math.MaxInt64), "s", sys); !errors.Is(err, parser.ErrOverflow) {
+		t.Errorf("RoundTo(MaxInt64, s) = %d, %v; want ErrOverflow", got, err)
+	}
+	if got, err := parser.RoundTo(uint8(250), "hs", sys); !errors.Is(err, parser.ErrOverflow) {
+		t.Errorf("RoundTo(uint8(250), hs) = %d, %v; want ErrOverflow", got, err)
+	}
+
+	sys.Config.OverflowPolicy = unit.OverflowSaturate
+	if got, err := parser.RoundTo(uint8(250), "hs", sys); err != nil || got != math.MaxUint8 {
+		t.Errorf("saturating RoundTo(uint8(250), hs) = %d, %v; want 255", got, err)
+	}
+}
+
+func TestRoundTo_Affine(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("K", 1, unit.DimTemp)
+	sys.Add("°C", 1, unit.DimTemp, unit.WithOffset(273.15))
+
+	if got, err := parser.RoundTo(293.4, "°C", sys); err != nil || math.Abs(got-293.15) > 1e-9 {
+		t.Errorf("RoundTo(293.4 K, °C) = %v, %v; want 293.15", got, err)
+	}
+	if got, err := parser.RoundTo(293.4, "K", sys); err != nil || got != 293 {
+		t.Errorf("RoundTo(293.4 K, K) = %v, %v; want 293", got, err)
+	}
+	var loss *parser.PrecisionLossError
+	if _, err := parser.RoundTo(int64(293), "°C", sys); !errors.As(err, &loss) {
+		t.Errorf("RoundTo(int64(293), °C) error = %v, want a precision loss", err)
+	}
+}
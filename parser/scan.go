@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// ScanToken reads the next whitespace-delimited token from state, for implementing
+// fmt.Scanner on quantity types:
+//
+//	func (b *Bytes) Scan(state fmt.ScanState, verb rune) error {
+//		tok, err := parser.ScanToken(state, verb)
+//		...
+//	}
+//
+// Only the %v and %s verbs are accepted. Since tokens end at whitespace, a quantity
+// with a space between number and unit ("10 MB") cannot be scanned as one value.
+func ScanToken(state fmt.ScanState, verb rune) (string, error) {
+	if verb != 'v' && verb != 's' {
+		return "", fmt.Errorf("bad verb %%%c for quantity", verb)
+	}
+	tok, err := state.Token(true, func(r rune) bool { return !unicode.IsSpace(r) })
+	if err != nil {
+		return "", err
+	}
+	if len(tok) == 0 {
+		return "", io.ErrUnexpectedEOF
+	}
+	return string(tok), nil
+}
+
+// FormatState writes val, in base units of sys, to state, for implementing fmt.Formatter
+// on quantity types:
+//
+//	func (l Length) Format(state fmt.State, verb rune) {
+//		parser.FormatState(state, verb, float64(l), System)
+//	}
+//
+// %v and %s write the form of Format ("1.5h"), %q quotes it and %f writes it with six
+// decimals unless a precision is given, so "%.2f" or "%.2v" fixes two decimals. %#v
+// also shows the value in the base unit and the dimension, as in
+// "1.5km (1500m, L^1 M^0 T^0 I^0 K^0 N^0 J^0)".
+// Width and the '-' flag pad the result. Other verbs format val as a float64. Values
+// Format rejects (e.g. a negative size) are written in the base unit.
+func FormatState(state fmt.State, verb rune, val float64, sys *unit.System, opts ...FormatOption) {
+	switch verb {
+	case 'v', 's', 'q', 'f', 'F':
+	default:
+		fmt.Fprintf(state, fmt.FormatString(state, verb), val)
+		return
+	}
+	if prec, ok := state.Precision(); ok {
+		opts = append(opts, WithPrecision(prec))
+	} else if verb == 'f' || verb == 'F' {
+		opts = append(opts, WithPrecision(6))
+	}
+
+	base, dim := baseUnit(val, sys, opts)
+	s, err := Format(val, sys, opts...)
+	if err != nil {
+		s = base
+	}
+	switch {
+	case verb == 'q':
+		s = strconv.Quote(s)
+	case verb == 'v' && state.Flag('#'):
+		s += " (" + base + ", " + dim + ")"
+	}
+
+	width, _ := state.Width()
+	pad := strings.Repeat(" ", max(0, width-utf8.RuneCountInString(s)))
+	if state.Flag('-') {
+		s += pad
+	} else {
+		s = pad + s
+	}
+	io.WriteString(state, s)
+}
+
+// baseUnit renders val with the base unit of its dimension, and that dimension.
+func baseUnit(val float64, sys *unit.System, opts []FormatOption) (string, string) {
+	var o formatOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	s := strconv.FormatFloat(val, 'g', -1, 64)
+	units := sys.DisplayUnits()
+	if o.dim == nil {
+		// Like Format, a System of several dimensions needs WithDimension.
+		if len(units) == 0 || !units[0].Dimension.Equals(units[len(units)-1].Dimension) {
+			return s, ""
+		}
+		o.dim = &units[0].Dimension
+	}
+	for _, u := range units {
+		if u.Dimension.Equals(*o.dim) && u.Scale == 1 && u.PrefixScale == 0 && u.Linear() {
+			return s + u.Symbol, o.dim.String()
+		}
+	}
+	return s, o.dim.String()
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatTemplate renders a value and unit symbol using a layout template.
+//
+// Placeholders are "{value}" and "{unit}", optionally followed by a printf verb
+// controlling precision, padding and alignment, e.g.:
+//
+//	"{value:%.2f} {unit}"     -> "1.50 GiB"
+//	"{value:%8.1f} {unit:%-3s}" -> "     1.5 GiB"
+//	"{value}{unit}"           -> "1.5GiB"
+//
+// Without a verb, value is printed in its shortest exact decimal form.
+// Literal braces are written as "{{" and "}}".
+func FormatTemplate(layout string, value float64, symbol string) (string, error) {
+	var b strings.Builder
+
+	for i := 0; i < len(layout); i++ {
+		c := layout[i]
+		switch {
+		case c == '{' && strings.HasPrefix(layout[i:], "{{"):
+			b.WriteByte('{')
+			i++
+		case c == '}' && strings.HasPrefix(layout[i:], "}}"):
+			b.WriteByte('}')
+			i++
+		case c == '}':
+			return "", fmt.Errorf("unmatched '}' at offset %d in layout %q", i, layout)
+		case c == '{':
+			end := strings.IndexByte(layout[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated placeholder at offset %d in layout %q", i, layout)
+			}
+			if err := renderPlaceholder(&b, layout[i+1:i+end], value, symbol); err != nil {
+				return "", err
+			}
+			i += end
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// renderPlaceholder writes a single "name[:verb]" placeholder.
+func renderPlaceholder(b *strings.Builder, spec string, value float64, symbol string) error {
+	name, verb, hasVerb := strings.Cut(spec, ":")
+
+	switch name {
+	case "value":
+		if !hasVerb {
+			b.WriteString(strconv.FormatFloat(value, 'f', -1, 64))
+			return nil
+		}
+		if err := checkVerb(verb, "eEfFgGv"); err != nil {
+			return err
+		}
+		fmt.Fprintf(b, verb, value)
+	case "unit":
+		if !hasVerb {
+			b.WriteString(symbol)
+			return nil
+		}
+		if err := checkVerb(verb, "sqv"); err != nil {
+			return err
+		}
+		fmt.Fprintf(b, verb, symbol)
+	default:
+		return fmt.Errorf("unknown placeholder {%s}", spec)
+	}
+	return nil
+}
+
+// checkVerb ensures verb is a single printf directive: '%', flags, an optional width and
+// precision written as digits, and one of the allowed verb letters. Argument indexes
+// ("%[2]f") and '*' widths are rejected, since the directive gets a single argument.
+func checkVerb(verb string, allowed string) error {
+	i := 1
+	if len(verb) < 2 || verb[0] != '%' {
+		return fmt.Errorf("invalid format verb %q", verb)
+	}
+	for i < len(verb) && strings.IndexByte("+-# 0", verb[i]) >= 0 {
+		i++
+	}
+	i = skipDigits(verb, i)
+	if i < len(verb) && verb[i] == '.' {
+		i = skipDigits(verb, i+1)
+	}
+	if i != len(verb)-1 || !strings.ContainsRune(allowed, rune(verb[i])) {
+		return fmt.Errorf("invalid format verb %q", verb)
+	}
+	return nil
+}
+
+// skipDigits returns the offset of the first non-digit of s at or after i.
+func skipDigits(s string, i int) int {
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i
+}
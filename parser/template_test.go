@@ -0,0 +1,49 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+)
+
+func TestFormatTemplate(t *testing.T) {
+	tests := []struct {
+		layout  string
+		value   float64
+		symbol  string
+		want    string
+		wantErr bool
+	}{
+		{"{value}{unit}", 1.5, "GiB", "1.5GiB", false},
+		{"{value:%.2f} {unit}", 1.5, "GiB", "1.50 GiB", false},
+		{"{value:%8.1f}|{unit:%-4s}|", 1.5, "h", "     1.5|h   |", false},
+		{"{value:%-6g}{unit:%3s}", 2, "ms", "2      ms", false},
+		{"{{{value}}} {unit}", 3, "m", "{3} m", false},
+		{"{value}", 0.000001, "", "0.000001", false},
+
+		// Errors
+		{"{value", 1, "m", "", true},
+		{"value}", 1, "m", "", true},
+		{"{size}", 1, "m", "", true},
+		{"{value:%d}", 1, "m", "", true},
+		{"{unit:%f}", 1, "m", "", true},
+		{"{value:%f%f}", 1, "m", "", true},
+		{"{value:%*f}", 1, "m", "", true},
+		{"{value:%[2]f}", 1, "m", "", true},
+		{"{value:%-*.*f}", 1, "m", "", true},
+		{"{value:%5f.2f}", 1, "m", "", true},
+		{"{unit:%[1]s}", 1, "m", "", true},
+		{"{value:%+08.3f}", 1.5, "m", "+001.500", false},
+	}
+
+	for _, tt := range tests {
+		got, err := parser.FormatTemplate(tt.layout, tt.value, tt.symbol)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("FormatTemplate(%q) error = %v, wantErr %v", tt.layout, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("FormatTemplate(%q) = %q, want %q", tt.layout, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// TokenKind classifies a Token.
+type TokenKind int
+
+const (
+	// TokenNumber is a number as Parse reads it, including its sign (e.g. "-1.5", "0x10", "1/2").
+	TokenNumber TokenKind = iota
+	// TokenUnit is a unit symbol as written, possibly prefixed (e.g. "km"); it is not resolved.
+	TokenUnit
+	// TokenSeparator is a run of separator characters (see unit.SystemConfig.Separators).
+	TokenSeparator
+)
+
+func (k TokenKind) String() string {
+	switch k {
+	case TokenNumber:
+		return "Number"
+	case TokenUnit:
+		return "Unit"
+	case TokenSeparator:
+		return "Separator"
+	}
+	return "TokenKind(?)"
+}
+
+// Token is a lexical element of a quantity string.
+type Token struct {
+	Kind   TokenKind
+	Text   string
+	Offset int // byte offset of Text within the input
+}
+
+// Tokenize splits s into Number, Unit and Separator tokens using the same rules as Parse,
+// as a building block for custom grammars (ranges, expressions). Unlike Parse it does
+// not require numbers and units to alternate, and units are not resolved against sys.
+//
+// With unit.SystemConfig.NormalizeDigits, offsets refer to the normalized input.
+// Invalid numbers (e.g. a lone "-") are reported as *SyntaxError, together with the
+// tokens read so far.
+func Tokenize(s string, sys *unit.System, opts ...ParseOption) ([]Token, error) {
+	cfg := newParseOptions(sys, opts).config
+	if err := checkInputLength(s, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.NormalizeDigits {
+		s = normalizeDigits(s)
+	}
+	orig := s
+	seps := cfg.Separators
+
+	var tokens []Token
+	emit := func(kind TokenKind, rest string) {
+		tokens = append(tokens, Token{Kind: kind, Text: s[:len(s)-len(rest)], Offset: len(orig) - len(s)})
+		s = rest
+	}
+	for s != "" {
+		if rest := safeSkipSeps(s, seps); len(rest) < len(s) {
+			emit(TokenSeparator, rest)
+			continue
+		}
+		_, rest, err := parseValue(s, sys, &cfg)
+		if err == nil {
+			emit(TokenNumber, rest)
+			continue
+		}
+		if c := s[0]; isDigit(c) || c == '.' || c == '+' || c == '-' {
+			return tokens, syntaxError(orig, s, badToken(s, seps), err)
+		}
+		unitStr, rest := parseUnit(s, seps)
+		if unitStr == "" {
+			// A digit parseUnit stops at, but which is not a number (e.g. "٣" without NormalizeDigits).
+			return tokens, syntaxError(orig, s, badToken(s, seps), ErrInvalidNumber)
+		}
+		emit(TokenUnit, rest)
+	}
+	return tokens, nil
+}
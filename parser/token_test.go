@@ -0,0 +1,54 @@
+package parser_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func TestTokenize(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true, AllowFractions: true})
+	sys.Add("h", 3600, unit.DimTime)
+	sys.Add("m", 60, unit.DimTime)
+
+	num := func(text string, off int) parser.Token {
+		return parser.Token{Kind: parser.TokenNumber, Text: text, Offset: off}
+	}
+	sym := func(text string, off int) parser.Token {
+		return parser.Token{Kind: parser.TokenUnit, Text: text, Offset: off}
+	}
+	sep := func(text string, off int) parser.Token {
+		return parser.Token{Kind: parser.TokenSeparator, Text: text, Offset: off}
+	}
+
+	tests := []struct {
+		input string
+		want  []parser.Token
+	}{
+		{"1h30m", []parser.Token{num("1", 0), sym("h", 1), num("30", 2), sym("m", 4)}},
+		{"1.5 h, -2m", []parser.Token{num("1.5", 0), sep(" ", 3), sym("h", 4), sep(", ", 5), num("-2", 7), sym("m", 9)}},
+		{"1-2h", []parser.Token{num("1", 0), num("-2", 1), sym("h", 3)}},
+		{"h to 1/2 m", []parser.Token{sym("h", 0), sep(" ", 1), sym("to", 2), sep(" ", 4), num("1/2", 5), sep(" ", 8), sym("m", 9)}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		got, err := parser.Tokenize(tt.input, sys)
+		if err != nil {
+			t.Errorf("Tokenize(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Tokenize(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	tokens, err := parser.Tokenize("1h - 30m", sys)
+	var syntaxErr *parser.SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Offset != 3 || len(tokens) != 3 {
+		t.Errorf("Tokenize(%q) = %v, %v, want 3 tokens and a SyntaxError at offset 3", "1h - 30m", tokens, err)
+	}
+}
@@ -0,0 +1,148 @@
+package str2quantity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// ErrDimension is reported when a Quantity is converted into a type or unit of another
+// dimension, such as a storage size into a time.Duration.
+var ErrDimension = errors.New("quantity has another dimension")
+
+// System returns the std System of the kind, or nil for an unknown kind.
+func (k Kind) System() *unit.System {
+	if d := k.detector(); d != nil {
+		return d.sys
+	}
+	return nil
+}
+
+// detector returns the detectors entry of the kind, or nil for an unknown kind.
+func (k Kind) detector() *detector {
+	for i := range detectors {
+		if detectors[i].kind == k {
+			return &detectors[i]
+		}
+	}
+	return nil
+}
+
+// Format renders val, given in base units, with the std System of dim and that
+// dimension's unit ladder: durations in h, m, s and below ("1.5h"), storage in IEC
+// bytes ("1.5GiB") and lengths in SI meters ("30cm"). opts apply after the ladder,
+// so WithUnits or WithCompound override it. Dimensions without a std System fail.
+func Format(val float64, dim unit.Dimension, opts ...parser.FormatOption) (string, error) {
+	for _, d := range detectors {
+		if d.dim.Equals(dim) {
+			return parser.Format(val, d.sys, d.formatOptions(opts)...)
+		}
+	}
+	return "", fmt.Errorf("%w: no std System formats %s", ErrDimension, dim)
+}
+
+// formatOptions prepends the unit ladder of d to opts.
+func (d *detector) formatOptions(opts []parser.FormatOption) []parser.FormatOption {
+	return append([]parser.FormatOption{parser.WithUnits(d.units...)}, opts...)
+}
+
+// AsDuration returns a duration quantity as a time.Duration. It fails for other
+// dimensions, fractional nanoseconds and durations beyond the time.Duration range.
+func (q Quantity) AsDuration() (time.Duration, error) {
+	if err := q.checkDimension(unit.DimTime); err != nil {
+		return 0, err
+	}
+	ns, err := toInt64(q.Value)
+	return time.Duration(ns), err
+}
+
+// AsBytes returns a storage quantity as a count of bytes. It fails for other
+// dimensions, sizes that are not a whole number of bytes ("4b") and sizes beyond int64.
+func (q Quantity) AsBytes() (int64, error) {
+	if err := q.checkDimension(unit.DimStorage); err != nil {
+		return 0, err
+	}
+	return toInt64(q.Value / 8)
+}
+
+// AsFloat returns the quantity in the given unit of its Kind's System, prefix included
+// ("ms", "GiB", "km"). It fails for unknown units and units of another dimension.
+func (q Quantity) AsFloat(symbol string) (float64, error) {
+	sys := q.Kind.System()
+	if sys == nil {
+		return 0, fmt.Errorf("unknown quantity kind %q", q.Kind)
+	}
+	r, found := sys.ResolveFull(symbol)
+	if !found {
+		return 0, &parser.UnknownUnitError{Symbol: symbol}
+	}
+	if !r.Dimension().Equals(q.Dimension) {
+		return 0, fmt.Errorf("%w: %s is %s, not %s", ErrDimension, symbol, r.Dimension(), q.Dimension)
+	}
+	_, fromBase := r.Unit.Funcs()
+	u := unit.DisplayUnit{Symbol: symbol, Scale: r.Scale(), Offset: r.Unit.Offset, Dimension: r.Dimension(), FromBase: fromBase}
+	return u.FromBaseValue(q.Value), nil
+}
+
+// RoundTo returns q rounded to the nearest multiple of a unit of its Kind's System,
+// prefix included: a storage quantity rounded to "MiB" is a whole number of MiB. It
+// fails for unknown units and units of another dimension. See parser.RoundTo.
+func (q Quantity) RoundTo(symbol string) (Quantity, error) {
+	sys := q.Kind.System()
+	if sys == nil {
+		return Quantity{}, fmt.Errorf("unknown quantity kind %q", q.Kind)
+	}
+	r, found := sys.ResolveFull(symbol)
+	if !found {
+		return Quantity{}, &parser.UnknownUnitError{Symbol: symbol}
+	}
+	if !r.Dimension().Equals(q.Dimension) {
+		return Quantity{}, fmt.Errorf("%w: %s is %s, not %s", ErrDimension, symbol, r.Dimension(), q.Dimension)
+	}
+	val, err := parser.RoundTo(q.Value, symbol, sys)
+	if err != nil {
+		return Quantity{}, err
+	}
+	q.Value = val
+	return q, nil
+}
+
+// checkDimension reports ErrDimension unless q has dimension dim.
+func (q Quantity) checkDimension(dim unit.Dimension) error {
+	if !q.Dimension.Equals(dim) {
+		return fmt.Errorf("%w: %s, not %s", ErrDimension, q.Dimension, dim)
+	}
+	return nil
+}
+
+// toInt64 converts an integral float64 within the int64 range.
+func toInt64(v float64) (int64, error) {
+	if math.IsNaN(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+		return 0, fmt.Errorf("%w: %g", parser.ErrOverflow, v)
+	}
+	if v != math.Trunc(v) {
+		return 0, &parser.PrecisionLossError{Value: v}
+	}
+	return int64(v), nil
+}
+
+// Format implements fmt.Formatter using parser.FormatState with the System and unit
+// ladder of q.Kind: %v prints "1.5h", %.2f "1.50h" and %#v adds the value in the base
+// unit and the dimension. Quantities of an unknown kind print their base value.
+func (q Quantity) Format(state fmt.State, verb rune) {
+	d := q.Kind.detector()
+	if d == nil {
+		fmt.Fprintf(state, fmt.FormatString(state, verb), q.Value)
+		return
+	}
+	parser.FormatState(state, verb, q.Value, d.sys, d.formatOptions([]parser.FormatOption{parser.WithDimension(q.Dimension)})...)
+}
+
+// String formats q with the best fitting unit of its kind, e.g. "1.5h".
+func (q Quantity) String() string {
+	return fmt.Sprint(q)
+}
@@ -0,0 +1,153 @@
+package str2quantity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func TestQuantity_AsDuration(t *testing.T) {
+	q, _, err := Detect("1h30m")
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if d, err := q.AsDuration(); err != nil || d != 90*time.Minute {
+		t.Errorf("AsDuration() = %v, %v; want 1h30m0s", d, err)
+	}
+
+	size, _, _ := Detect("1KiB")
+	if _, err := size.AsDuration(); !errors.Is(err, ErrDimension) {
+		t.Errorf("storage AsDuration() error = %v, want ErrDimension", err)
+	}
+	var loss *parser.PrecisionLossError
+	if _, err := (Quantity{Kind: KindDuration, Value: 0.5, Dimension: q.Dimension}).AsDuration(); !errors.As(err, &loss) {
+		t.Errorf("0.5ns AsDuration() error = %v, want a precision loss", err)
+	}
+	if _, err := (Quantity{Kind: KindDuration, Value: 1e19, Dimension: q.Dimension}).AsDuration(); !errors.Is(err, parser.ErrOverflow) {
+		t.Errorf("1e19ns AsDuration() error = %v, want ErrOverflow", err)
+	}
+}
+
+func TestQuantity_AsBytes(t *testing.T) {
+	q, _, err := Detect("1.5KiB")
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if n, err := q.AsBytes(); err != nil || n != 1536 {
+		t.Errorf("AsBytes() = %d, %v; want 1536", n, err)
+	}
+
+	bits, _, _ := Detect("4b")
+	var loss *parser.PrecisionLossError
+	if _, err := bits.AsBytes(); !errors.As(err, &loss) {
+		t.Errorf("4b AsBytes() error = %v, want a precision loss", err)
+	}
+	length, _, _ := Detect("1km")
+	if _, err := length.AsBytes(); !errors.Is(err, ErrDimension) {
+		t.Errorf("length AsBytes() error = %v, want ErrDimension", err)
+	}
+}
+
+func TestQuantity_AsFloat(t *testing.T) {
+	tests := []struct {
+		input, symbol string
+		want          float64
+	}{
+		{"1h30m", "h", 1.5},
+		{"1500ms", "s", 1.5},
+		{"2GiB", "MiB", 2048},
+		{"1km", "cm", 100000},
+	}
+	for _, tt := range tests {
+		q, _, err := Detect(tt.input)
+		if err != nil {
+			t.Fatalf("Detect(%q) failed: %v", tt.input, err)
+		}
+		if got, err := q.AsFloat(tt.symbol); err != nil || math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Detect(%q).AsFloat(%q) = %g, %v; want %g", tt.input, tt.symbol, got, err, tt.want)
+		}
+	}
+
+	q, _, _ := Detect("1h")
+	var unknown *parser.UnknownUnitError
+	if _, err := q.AsFloat("parsec"); !errors.As(err, &unknown) {
+		t.Errorf("AsFloat(parsec) error = %v, want an unknown unit", err)
+	}
+	if _, err := (Quantity{Kind: "volume"}).AsFloat("l"); err == nil {
+		t.Error("AsFloat on an unknown kind expected error, got nil")
+	}
+}
+
+func TestQuantity_RoundTo(t *testing.T) {
+	q, _, err := Detect("1.6MiB")
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	r, err := q.RoundTo("MiB")
+	if err != nil || r.Value != 2*(1<<20)*8 || r.Kind != KindStorage {
+		t.Errorf("RoundTo(MiB) = %+v, %v; want 2MiB", r, err)
+	}
+	var unknown *parser.UnknownUnitError
+	if _, err := q.RoundTo("h"); !errors.As(err, &unknown) {
+		t.Errorf("RoundTo(h) on storage error = %v, want an unknown unit", err)
+	}
+	if _, err := (Quantity{Kind: KindStorage, Value: 8, Dimension: unit.DimTime}).RoundTo("MiB"); !errors.Is(err, ErrDimension) {
+		t.Errorf("RoundTo(MiB) of a time dimension error = %v, want ErrDimension", err)
+	}
+}
+
+func TestQuantity_Format(t *testing.T) {
+	q, _, err := Detect("1h30m")
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	for format, want := range map[string]string{
+		"%v":   "1.5h",
+		"%.2f": "1.50h",
+		"%#v":  "1.5h (5.4e+12ns, " + q.Dimension.String() + ")",
+	} {
+		if got := fmt.Sprintf(format, q); got != want {
+			t.Errorf("Sprintf(%q) = %q, want %q", format, got, want)
+		}
+	}
+	if got := q.String(); got != "1.5h" {
+		t.Errorf("String() = %q, want %q", got, "1.5h")
+	}
+	if got := fmt.Sprint(Quantity{Kind: "volume", Value: 3}); got != "3" {
+		t.Errorf("unknown kind Sprint = %q, want %q", got, "3")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		val  float64
+		dim  unit.Dimension
+		want string
+	}{
+		{90e9, unit.DimTime, "1.5m"},
+		{3 * 86400e9, unit.DimTime, "72h"},
+		{2500, unit.DimTime, "2.5µs"},
+		{8 * 1536, unit.DimStorage, "1.5KiB"},
+		{8e9, unit.DimStorage, "953.67431640625MiB"},
+		{1e-5, unit.DimLength, "10µm"},
+		{0.3, unit.DimLength, "30cm"},
+		{1e12, unit.DimLength, "1000000000km"},
+	}
+	for _, tt := range tests {
+		if got, err := Format(tt.val, tt.dim); err != nil || got != tt.want {
+			t.Errorf("Format(%g, %s) = %q, %v; want %q", tt.val, tt.dim, got, err, tt.want)
+		}
+	}
+
+	if got, err := Format(5400e9, unit.DimTime, parser.WithUnits("s")); err != nil || got != "5400s" {
+		t.Errorf("Format with WithUnits(s) = %q, %v; want %q", got, err, "5400s")
+	}
+	if _, err := Format(1, unit.DimMass); !errors.Is(err, ErrDimension) {
+		t.Errorf("Format(mass) error = %v, want ErrDimension", err)
+	}
+}
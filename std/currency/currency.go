@@ -0,0 +1,94 @@
+package currency
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// System is the unit system for magnitude suffixes ("k", "M", "bn", ...).
+var System *unit.System
+
+// amountUnit is the DefaultUnit of System. Unit tokens never start with a digit, so
+// it cannot be written in an input.
+const amountUnit = "1"
+
+// symbols maps leading currency symbols to ISO 4217 codes.
+// Longer symbols must be listed before their prefixes (e.g. "US$" before "$").
+var symbols = []struct {
+	sym  string
+	code string
+}{
+	{"US$", "USD"},
+	{"C$", "CAD"},
+	{"A$", "AUD"},
+	{"$", "USD"},
+	{"€", "EUR"},
+	{"£", "GBP"},
+	{"¥", "JPY"},
+	{"₹", "INR"},
+	{"₩", "KRW"},
+	{"₽", "RUB"},
+	{"₿", "BTC"},
+}
+
+func init() {
+	// Initialize system: single amount, suffixes are case-insensitive ("3m" == "3M" in finance).
+	System = unit.NewSystem(unit.SystemConfig{
+		AllowMultiPart:      false,
+		CaseInsensitive:     true,
+		DigitGroupSeparator: ',', // "$1,000"
+		DefaultUnit:         amountUnit,
+	})
+
+	// Bare amounts ("$1,000") are counted in the unit one.
+	System.Add(amountUnit, 1, unit.DimDimensionless)
+
+	// Magnitude suffixes, dimensionless.
+	magnitudes := []struct {
+		syms []string
+		val  float64
+	}{
+		{[]string{"k"}, 1e3},                    // Thousand
+		{[]string{"m", "mm", "mn", "mio"}, 1e6}, // Million
+		{[]string{"b", "bn", "bln"}, 1e9},       // Billion
+		{[]string{"t", "tn", "tr"}, 1e12},       // Trillion
+	}
+	for _, m := range magnitudes {
+		for _, sym := range m.syms {
+			System.Add(sym, m.val, unit.DimDimensionless)
+		}
+	}
+}
+
+// Parse parses an amount such as "$1.5k", "€3M", "-£200" or "$1,000" and returns the
+// value together with the detected ISO 4217 currency code ("" if no symbol was given).
+// The sign may come before or after the symbol ("-$5", "$-5"), but not both.
+func Parse(s string) (float64, string, error) {
+	s = strings.TrimSpace(s)
+
+	// A sign before the symbol moves to the amount, where the parser rejects a second one.
+	sign := ""
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], strings.TrimSpace(s[1:])
+	}
+
+	code := ""
+	for _, c := range symbols {
+		if strings.HasPrefix(s, c.sym) {
+			code, s = c.code, strings.TrimSpace(s[len(c.sym):])
+			break
+		}
+	}
+	if s == "" {
+		return 0, code, errors.New("missing amount")
+	}
+
+	val, _, err := parser.Parse[float64](sign+s, System)
+	if err != nil {
+		return 0, code, err
+	}
+	return val, code, nil
+}
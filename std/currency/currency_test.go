@@ -0,0 +1,71 @@
+package currency
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		want     float64
+		wantCode string
+	}{
+		{"$1.5k", 1500, "USD"},
+		{"€3M", 3e6, "EUR"},
+		{"£200", 200, "GBP"},
+		{"¥ 10bn", 1e10, "JPY"},
+		{"US$2.5mm", 2.5e6, "USD"},
+		{"C$1T", 1e12, "CAD"},
+		{"-$5k", -5000, "USD"},
+		{"$-5k", -5000, "USD"},
+		{"42K", 42000, ""}, // No currency symbol
+		{"0.25", 0.25, ""},
+		{"$1e3", 1000, "USD"}, // Exponent, not a suffix
+		{"€2.5E-1", 0.25, "EUR"},
+		{"$1e3k", 1e6, "USD"},
+		{"- $5", -5, "USD"},
+		{"+€5", 5, "EUR"},
+		{"$1,000", 1000, "USD"},
+		{"-$1,250,000.50", -1250000.5, "USD"},
+	}
+
+	for _, tt := range tests {
+		got, code, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-9 || code != tt.wantCode {
+			t.Errorf("Parse(%q) = %v, %q; want %v, %q", tt.input, got, code, tt.want, tt.wantCode)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	invalidInputs := []string{
+		"$",      // Symbol only
+		"",       // Empty
+		"$1.5x",  // Unknown suffix
+		"$1k2k",  // Multi-part
+		"$1.2.3", // Bad number
+		"$1e",    // Exponent without digits
+	}
+
+	for _, input := range invalidInputs {
+		if _, _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) expected error, got nil", input)
+		}
+	}
+
+	// Malformed amounts report the parser's typed errors.
+	for _, input := range []string{"-$-5k", "-$-5", "$1,00"} {
+		var se *parser.SyntaxError
+		if _, _, err := Parse(input); !errors.Is(err, parser.ErrInvalidNumber) || !errors.As(err, &se) {
+			t.Errorf("Parse(%q) error = %v, want a *parser.SyntaxError wrapping ErrInvalidNumber", input, err)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package currency provides parsing of currency amounts written in financial shorthand.
+package currency
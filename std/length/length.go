@@ -1,8 +1,6 @@
 package length
 
 import (
-	"errors"
-
 	"github.com/armourstill/str2quantity/parser"
 	"github.com/armourstill/str2quantity/unit"
 )
@@ -41,14 +39,10 @@ func init() {
 
 // ParseLength parses a length string into meters (float64).
 func ParseLength(s string) (float64, error) {
-	val, dim, err := parser.Parse[float64](s, System)
-	if err != nil {
-		return 0, err
-	}
-
-	if !dim.Equals(unit.DimLength) {
-		return 0, errors.New("parsed quantity is not a length")
-	}
-
-	return val, nil
+	val, _, err := parseLength(s, System)
+	return val, err
 }
+
+// parseLength parses into meters and rejects non-length quantities.
+var parseLength = parser.Chain(parser.Parse[float64],
+	parser.RequireDimension[float64](unit.DimLength, "length"))
@@ -1,6 +1,7 @@
 package length
 
 import (
+	"fmt"
 	"math"
 	"testing"
 )
@@ -54,3 +55,21 @@ func TestParseLength_Errors(t *testing.T) {
 		}
 	}
 }
+
+func TestScan(t *testing.T) {
+	var l Length
+	if _, err := fmt.Sscanf("height=1.8m", "height=%v", &l); err != nil || math.Abs(float64(l)-1.8) > 1e-12 {
+		t.Errorf("Sscanf(height=1.8m) = %v, %v, want 1.8", l, err)
+	}
+	if _, err := fmt.Sscan("3s", &l); err == nil {
+		t.Error("Sscan(3s) expected error")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	for format, want := range map[string]string{"%v": "1.5km", "%.2f": "1.50km", "%g": "1500"} {
+		if got := fmt.Sprintf(format, Length(1500)); got != want {
+			t.Errorf("Sprintf(%q, 1500) = %q, want %q", format, got, want)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package length
+
+import (
+	"fmt"
+
+	"github.com/armourstill/str2quantity/parser"
+)
+
+// Length is a length in meters that implements fmt.Scanner using ParseLength.
+type Length float64
+
+// Scan implements fmt.Scanner.
+func (l *Length) Scan(state fmt.ScanState, verb rune) error {
+	tok, err := parser.ScanToken(state, verb)
+	if err != nil {
+		return err
+	}
+	v, err := ParseLength(tok)
+	if err != nil {
+		return err
+	}
+	*l = Length(v)
+	return nil
+}
+
+// Format implements fmt.Formatter using parser.FormatState, so %v prints "1.5km".
+func (l Length) Format(state fmt.State, verb rune) {
+	parser.FormatState(state, verb, float64(l), System)
+}
+
+// String formats the length with the best fitting unit, e.g. "1.5km".
+func (l Length) String() string {
+	return fmt.Sprint(l)
+}
@@ -0,0 +1,2 @@
+// Package percent provides parsing of dimensionless ratios written as percent, per mille or parts per million.
+package percent
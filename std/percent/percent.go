@@ -0,0 +1,40 @@
+package percent
+
+import (
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// System is the unit system for ratios; all units are dimensionless fractions of one.
+var System *unit.System
+
+func init() {
+	// Initialize system: a single ratio, symbols fold case ("PPM" == "ppm").
+	System = unit.NewSystem(unit.SystemConfig{
+		AllowMultiPart:  false,
+		CaseInsensitive: true,
+	})
+
+	ratios := []struct {
+		syms []string
+		val  float64
+	}{
+		{[]string{"%", "pct", "percent"}, 1e-2}, // Percent
+		{[]string{"‰", "permille"}, 1e-3},       // Per mille
+		{[]string{"‱", "bp", "bps"}, 1e-4},      // Basis point
+		{[]string{"ppm"}, 1e-6},                 // Parts per million
+		{[]string{"ppb"}, 1e-9},                 // Parts per billion
+	}
+	for _, r := range ratios {
+		for _, sym := range r.syms {
+			System.Add(sym, r.val, unit.DimDimensionless)
+		}
+	}
+}
+
+// ParseRatio parses a ratio such as "15%", "3‰" or "250 ppm" into a fraction of one
+// (0.15, 0.003, 0.00025).
+func ParseRatio(s string) (float64, error) {
+	val, _, err := parser.Parse[float64](s, System)
+	return val, err
+}
@@ -0,0 +1,51 @@
+package percent
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseRatio(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"15%", 0.15},
+		{"15 %", 0.15},
+		{"100%", 1},
+		{"-2.5%", -0.025},
+		{"3‰", 0.003},
+		{"25bp", 0.0025},
+		{"1‱", 0.0001},
+		{"250 ppm", 0.00025},
+		{"250 PPM", 0.00025},
+		{"7ppb", 7e-9},
+		{"12 percent", 0.12},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRatio(tt.input)
+		if err != nil {
+			t.Errorf("ParseRatio(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-15 {
+			t.Errorf("ParseRatio(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseRatio_Errors(t *testing.T) {
+	invalidInputs := []string{
+		"15",     // Missing unit
+		"15kg",   // Unknown unit
+		"1% 2%",  // Multi-part
+		"1.2.3%", // Bad number
+	}
+
+	for _, input := range invalidInputs {
+		if _, err := ParseRatio(input); err == nil {
+			t.Errorf("ParseRatio(%q) expected error, got nil", input)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/armourstill/str2quantity/parser"
+)
+
+// Bytes is a storage quantity in Bytes that implements fmt.Scanner using ParseBytes.
+type Bytes float64
+
+// Scan implements fmt.Scanner.
+func (b *Bytes) Scan(state fmt.ScanState, verb rune) error {
+	tok, err := parser.ScanToken(state, verb)
+	if err != nil {
+		return err
+	}
+	v, err := ParseBytes(tok)
+	if err != nil {
+		return err
+	}
+	*b = Bytes(v)
+	return nil
+}
+
+// Format implements fmt.Formatter using parser.FormatState, so %v prints "1.5KiB".
+func (b Bytes) Format(state fmt.State, verb rune) {
+	parser.FormatState(state, verb, float64(b)*bitsPerByte, System, parser.WithUnits("B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"))
+}
+
+// String formats the size with the best fitting IEC unit, e.g. "1.5KiB".
+func (b Bytes) String() string {
+	return fmt.Sprint(b)
+}
+
+// Bits is an exact storage quantity in bits that implements fmt.Scanner using ParseBits.
+type Bits int64
+
+// Scan implements fmt.Scanner.
+func (b *Bits) Scan(state fmt.ScanState, verb rune) error {
+	tok, err := parser.ScanToken(state, verb)
+	if err != nil {
+		return err
+	}
+	v, err := ParseBits(tok)
+	if err != nil {
+		return err
+	}
+	*b = Bits(v)
+	return nil
+}
+
+// Format implements fmt.Formatter using parser.FormatState, so %v prints "1.5Kib".
+// Verbs other than %v, %s, %q and %f format the count of bits, so %d prints "1536".
+func (b Bits) Format(state fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's', 'q', 'f', 'F':
+		parser.FormatState(state, verb, float64(b), System, parser.WithUnits("b", "Kib", "Mib", "Gib", "Tib", "Pib", "Eib"))
+	default:
+		fmt.Fprintf(state, fmt.FormatString(state, verb), int64(b))
+	}
+}
+
+// String formats the size with the best fitting IEC unit, e.g. "1.5Kib".
+func (b Bits) String() string {
+	return fmt.Sprint(b)
+}
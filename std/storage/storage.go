@@ -1,8 +1,6 @@
 package storage
 
 import (
-	"errors"
-
 	"github.com/armourstill/str2quantity/parser"
 	"github.com/armourstill/str2quantity/unit"
 )
@@ -14,72 +12,59 @@ var System *unit.System
 const bitsPerByte = 8.0
 
 func init() {
-	// Initialize system: Check common usage (no multipart, correct case sensitivity).
+	// Initialize system: no multipart, no negative sizes; symbols fold case except the bit/Byte abbreviations.
 	System = unit.NewSystem(unit.SystemConfig{
 		AllowMultiPart:  false,
-		CaseInsensitive: false,
+		CaseInsensitive: true,
+		NegativePolicy:  unit.RejectNegative,
+		AutoPlural:      true, // "bits", "Bytes"
 	})
 
 	// --- 1. Register Base Units ---
 	// Bit (b) is base unit (Scale=1.0) for integer counting compatibility.
+	// "b" and "B" stay case-sensitive since they only differ by case.
 
 	// Bit (Base Unit)
-	System.Add("b", 1.0, unit.DimStorage)
-	System.Add("bit", 1.0, unit.DimStorage)
-	System.Add("bits", 1.0, unit.DimStorage)
+	System.Add("b", 1.0, unit.DimStorage, unit.WithCaseSensitive())
+	System.AddAlias("bit", "b")
 
 	// Byte (1 Byte = 8 bits)
-	System.Add("B", bitsPerByte, unit.DimStorage)
-	System.Add("Byte", bitsPerByte, unit.DimStorage)
-	System.Add("Bytes", bitsPerByte, unit.DimStorage)
-	// Duplicate unit removed for brevity
+	System.Add("B", bitsPerByte, unit.DimStorage, unit.WithCaseSensitive())
+	System.AddAlias("Byte", "B")
 
-	targetUnits := []string{"B", "Byte", "Bytes", "b", "bit", "bits"}
+	// Aliases inherit the prefix bindings of their unit.
+	targetUnits := []string{"B", "b"}
 
 	// --- 2. Register IEC Standard Prefixes (Binary 1024) ---
-	// Explicitly register case variants so users can write "1kib" even in case-sensitive mode.
+	// Prefixes fold case, so "Ki" also accepts "ki" and "KI".
 	iecPrefixes := []struct {
-		val  float64
-		syms []string
+		sym string
+		val float64
 	}{
-		{float64(1 << 10), []string{"Ki", "ki", "KI"}}, // Ki = 2^10
-		{float64(1 << 20), []string{"Mi", "mi", "MI"}}, // Mi = 2^20
-		{float64(1 << 30), []string{"Gi", "gi", "GI"}}, // Gi = 2^30
-		{float64(1 << 40), []string{"Ti", "ti", "TI"}}, // Ti = 2^40
-		{float64(1 << 50), []string{"Pi", "pi", "PI"}}, // Pi = 2^50
-		{float64(1 << 60), []string{"Ei", "ei", "EI"}}, // Ei = 2^60
+		{"Ki", float64(1 << 10)}, // Ki = 2^10
+		{"Mi", float64(1 << 20)}, // Mi = 2^20
+		{"Gi", float64(1 << 30)}, // Gi = 2^30
+		{"Ti", float64(1 << 40)}, // Ti = 2^40
+		{"Pi", float64(1 << 50)}, // Pi = 2^50
+		{"Ei", float64(1 << 60)}, // Ei = 2^60
 	}
 	for _, p := range iecPrefixes {
-		for _, sym := range p.syms {
-			System.AddPrefix(sym, p.val, targetUnits...)
-		}
+		System.AddPrefix(p.sym, p.val, targetUnits...)
 	}
 
 	// --- 3. Register JEDEC/Binary Prefixes ---
 	// Adopts JEDEC standard: K, M, G are 1024-based.
-	// Maps both upper/lower case prefixes to binary scale for UX (overriding standard SI meaning of 'm', 'k').
+	// Both upper/lower case map to binary scale for UX (overriding standard SI meaning of 'm', 'k').
 	prefixes := []struct {
 		sym string
 		val float64
 	}{
-		// Kilo (2^10)
-		{"k", float64(1 << 10)},
-		{"K", float64(1 << 10)},
-		// Mega (2^20)
-		{"m", float64(1 << 20)},
-		{"M", float64(1 << 20)},
-		// Giga (2^30)
-		{"g", float64(1 << 30)},
-		{"G", float64(1 << 30)},
-		// Tera (2^40)
-		{"t", float64(1 << 40)},
-		{"T", float64(1 << 40)},
-		// Peta (2^50)
-		{"p", float64(1 << 50)},
-		{"P", float64(1 << 50)},
-		// Exa (2^60)
-		{"e", float64(1 << 60)},
-		{"E", float64(1 << 60)},
+		{"K", float64(1 << 10)}, // Kilo (2^10)
+		{"M", float64(1 << 20)}, // Mega (2^20)
+		{"G", float64(1 << 30)}, // Giga (2^30)
+		{"T", float64(1 << 40)}, // Tera (2^40)
+		{"P", float64(1 << 50)}, // Peta (2^50)
+		{"E", float64(1 << 60)}, // Exa (2^60)
 	}
 	for _, p := range prefixes {
 		System.AddPrefix(p.sym, p.val, targetUnits...)
@@ -93,14 +78,21 @@ func init() {
 // the maximum representable value is approx 1.15 Exabytes (2^63 bits).
 // For larger values (e.g. Zettabytes), use ParseBytes which uses float64.
 func ParseBits(s string) (int64, error) {
-	valBits, dim, err := parser.Parse[int64](s, System)
-	if err != nil {
-		return 0, err
-	}
-	if !dim.Equals(unit.DimStorage) {
-		return 0, errors.New("parsed quantity is not a storage unit")
-	}
-	return valBits, nil
+	return parseBits(s, System)
+}
+
+// Storage parse functions reject quantities of other dimensions.
+var (
+	parseInt64 = parser.Chain(parser.Parse[int64],
+		parser.RequireDimension[int64](unit.DimStorage, "storage unit"))
+	parseFloat64 = parser.Chain(parser.Parse[float64],
+		parser.RequireDimension[float64](unit.DimStorage, "storage unit"))
+)
+
+// parseBits parses a storage string into bits using the given System.
+func parseBits(s string, sys *unit.System) (int64, error) {
+	valBits, _, err := parseInt64(s, sys)
+	return valBits, err
 }
 
 // ParseBytes parses a storage string and returns the quantity in Bytes.
@@ -111,14 +103,16 @@ func ParseBits(s string) (int64, error) {
 // Note: While this allows inputs that result in fractional bits (like "0.5 bit"),
 // it prioritizes range and flexibility over strict physical bit validity.
 func ParseBytes(s string) (float64, error) {
+	return parseBytes(s, System)
+}
+
+// parseBytes parses a storage string into Bytes using the given System.
+func parseBytes(s string, sys *unit.System) (float64, error) {
 	// Parse as float64 bits first.
-	valBits, dim, err := parser.Parse[float64](s, System)
+	valBits, _, err := parseFloat64(s, sys)
 	if err != nil {
 		return 0, err
 	}
-	if !dim.Equals(unit.DimStorage) {
-		return 0, errors.New("parsed quantity is not a storage unit")
-	}
 	// Convert bits to Bytes.
 	return valBits / bitsPerByte, nil
 }
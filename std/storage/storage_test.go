@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"fmt"
 	"math"
 	"testing"
 )
@@ -46,9 +47,18 @@ func TestParseStorage(t *testing.T) {
 		{"1miB", m, false}, // Lowercase mi
 		{"1GiB", g, false},
 		{"1Kib", k / 8, false}, // 1 Kibit = 1024 bits = 128 Bytes
+		{"1kIB", k, false},     // Prefix case folds, "B" stays Byte
+
+		// Long names fold case
+		{"2BYTES", 2, false},
+		{"8BIT", 1, false},
 
 		// Decimals
 		{"1.5KB", 1.5 * k, false}, // 1.5 * 1024
+
+		// Exa prefix vs scientific notation
+		{"1EB", k * k * k * k * k * k, false},
+		{"1e3B", 1000, false},
 		{"0.5B", 0.5, false},
 
 		// Complex formatting
@@ -59,6 +69,7 @@ func TestParseStorage(t *testing.T) {
 		{"10s", 0, true},  // Wrong dimension (time)
 		{"10Kg", 0, true}, // Unknown unit
 		{"invalid", 0, true},
+		{"-1KB", 0, true}, // Negative size
 	}
 
 	for _, tt := range tests {
@@ -120,6 +131,9 @@ func TestParseBits(t *testing.T) {
 
 		// Large values (int64 limit checks)
 		{"1 PiB", 1 << 53, false},
+
+		// Scientific notation
+		{"1e3b", 1000, false},
 	}
 
 	for _, tt := range tests {
@@ -138,3 +152,40 @@ func TestParseBits(t *testing.T) {
 		}
 	}
 }
+
+func TestScan(t *testing.T) {
+	var size Bytes
+	var mtu Bits
+	n, err := fmt.Sscanf("size=1.5GiB mtu=1500B", "size=%v mtu=%v", &size, &mtu)
+	if err != nil || n != 2 {
+		t.Fatalf("Sscanf = %d, %v, want 2, nil", n, err)
+	}
+	if size != 1.5*(1<<30) || mtu != 1500*8 {
+		t.Errorf("Sscanf = %v, %v, want %v, %v", size, mtu, 1.5*(1<<30), 1500*8)
+	}
+
+	if _, err := fmt.Sscan("1bit", &size); err != nil || size != 0.125 {
+		t.Errorf("Sscan(1bit) = %v, %v, want 0.125", size, err)
+	}
+	if _, err := fmt.Sscan("0.5b", &mtu); err == nil {
+		t.Error("Sscan(0.5b) into Bits expected error")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		val    any
+		want   string
+	}{
+		{"%v", Bytes(1536), "1.5KiB"},
+		{"%.2v", Bytes(1 << 30), "1.00GiB"},
+		{"%v", Bits(1536), "1.5Kib"},
+		{"%d", Bits(1536), "1536"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, tt.val); got != tt.want {
+			t.Errorf("Sprintf(%q, %#v) = %q, want %q", tt.format, tt.val, got, tt.want)
+		}
+	}
+}
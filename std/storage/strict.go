@@ -0,0 +1,53 @@
+package storage
+
+import "github.com/armourstill/str2quantity/unit"
+
+// StrictSystem is a case-sensitive storage system that only accepts explicit prefixes:
+// uppercase JEDEC (K, M, G, T, P, E) and IEC (Ki, Mi, Gi, Ti, Pi, Ei).
+// Lowercase shorthands such as "10mb" or "5gb" are rejected, which catches typos
+// (e.g. minutes typed into a size field) in strict deployments.
+var StrictSystem *unit.System
+
+func init() {
+	StrictSystem = unit.NewSystem(unit.SystemConfig{
+		AllowMultiPart:  false,
+		CaseInsensitive: false,
+		NegativePolicy:  unit.RejectNegative,
+		AutoPlural:      true, // "bits", "Bytes"
+	})
+
+	// Base units, spelled exactly.
+	StrictSystem.Add("b", 1.0, unit.DimStorage)
+	StrictSystem.AddAlias("bit", "b")
+	StrictSystem.Add("B", bitsPerByte, unit.DimStorage)
+	StrictSystem.AddAlias("Byte", "B")
+
+	targetUnits := []string{"B", "b"}
+
+	prefixes := []struct {
+		syms []string
+		val  float64
+	}{
+		{[]string{"Ki", "K"}, float64(1 << 10)},
+		{[]string{"Mi", "M"}, float64(1 << 20)},
+		{[]string{"Gi", "G"}, float64(1 << 30)},
+		{[]string{"Ti", "T"}, float64(1 << 40)},
+		{[]string{"Pi", "P"}, float64(1 << 50)},
+		{[]string{"Ei", "E"}, float64(1 << 60)},
+	}
+	for _, p := range prefixes {
+		for _, sym := range p.syms {
+			StrictSystem.AddPrefix(sym, p.val, targetUnits...)
+		}
+	}
+}
+
+// ParseBitsStrict is like ParseBits but uses StrictSystem.
+func ParseBitsStrict(s string) (int64, error) {
+	return parseBits(s, StrictSystem)
+}
+
+// ParseBytesStrict is like ParseBytes but uses StrictSystem.
+func ParseBytesStrict(s string) (float64, error) {
+	return parseBytes(s, StrictSystem)
+}
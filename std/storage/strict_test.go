@@ -0,0 +1,50 @@
+package storage
+
+import "testing"
+
+func TestParseBytesStrict(t *testing.T) {
+	const k = 1024.0
+
+	tests := []struct {
+		input    string
+		expected float64
+		hasError bool
+	}{
+		{"1B", 1, false},
+		{"8b", 1, false},
+		{"1KB", k, false},
+		{"1KiB", k, false},
+		{"1MB", k * k, false},
+		{"1Mb", k * k / 8, false},
+		{"2 Bytes", 2, false},
+
+		// Single-letter lowercase shorthands are rejected
+		{"10mb", 0, true},
+		{"1kB", 0, true},
+		{"5gb", 0, true},
+		{"1kib", 0, true},
+		{"1KIB", 0, true},
+		{"10m", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseBytesStrict(tt.input)
+		if tt.hasError {
+			if err == nil {
+				t.Errorf("ParseBytesStrict(%q) expected error, got nil", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseBytesStrict(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ParseBytesStrict(%q) = %v, expected %v", tt.input, got, tt.expected)
+		}
+	}
+
+	if bits, err := ParseBitsStrict("1KiB"); err != nil || bits != 8192 {
+		t.Errorf("ParseBitsStrict(1KiB) = %d, %v; want 8192", bits, err)
+	}
+}
@@ -0,0 +1,3 @@
+// Package temperature provides standard temperature unit definitions, distinguishing
+// absolute temperatures from temperature differences.
+package temperature
@@ -0,0 +1,157 @@
+package temperature
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// System is the unit system for temperature differences, in kelvin.
+// Its scales only convert interval sizes (1 °C step = 1 K, 1 °F step = 5/9 K).
+var System *unit.System
+
+// AbsoluteSystem is the unit system for absolute temperatures, in kelvin.
+// Its units carry the offset of their zero point, so "20°C" parses as 293.15 K.
+var AbsoluteSystem *unit.System
+
+// Temperature is an absolute thermodynamic temperature. Its only arithmetic is Add and
+// Sub: it is not a number type, so summing absolute temperatures, which is meaningless,
+// does not compile. The zero value is absolute zero.
+type Temperature struct {
+	k float64
+}
+
+// Kelvin returns the absolute temperature of k kelvin.
+func Kelvin(k float64) Temperature {
+	return Temperature{k: k}
+}
+
+// Kelvin returns t in kelvin.
+func (t Temperature) Kelvin() float64 {
+	return t.k
+}
+
+// String renders t in kelvin, e.g. "293.15K".
+func (t Temperature) String() string {
+	return strconv.FormatFloat(t.k, 'g', -1, 64) + "K"
+}
+
+// Format implements fmt.Formatter using parser.FormatState, in kelvin: %v prints
+// "293.15K" and %.0f "293K".
+func (t Temperature) Format(state fmt.State, verb rune) {
+	parser.FormatState(state, verb, t.k, AbsoluteSystem, parser.WithUnits("K"))
+}
+
+// Delta is a temperature difference (ΔT) in kelvin.
+type Delta float64
+
+// Add shifts an absolute temperature by a difference.
+func (t Temperature) Add(d Delta) Temperature {
+	return Temperature{k: t.k + float64(d)}
+}
+
+// Sub returns the difference between two absolute temperatures.
+func (t Temperature) Sub(other Temperature) Delta {
+	return Delta(t.k - other.k)
+}
+
+// String renders d in kelvin, e.g. "5K".
+func (d Delta) String() string {
+	return fmt.Sprint(d)
+}
+
+// Format implements fmt.Formatter using parser.FormatState, in kelvin.
+func (d Delta) Format(state fmt.State, verb rune) {
+	parser.FormatState(state, verb, float64(d), System, parser.WithUnits("K"))
+}
+
+// Add sums two temperature differences.
+func (d Delta) Add(other Delta) Delta {
+	return d + other
+}
+
+// Scan implements fmt.Scanner using ParseTemperature.
+func (t *Temperature) Scan(state fmt.ScanState, verb rune) error {
+	tok, err := parser.ScanToken(state, verb)
+	if err != nil {
+		return err
+	}
+	v, err := ParseTemperature(tok)
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// Scan implements fmt.Scanner using ParseDelta.
+func (d *Delta) Scan(state fmt.ScanState, verb rune) error {
+	tok, err := parser.ScanToken(state, verb)
+	if err != nil {
+		return err
+	}
+	v, err := ParseDelta(tok)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+func init() {
+	// Single values only: "20°C 5°C" has no meaning for absolute temperatures.
+	System = unit.NewSystem(unit.SystemConfig{
+		AllowMultiPart:  false,
+		CaseInsensitive: false,
+	})
+	AbsoluteSystem = unit.NewSystem(System.Config)
+
+	scales := []struct {
+		syms   []string
+		scale  float64
+		offset float64
+	}{
+		{[]string{"K"}, 1, 0},                                       // Kelvin (Base Unit)
+		{[]string{"°C", "℃", "C", "degC"}, 1, 273.15},               // Celsius
+		{[]string{"°F", "℉", "F", "degF"}, 5.0 / 9, 459.67 * 5 / 9}, // Fahrenheit
+		{[]string{"°R", "R", "degR"}, 5.0 / 9, 0},                   // Rankine
+	}
+	for _, s := range scales {
+		for _, sym := range s.syms {
+			System.Add(sym, s.scale, unit.DimTemp)
+			AbsoluteSystem.Add(sym, s.scale, unit.DimTemp, unit.WithOffset(s.offset))
+		}
+	}
+	System.AddPrefix("m", 1e-3, "K")         // millikelvin
+	AbsoluteSystem.AddPrefix("m", 1e-3, "K") // millikelvin
+}
+
+// ParseTemperature parses an absolute temperature ("20°C", "-40 F", "300K") into kelvin.
+func ParseTemperature(s string) (Temperature, error) {
+	val, _, err := parseKelvin(strings.TrimSpace(s), AbsoluteSystem)
+	if err != nil {
+		return Temperature{}, err
+	}
+	if val < 0 {
+		return Temperature{}, fmt.Errorf("temperature %q is below absolute zero", s)
+	}
+	return Temperature{k: val}, nil
+}
+
+// ParseDelta parses a temperature difference ("5K", "10°F") into kelvin.
+// A leading "Δ" is accepted ("Δ5K").
+func ParseDelta(s string) (Delta, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "Δ")
+	val, _, err := parseKelvin(s, System)
+	if err != nil {
+		return 0, err
+	}
+	return Delta(val), nil
+}
+
+// parseKelvin parses into kelvin and rejects non-temperature quantities.
+var parseKelvin = parser.Chain(parser.Parse[float64],
+	parser.RequireDimension[float64](unit.DimTemp, "temperature"))
@@ -0,0 +1,131 @@
+package temperature
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/armourstill/str2quantity/parser"
+)
+
+func TestParseTemperature(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64 // in kelvin
+	}{
+		{"0K", 0},
+		{"300K", 300},
+		{"20°C", 293.15},
+		{"-40 °C", 233.15},
+		{"20℃", 293.15},
+		{"32°F", 273.15},
+		{"-40F", 233.15},
+		{"212 degF", 373.15},
+		{"491.67°R", 273.15},
+		{"500mK", 0.5},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTemperature(tt.input)
+		if err != nil {
+			t.Errorf("ParseTemperature(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if math.Abs(got.Kelvin()-tt.want) > 1e-9 {
+			t.Errorf("ParseTemperature(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseDelta(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Delta // in kelvin
+	}{
+		{"5K", 5},
+		{"5°C", 5}, // A Celsius step equals a kelvin step
+		{"9°F", 5},
+		{"Δ18F", 10},
+		{"-2°C", -2},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDelta(tt.input)
+		if err != nil {
+			t.Errorf("ParseDelta(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if math.Abs(float64(got-tt.want)) > 1e-9 {
+			t.Errorf("ParseDelta(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestTemperatureArithmetic(t *testing.T) {
+	start, _ := ParseTemperature("20°C")
+	rise, _ := ParseDelta("9°F")
+
+	end := start.Add(rise)
+	if math.Abs(end.Kelvin()-298.15) > 1e-9 {
+		t.Errorf("20°C + 9°F delta = %v K, want 298.15 K", end)
+	}
+	if d := end.Sub(start); math.Abs(float64(d)-5) > 1e-9 {
+		t.Errorf("Sub = %v K, want 5 K", d)
+	}
+	if d := rise.Add(rise); math.Abs(float64(d)-10) > 1e-9 {
+		t.Errorf("Delta.Add = %v K, want 10 K", d)
+	}
+	if got := Kelvin(300).String(); got != "300K" {
+		t.Errorf("Kelvin(300).String() = %q, want 300K", got)
+	}
+}
+
+func TestParseTemperature_Errors(t *testing.T) {
+	invalidInputs := []string{
+		"-1K",      // Below absolute zero
+		"-300°C",   // Below absolute zero
+		"20°C 5°C", // Multi-part
+		"20kg",     // Wrong unit
+		"hello",    // Garbage
+		"",         // Empty
+		"1.1.1°C",  // Bad number
+	}
+
+	for _, input := range invalidInputs {
+		if _, err := ParseTemperature(input); err == nil {
+			t.Errorf("ParseTemperature(%q) expected error, got nil", input)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	var temp Temperature
+	var delta Delta
+	n, err := fmt.Sscanf("at 20°C rise 5K", "at %v rise %v", &temp, &delta)
+	if err != nil || n != 2 {
+		t.Fatalf("Sscanf = %d, %v, want 2, nil", n, err)
+	}
+	if math.Abs(temp.Kelvin()-293.15) > 1e-9 || delta != 5 {
+		t.Errorf("Sscanf = %v, %v, want 293.15, 5", temp, delta)
+	}
+}
+
+func TestAbsoluteSystem_Format(t *testing.T) {
+	got, err := parser.Format(293.15, AbsoluteSystem, parser.WithUnits("°C"))
+	if err != nil || got != "20°C" {
+		t.Errorf("Format(293.15 K, °C) = %q, %v, want 20°C", got, err)
+	}
+	got, err = parser.Format(233.15, AbsoluteSystem, parser.WithUnits("°F"))
+	if err != nil || got != "-40°F" {
+		t.Errorf("Format(233.15 K, °F) = %q, %v, want -40°F", got, err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	if got := fmt.Sprintf("%.0f", Kelvin(293.15)); got != "293K" {
+		t.Errorf("Sprintf(%%.0f, 293.15) = %q, want %q", got, "293K")
+	}
+	if got := Delta(5).String(); got != "5K" {
+		t.Errorf("Delta(5).String() = %q, want %q", got, "5K")
+	}
+}
@@ -0,0 +1,103 @@
+package time
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// BusinessCalendar defines business-time units ("2 workdays", "4 business hours")
+// for SLA tooling that counts in business time rather than wall-clock time.
+type BusinessCalendar struct {
+	// HoursPerWorkday is the length of a workday. Defaults to 8 if zero.
+	HoursPerWorkday float64
+	// WorkdaysPerWeek counts workdays from Monday on. Defaults to 5 (Monday-Friday) if zero.
+	WorkdaysPerWeek int
+	// Holidays are non-working dates (only year, month and day are compared).
+	Holidays []time.Time
+}
+
+// businessSpelling matches two-word spellings such as "Business Hours", which are joined into a single unit token.
+var businessSpelling = regexp.MustCompile(`(?i)\bbusiness\s+(hours?|days?|weeks?)\b`)
+
+// withDefaults fills in zero fields.
+func (c BusinessCalendar) withDefaults() BusinessCalendar {
+	if c.HoursPerWorkday == 0 {
+		c.HoursPerWorkday = 8
+	}
+	if c.WorkdaysPerWeek == 0 {
+		c.WorkdaysPerWeek = 5
+	}
+	return c
+}
+
+// System returns a copy of the standard time System extended with business units:
+// "workday"/"businessday" (wd), "workweek"/"businessweek" (ww) and "businesshour" (bh).
+func (c BusinessCalendar) System() *unit.System {
+	c = c.withDefaults()
+	sys := System.Clone()
+
+	hour := 3600 * 1e9
+	day := c.HoursPerWorkday * hour
+	week := float64(c.WorkdaysPerWeek) * day
+
+	units := []struct {
+		syms  []string
+		scale float64
+	}{
+		{[]string{"bh", "businesshour", "businesshours"}, hour},
+		{[]string{"wd", "workday", "workdays", "businessday", "businessdays"}, day},
+		{[]string{"ww", "workweek", "workweeks", "businessweek", "businessweeks"}, week},
+	}
+	for _, u := range units {
+		for _, sym := range u.syms {
+			// Add only fails on frozen Systems, and a Clone is never frozen.
+			_ = sys.Add(sym, u.scale, unit.DimTime)
+		}
+	}
+	return sys
+}
+
+// ParseDuration parses a duration that may use business units, e.g. "2 workdays 4 business hours".
+// It builds the business System on every call; hot paths should keep the result of System instead.
+func (c BusinessCalendar) ParseDuration(s string) (time.Duration, error) {
+	s = businessSpelling.ReplaceAllStringFunc(s, func(m string) string {
+		return strings.ToLower(strings.Join(strings.Fields(m), ""))
+	})
+	val, _, err := parseDuration(s, c.System())
+	return val, err
+}
+
+// IsWorkday reports whether t falls on a workday that is not a holiday.
+func (c BusinessCalendar) IsWorkday(t time.Time) bool {
+	c = c.withDefaults()
+	// Days since Monday: Monday=0 ... Sunday=6.
+	if (int(t.Weekday())+6)%7 >= c.WorkdaysPerWeek {
+		return false
+	}
+	y, m, d := t.Date()
+	for _, h := range c.Holidays {
+		hy, hm, hd := h.Date()
+		if y == hy && m == hm && d == hd {
+			return false
+		}
+	}
+	return true
+}
+
+// AddWorkdays moves t forward by n workdays (backwards if n < 0), skipping weekends and holidays.
+func (c BusinessCalendar) AddWorkdays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if c.IsWorkday(t) {
+			n--
+		}
+	}
+	return t
+}
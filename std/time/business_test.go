@@ -0,0 +1,67 @@
+package time
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBusinessCalendar_ParseDuration(t *testing.T) {
+	defaultCal := BusinessCalendar{}
+	shortWeek := BusinessCalendar{HoursPerWorkday: 7.5, WorkdaysPerWeek: 4}
+
+	tests := []struct {
+		cal   BusinessCalendar
+		input string
+		want  time.Duration
+	}{
+		{defaultCal, "2 workdays", 16 * time.Hour},
+		{defaultCal, "4 business hours", 4 * time.Hour},
+		{defaultCal, "1 Business Day", 8 * time.Hour},
+		{defaultCal, "1ww", 40 * time.Hour},
+		{defaultCal, "1wd 30m", 8*time.Hour + 30*time.Minute}, // Mixed with standard units
+		{shortWeek, "1 workday", 7*time.Hour + 30*time.Minute},
+		{shortWeek, "1 workweek", 30 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.cal.ParseDuration(tt.input)
+		if err != nil {
+			t.Errorf("ParseDuration(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	// The standard System is left untouched.
+	if _, err := ParseDuration("1wd"); err == nil {
+		t.Error("ParseDuration(1wd) on the standard System expected error, got nil")
+	}
+}
+
+func TestBusinessCalendar_AddWorkdays(t *testing.T) {
+	cal := BusinessCalendar{
+		Holidays: []time.Time{time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)},
+	}
+	friday := time.Date(2024, 12, 20, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		n    int
+		want time.Time
+	}{
+		{1, time.Date(2024, 12, 23, 9, 0, 0, 0, time.UTC)}, // Skips the weekend
+		{3, time.Date(2024, 12, 26, 9, 0, 0, 0, time.UTC)}, // Skips Christmas
+		{-1, time.Date(2024, 12, 19, 9, 0, 0, 0, time.UTC)},
+		{0, friday},
+	}
+	for _, tt := range tests {
+		if got := cal.AddWorkdays(friday, tt.n); !got.Equal(tt.want) {
+			t.Errorf("AddWorkdays(%v, %d) = %v, want %v", friday, tt.n, got, tt.want)
+		}
+	}
+
+	if cal.IsWorkday(time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC)) {
+		t.Error("IsWorkday(Saturday) = true, want false")
+	}
+}
@@ -0,0 +1,59 @@
+package time
+
+import (
+	"github.com/armourstill/str2quantity/parser"
+	"github.com/armourstill/str2quantity/unit"
+)
+
+// RemainderPolicy decides what ParseDuration does with a sub-nanosecond remainder
+// left after scaling, e.g. "1.0000000005s".
+type RemainderPolicy int
+
+const (
+	// RemainderError reports a *parser.PrecisionLossError (the default).
+	RemainderError RemainderPolicy = iota
+	// RemainderTruncate drops the remainder of each part, rounding toward zero.
+	RemainderTruncate
+	// RemainderRound rounds each part to the nearest nanosecond, halves away from zero.
+	RemainderRound
+)
+
+// Option configures a single ParseDuration call.
+type Option func(*options)
+
+type options struct {
+	remainder   RemainderPolicy
+	leadingSign bool
+}
+
+// WithRemainder sets the RemainderPolicy for the call, e.g. RemainderTruncate for log
+// ingestion that should not fail on over-precise timestamps.
+func WithRemainder(policy RemainderPolicy) Option {
+	return func(o *options) {
+		o.remainder = policy
+	}
+}
+
+// WithLeadingSign parses signs the way time.ParseDuration does: a leading sign negates
+// the whole duration ("-1h30m" is -90m) and signs on later parts ("1h-30m") are errors.
+func WithLeadingSign() Option {
+	return func(o *options) {
+		o.leadingSign = true
+	}
+}
+
+// parseOptions returns the parser options implementing the options. The parser rounds
+// each part exactly on its integer path, so totals beyond 2^53 ns stay exact.
+func (o options) parseOptions() []parser.ParseOption {
+	var opts []parser.ParseOption
+	switch o.remainder {
+	case RemainderTruncate:
+		opts = append(opts, parser.WithPrecisionPolicy(unit.PrecisionTruncate))
+	case RemainderRound:
+		opts = append(opts, parser.WithPrecisionPolicy(unit.PrecisionRoundNearest))
+	}
+	if o.leadingSign {
+		opts = append(opts, parser.WithSignPolicy(unit.SignLeading))
+	}
+	return opts
+}
@@ -0,0 +1,46 @@
+package time
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/armourstill/str2quantity/parser"
+)
+
+// Duration is a time.Duration that implements fmt.Scanner using ParseDuration,
+// so fmt.Sscanf(line, "timeout=%v", &d) accepts "1h30m" as well as "1.5d".
+type Duration time.Duration
+
+// Scan implements fmt.Scanner.
+func (d *Duration) Scan(state fmt.ScanState, verb rune) error {
+	tok, err := parser.ScanToken(state, verb)
+	if err != nil {
+		return err
+	}
+	v, err := ParseDuration(tok)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// String formats the duration like time.Duration.
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// Format implements fmt.Formatter. %v, %s and %q print String ("1h30m0s"); with a
+// precision, %f and %#v print the best fitting unit through parser.FormatState ("%.1v"
+// is "1.5h"). Other verbs format the nanoseconds, so %d prints "5400000000000".
+func (d Duration) Format(state fmt.State, verb rune) {
+	_, hasPrec := state.Precision()
+	switch {
+	case !hasPrec && (verb == 's' || verb == 'q' || verb == 'v' && !state.Flag('#')):
+		fmt.Fprintf(state, fmt.FormatString(state, verb), d.String())
+	case verb == 'v' || verb == 's' || verb == 'q' || verb == 'f' || verb == 'F':
+		parser.FormatState(state, verb, float64(d), System)
+	default:
+		fmt.Fprintf(state, fmt.FormatString(state, verb), int64(d))
+	}
+}
@@ -1,7 +1,7 @@
 package time
 
 import (
-	"errors"
+	"strings"
 	"time"
 
 	"github.com/armourstill/str2quantity/parser"
@@ -16,6 +16,8 @@ func init() {
 	System = unit.NewSystem(unit.SystemConfig{
 		AllowMultiPart:  true,
 		CaseInsensitive: false, // Go duration strings are case sensitive (ms, not MS)
+		// Accept the Greek mu ("μs") as well as the micro sign, as time.ParseDuration does.
+		NormalizeUnicode: true,
 	})
 
 	// Register Standard Units
@@ -24,7 +26,7 @@ func init() {
 	// SI Time Units
 	System.Add("ns", 1.0, unit.DimTime)
 	System.Add("us", 1e3, unit.DimTime)
-	System.Add("µs", 1e3, unit.DimTime) // Support micro symbol
+	System.AddAlias("µs", "us") // Support micro symbol
 	System.Add("ms", 1e6, unit.DimTime)
 	System.Add("s", 1e9, unit.DimTime)
 
@@ -33,20 +35,70 @@ func init() {
 	System.Add("h", 3600*1e9, unit.DimTime)    // Hour
 	System.Add("d", 24*3600*1e9, unit.DimTime) // Day
 	System.Add("w", 604800*1e9, unit.DimTime)  // Week
+
+	// Display the micro sign, matching time.Duration.String.
+	System.SetDisplaySymbol("µs")
 }
 
+// parseDuration parses into time.Duration and rejects non-time quantities.
+var parseDuration = parser.Chain(parser.Parse[time.Duration],
+	parser.RequireDimension[time.Duration](unit.DimTime, "time duration"))
+
 // ParseDuration parses a duration string into time.Duration.
-// Supports additive formats ("1h30m") and decimal values ("1.5h").
-func ParseDuration(s string) (time.Duration, error) {
-	val, dim, err := parser.Parse[time.Duration](s, System)
-	if err != nil {
-		return 0, err
+// Supports additive formats ("1h30m") and decimal values ("1.5h"). Every part carries
+// its own sign ("-1h30m" is -30m); WithLeadingSign switches to Go's rules.
+// A sub-nanosecond remainder is an error unless WithRemainder says otherwise.
+func ParseDuration(s string, opts ...Option) (time.Duration, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	val, _, err := parseDuration(s, System, o.parseOptions()...)
+	return val, err
+}
+
+// defaultKeywords are the words ParseDurationPhrase strips when no keywords are given.
+var defaultKeywords = []string{"every", "in", "after", "for", "within", "later", "from now"}
+
+// DefaultKeywords returns the words ParseDurationPhrase strips when no keywords are
+// given, e.g. to extend them: ParseDurationPhrase(s, append(DefaultKeywords(), "each")...).
+// The slice is a copy and may be modified.
+func DefaultKeywords() []string {
+	return append([]string(nil), defaultKeywords...)
+}
+
+// ParseDurationPhrase parses a duration wrapped in leading/trailing keywords,
+// as found in scheduler configs and chat-ops commands ("every 5m", "in 2h", "30s later").
+// Keywords match case-insensitively as whole words; DefaultKeywords is used if none are given.
+func ParseDurationPhrase(s string, keywords ...string) (time.Duration, error) {
+	if len(keywords) == 0 {
+		keywords = defaultKeywords
 	}
+	return ParseDuration(stripKeywords(s, keywords))
+}
 
-	// Validate Dimension
-	if !dim.Equals(unit.DimTime) {
-		return 0, errors.New("parsed quantity is not a time duration")
+// stripKeywords repeatedly removes whole-word keywords from both ends of s.
+func stripKeywords(s string, keywords []string) string {
+	s = strings.TrimSpace(s)
+	for stripped := true; stripped; {
+		stripped = false
+		for _, kw := range keywords {
+			n := len(kw)
+			if n == 0 || n > len(s) {
+				continue
+			}
+			if strings.EqualFold(s[:n], kw) && (n == len(s) || isSpace(s[n])) {
+				s, stripped = strings.TrimSpace(s[n:]), true
+			}
+			if n <= len(s) && strings.EqualFold(s[len(s)-n:], kw) && (n == len(s) || isSpace(s[len(s)-n-1])) {
+				s, stripped = strings.TrimSpace(s[:len(s)-n]), true
+			}
+		}
 	}
+	return s
+}
 
-	return val, nil
+// isSpace reports whether c is an ASCII whitespace character.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
 }
@@ -1,8 +1,11 @@
 package time
 
 import (
+	"fmt"
 	"testing"
 	"time"
+
+	"github.com/armourstill/str2quantity/parser"
 )
 
 func TestParseDuration(t *testing.T) {
@@ -19,7 +22,11 @@ func TestParseDuration(t *testing.T) {
 		{"1s 500ms", 1500 * time.Millisecond}, // Space separator handling
 		{"10us", 10 * time.Microsecond},
 		{"10µs", 10 * time.Microsecond},
+		{"10\u03bcs", 10 * time.Microsecond}, // Greek mu instead of the micro sign
 		{"10us45m2h15s", 10*time.Microsecond + 45*time.Minute + 2*time.Hour + 15*time.Second}, // Out-of-order time
+		{"-1h30m", -30 * time.Minute}, // Every part carries its own sign
+		{"-1h-30m", -90 * time.Minute},
+		{"+1h30m", 90 * time.Minute},
 	}
 
 	for _, tt := range tests {
@@ -34,12 +41,25 @@ func TestParseDuration(t *testing.T) {
 	}
 }
 
+func TestParseDuration_LeadingSign(t *testing.T) {
+	if got, err := ParseDuration("-1h30m", WithLeadingSign()); err != nil || got != -90*time.Minute {
+		t.Errorf("ParseDuration(-1h30m, WithLeadingSign) = %v, %v; want -1h30m0s", got, err)
+	}
+	if _, err := ParseDuration("1h-30m", WithLeadingSign()); err == nil {
+		t.Error("ParseDuration(1h-30m, WithLeadingSign) expected error, got nil")
+	}
+	if got, err := ParseDuration("-1.0000000005s", WithLeadingSign(), WithRemainder(RemainderTruncate)); err != nil || got != -time.Second {
+		t.Errorf("ParseDuration with both options = %v, %v; want -1s", got, err)
+	}
+}
+
 func TestParseDuration_Errors(t *testing.T) {
 	invalidInputs := []string{
 		"1kg",    // Wrong unit
 		"hello",  // Garbage
 		"",       // Empty
 		"1.1.1s", // Bad number
+		"+-1h",   // Double sign
 	}
 
 	for _, input := range invalidInputs {
@@ -49,3 +69,134 @@ func TestParseDuration_Errors(t *testing.T) {
 		}
 	}
 }
+
+func TestSystem_FractionalNanoseconds(t *testing.T) {
+	// Sub-nanosecond values are only rejected by integer targets, whatever the unit.
+	for _, input := range []string{"0.5ns", "0.0005us"} {
+		if got, _, err := parser.Parse[float64](input, System); err != nil || got != 0.5 {
+			t.Errorf("Parse[float64](%q) = %v, %v, want 0.5", input, got, err)
+		}
+		if _, err := ParseDuration(input); err == nil {
+			t.Errorf("ParseDuration(%q) expected error, got nil", input)
+		}
+	}
+}
+
+func TestParseDurationPhrase(t *testing.T) {
+	tests := []struct {
+		input    string
+		keywords []string
+		want     time.Duration
+	}{
+		{"every 5m", nil, 5 * time.Minute},
+		{"in 2h", nil, 2 * time.Hour},
+		{"After 30s", nil, 30 * time.Second},
+		{"30s later", nil, 30 * time.Second},
+		{"in 1h30m from now", nil, 90 * time.Minute},
+		{"  within   10ms ", nil, 10 * time.Millisecond},
+		{"1h", nil, time.Hour},
+		{"wait 5s please", []string{"wait", "please"}, 5 * time.Second},
+		{"each 5s", append(DefaultKeywords(), "each"), 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDurationPhrase(tt.input, tt.keywords...)
+		if err != nil {
+			t.Errorf("ParseDurationPhrase(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDurationPhrase(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	invalidInputs := []string{
+		"every",     // Keyword only
+		"inside 5m", // Keyword must be a whole word
+		"every 5kg", // Wrong unit
+	}
+	for _, input := range invalidInputs {
+		if _, err := ParseDurationPhrase(input); err == nil {
+			t.Errorf("ParseDurationPhrase(%q) expected error, got nil", input)
+		}
+	}
+
+	// DefaultKeywords returns a copy; changing it does not change the defaults.
+	DefaultKeywords()[0] = "wait"
+	if _, err := ParseDurationPhrase("every 5m"); err != nil {
+		t.Errorf("ParseDurationPhrase(every 5m) after modifying DefaultKeywords(): %v", err)
+	}
+}
+
+func TestDurationScan(t *testing.T) {
+	var a, b Duration
+	n, err := fmt.Sscanf("timeout=1h30m retry=1.5s", "timeout=%v retry=%v", &a, &b)
+	if err != nil || n != 2 {
+		t.Fatalf("Sscanf = %d, %v, want 2, nil", n, err)
+	}
+	if time.Duration(a) != 90*time.Minute || time.Duration(b) != 1500*time.Millisecond {
+		t.Errorf("Sscanf = %v, %v, want 1h30m0s, 1.5s", a, b)
+	}
+
+	if _, err := fmt.Sscan("5kg", &a); err == nil {
+		t.Error("Sscan(5kg) expected error")
+	}
+	if _, err := fmt.Sscanf("1h", "%d", &a); err == nil {
+		t.Errorf("Sscanf with %%d expected bad verb error")
+	}
+}
+
+func TestParseDurationRemainder(t *testing.T) {
+	tests := []struct {
+		input   string
+		policy  RemainderPolicy
+		want    time.Duration
+		wantErr bool
+	}{
+		{"1.0000000005s", RemainderError, 0, true},
+		{"1.0000000005s", RemainderTruncate, time.Second, false},
+		{"1.0000000005s", RemainderRound, time.Second + 1, false},
+		{"-1.0000000005s", RemainderTruncate, -time.Second, false},
+		{"-1.0000000005s", RemainderRound, -time.Second - 1, false},
+		{"1h 0.0000000004s", RemainderRound, time.Hour, false},
+		{"0.5ns", RemainderRound, 1, false},
+		{"0.5ns", RemainderTruncate, 0, false},
+		{"2562047h 1.0000000005s", RemainderTruncate, 9223369201000000000, false}, // beyond 2^53
+		{"2562047h 1.0000000005s", RemainderRound, 9223369201000000001, false},
+		{"1.5s", RemainderTruncate, 1500 * time.Millisecond, false},
+		{"1e10h", RemainderRound, 0, true}, // Overflow is still an error
+		{"1kg", RemainderTruncate, 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.input, WithRemainder(tt.policy))
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseDuration(%q, %d) expected error, got %v", tt.input, tt.policy, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseDuration(%q, %d) unexpected error: %v", tt.input, tt.policy, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDuration(%q, %d) = %v, want %v", tt.input, tt.policy, got, tt.want)
+		}
+	}
+}
+
+func TestDurationFormat(t *testing.T) {
+	d := Duration(90 * time.Minute)
+	for format, want := range map[string]string{
+		"%v":   "1h30m0s",
+		"%q":   `"1h30m0s"`,
+		"%.1v": "1.5h",
+		"%.2f": "1.50h",
+		"%d":   "5400000000000",
+	} {
+		if got := fmt.Sprintf(format, d); got != want {
+			t.Errorf("Sprintf(%q) = %q, want %q", format, got, want)
+		}
+	}
+}
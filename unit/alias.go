@@ -0,0 +1,54 @@
+package unit
+
+import (
+	"fmt"
+	"sort"
+)
+
+// unitAlias is an alternative spelling of a unit.
+type unitAlias struct {
+	symbol string // as registered
+	unit   string // registry key of the unit
+}
+
+// AddAlias registers alias as another spelling of the canonical unit (e.g. "Byte" for
+// "B"). The alias resolves to the same Unit record and inherits its prefix bindings,
+// including those added later. Aliases follow the System's CaseInsensitive setting,
+// and an alias of an alias spells the same unit.
+func (s *System) AddAlias(alias, canonical string) error {
+	if err := s.checkMutable("add alias " + alias); err != nil {
+		return err
+	}
+	uKey, _, ok := s.lookupUnit(canonical)
+	if !ok {
+		return fmt.Errorf("cannot alias unknown unit: %s", canonical)
+	}
+	if alias == "" {
+		return fmt.Errorf("alias of %s without symbol", canonical)
+	}
+	if key, _, ok := s.lookupUnit(alias); ok {
+		if _, _, direct := s.lookupSymbol(alias); direct || key != uKey {
+			return fmt.Errorf("alias %s already defined", alias)
+		}
+		return nil
+	}
+	s.aliases[s.normalizeKey(alias)] = unitAlias{symbol: alias, unit: uKey}
+	return nil
+}
+
+// Aliases returns the aliases of the unit with the given symbol in ascending order,
+// or nil for unknown units and units without aliases.
+func (s *System) Aliases(symbol string) []string {
+	key, _, ok := s.lookupUnit(symbol)
+	if !ok {
+		return nil
+	}
+	var out []string
+	for _, a := range s.aliases {
+		if a.unit == key {
+			out = append(out, a.symbol)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
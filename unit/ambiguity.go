@@ -0,0 +1,82 @@
+package unit
+
+import "strings"
+
+// Ambiguity is a symbol with several readings of different meaning, e.g. "min" as the
+// minute and as milli-inch when a unit "in" and a prefix "m" are registered too.
+type Ambiguity struct {
+	Symbol string
+	// Readings lists one Resolution per meaning; the first is the one Resolve picks
+	// unless SystemConfig.RejectAmbiguousUnits is set.
+	Readings []Resolution
+}
+
+// String renders the ambiguity as e.g. "min: min | m+in".
+func (a Ambiguity) String() string {
+	readings := make([]string, len(a.Readings))
+	for i, r := range a.Readings {
+		readings[i] = r.Alias
+		if r.Prefix.Symbol != "" {
+			readings[i] = r.Prefix.Symbol + "+" + r.Alias
+		}
+	}
+	return a.Symbol + ": " + strings.Join(readings, " | ")
+}
+
+// Ambiguity reports the readings of symbol if it has more than one meaning: an exact
+// unit and prefixed units that differ in scale or dimension.
+func (s *System) Ambiguity(symbol string) (Ambiguity, bool) {
+	var readings []Resolution
+	add := func(r Resolution) {
+		for _, prev := range readings {
+			if prev.Scale() == r.Scale() && prev.Dimension().Equals(r.Dimension()) {
+				return
+			}
+		}
+		readings = append(readings, r)
+	}
+
+	if _, u, ok := s.lookupUnit(symbol); ok {
+		add(Resolution{Unit: u, Alias: symbol, Exponent: 1})
+	}
+	for i, p := range s.prefixes {
+		pLen := len(p.Symbol)
+		pKey := s.prefixKey(i)
+		if len(symbol) <= pLen || !s.prefixMatches(i, symbol[:pLen]) {
+			continue
+		}
+		if uKey, u, ok := s.lookupUnit(symbol[pLen:]); ok && u.Linear() && s.prefixAllowed(uKey, pKey) {
+			add(Resolution{Unit: u, Prefix: p, Alias: symbol[pLen:], Exponent: 1})
+		}
+	}
+
+	if len(readings) < 2 {
+		return Ambiguity{}, false
+	}
+	return Ambiguity{Symbol: symbol, Readings: readings}, true
+}
+
+// Ambiguities lists the ambiguous symbols among the units, aliases and prefixed units
+// of the System, sorted by symbol, e.g. to lint a System before it is used.
+func (s *System) Ambiguities() []Ambiguity {
+	symbols := make(map[string]bool)
+	for key, u := range s.units {
+		symbols[u.Symbol] = true
+		for _, p := range s.prefixes {
+			if u.Linear() && s.prefixAllowed(key, s.normalizeKey(p.Symbol)) {
+				symbols[p.Symbol+u.Symbol] = true
+			}
+		}
+	}
+	for _, a := range s.aliases {
+		symbols[a.symbol] = true
+	}
+
+	var out []Ambiguity
+	for _, symbol := range sortedKeys(symbols) {
+		if a, ok := s.Ambiguity(symbol); ok {
+			out = append(out, a)
+		}
+	}
+	return out
+}
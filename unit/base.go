@@ -0,0 +1,39 @@
+package unit
+
+import "fmt"
+
+// SetBase declares symbol (optionally prefixed) the base unit of dim, i.e. what "1"
+// means for integer parse results of that dimension. It fails unless symbol resolves
+// to a linear unit of dim with Scale exactly 1, so a System whose scales are relative
+// to the wrong unit (e.g. milliseconds in a seconds-based System) is caught when it is
+// defined rather than by wrong totals at parse time. Validate reports declared base
+// units that later stop having Scale 1.
+func (s *System) SetBase(dim Dimension, symbol string) error {
+	if err := s.checkMutable("set base unit " + symbol); err != nil {
+		return err
+	}
+	r, ok := s.ResolveFull(symbol)
+	if !ok {
+		return fmt.Errorf("unknown base unit: %s", symbol)
+	}
+	if !r.Dimension().Equals(dim) {
+		return fmt.Errorf("cannot make %s the base unit of %s: unit has dimension %s", symbol, dim, r.Dimension())
+	}
+	if !r.Unit.Linear() {
+		return fmt.Errorf("cannot make %s the base unit of %s: unit is not linear", symbol, dim)
+	}
+	if scale := r.Scale(); scale != 1 {
+		return fmt.Errorf("cannot make %s the base unit of %s: scale is %g, want 1", symbol, dim, scale)
+	}
+	if s.bases == nil {
+		s.bases = make(map[Dimension]string)
+	}
+	s.bases[dim] = symbol
+	return nil
+}
+
+// Base returns the base unit declared for dim with SetBase.
+func (s *System) Base(dim Dimension) (string, bool) {
+	symbol, ok := s.bases[dim]
+	return symbol, ok
+}
@@ -0,0 +1,150 @@
+package unit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// siPrefixes are the SI prefixes bound by Builder.SIPrefixes; "u" spells micro in ASCII.
+var siPrefixes = []struct {
+	Symbol string
+	Scale  float64
+}{
+	{"q", 1e-30}, {"r", 1e-27}, {"y", 1e-24}, {"z", 1e-21}, {"a", 1e-18},
+	{"f", 1e-15}, {"p", 1e-12}, {"n", 1e-9}, {"µ", 1e-6}, {"u", 1e-6},
+	{"m", 1e-3}, {"c", 1e-2}, {"d", 1e-1}, {"da", 1e1}, {"h", 1e2},
+	{"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12}, {"P", 1e15},
+	{"E", 1e18}, {"Z", 1e21}, {"Y", 1e24}, {"R", 1e27}, {"Q", 1e30},
+}
+
+// Builder constructs a System with chained calls and validates it at Build time,
+// so errors cannot be dropped between Add and AddPrefix calls:
+//
+//	sys, err := unit.NewBuilder().
+//		Base("m", unit.DimLength).Unit("ft", 0.3048).SIPrefixes("m").
+//		Build()
+//
+// Unlike Add, the Builder rejects units registered twice.
+type Builder struct {
+	config  SystemConfig
+	dim     Dimension
+	hasBase bool
+	steps   []func(*System) error
+}
+
+// NewBuilder returns an empty Builder with a zero SystemConfig.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Config sets the configuration of the built System.
+func (b *Builder) Config(config SystemConfig) *Builder {
+	b.config = config
+	return b
+}
+
+// Base adds a unit with Scale 1, declares it the base unit of dim (see System.SetBase)
+// and makes dim the dimension of the following Unit calls.
+func (b *Builder) Base(symbol string, dim Dimension, opts ...UnitOption) *Builder {
+	b.dim, b.hasBase = dim, true
+	b.add(symbol, 1, dim, opts)
+	return b.step(func(s *System) error {
+		if _, _, ok := s.lookupUnit(symbol); !ok {
+			return nil // reported by add
+		}
+		return s.SetBase(dim, symbol)
+	})
+}
+
+// Unit adds a unit of the dimension of the last Base call, scale times the base unit.
+func (b *Builder) Unit(symbol string, scale float64, opts ...UnitOption) *Builder {
+	if !b.hasBase {
+		return b.step(func(*System) error {
+			return fmt.Errorf("unit %s: no base unit defined", symbol)
+		})
+	}
+	return b.add(symbol, scale, b.dim, opts)
+}
+
+// Alias adds alias as another spelling of the canonical unit (see System.AddAlias).
+func (b *Builder) Alias(alias, canonical string) *Builder {
+	return b.step(func(s *System) error {
+		return s.AddAlias(alias, canonical)
+	})
+}
+
+// Prefix adds a prefix bound to the given units.
+func (b *Builder) Prefix(symbol string, scale float64, units ...string) *Builder {
+	return b.step(func(s *System) error {
+		if symbol == "" {
+			return fmt.Errorf("prefix without symbol")
+		}
+		return s.AddPrefix(symbol, scale, units...)
+	})
+}
+
+// SIPrefixes binds the SI prefixes from quecto (q) to quetta (Q) to the given units.
+// They tell "m" from "M", so Build fails for case-insensitive Systems.
+func (b *Builder) SIPrefixes(units ...string) *Builder {
+	for _, p := range siPrefixes {
+		b.Prefix(p.Symbol, p.Scale, units...)
+	}
+	return b
+}
+
+// Display marks a spelling of a unit as the one to display it in (see System.SetDisplaySymbol).
+func (b *Builder) Display(symbol string) *Builder {
+	return b.step(func(s *System) error {
+		return s.SetDisplaySymbol(symbol)
+	})
+}
+
+// Build creates the System, reporting all invalid definitions joined in one error.
+func (b *Builder) Build() (*System, error) {
+	sys := NewSystem(b.config)
+	var errs []error
+	for _, step := range b.steps {
+		if err := step(sys); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return sys, nil
+}
+
+func (b *Builder) step(f func(*System) error) *Builder {
+	b.steps = append(b.steps, f)
+	return b
+}
+
+func (b *Builder) add(symbol string, scale float64, dim Dimension, opts []UnitOption) *Builder {
+	return b.step(func(s *System) error {
+		return s.addChecked(symbol, scale, dim, opts)
+	})
+}
+
+// addChecked is Add for declarative definitions (Builder, Definition): it rejects empty
+// symbols, scales that are not positive and finite, and symbols already registered as
+// a unit or alias, instead of overwriting them.
+func (s *System) addChecked(symbol string, scale float64, dim Dimension, opts []UnitOption) error {
+	if symbol == "" {
+		return fmt.Errorf("unit definition without symbol")
+	}
+	if !(scale > 0) || math.IsInf(scale, 0) {
+		return fmt.Errorf("unit %s: scale must be positive, got %g", symbol, scale)
+	}
+	u := Unit{Symbol: symbol}
+	for _, opt := range opts {
+		opt(&u)
+	}
+	if prev, ok := s.units[s.unitKey(u)]; ok {
+		return fmt.Errorf("unit %s already defined as %s", symbol, prev.Symbol)
+	}
+	if _, ok := s.aliases[s.normalizeKey(symbol)]; ok {
+		return fmt.Errorf("unit %s already defined as alias", symbol)
+	}
+	return s.Add(symbol, scale, dim, opts...)
+}
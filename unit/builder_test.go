@@ -0,0 +1,73 @@
+package unit_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func TestBuilder(t *testing.T) {
+	sys, err := unit.NewBuilder().
+		Config(unit.SystemConfig{AllowMultiPart: true}).
+		Base("m", unit.DimLength).Unit("ft", 0.3048).Unit("in", 0.0254).
+		Base("s", unit.DimTime).Unit("min", 60).
+		SIPrefixes("m", "s").
+		Display("m").
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+	if !sys.Config.AllowMultiPart {
+		t.Error("Build() dropped the Config")
+	}
+	tests := []struct {
+		symbol string
+		scale  float64
+		dim    unit.Dimension
+	}{
+		{"ft", 0.3048, unit.DimLength},
+		{"km", 1000, unit.DimLength},
+		{"dam", 10, unit.DimLength},
+		{"µs", 1e-6, unit.DimTime},
+		{"min", 60, unit.DimTime},
+	}
+	for _, tt := range tests {
+		u, scale, found := sys.Resolve(tt.symbol)
+		if !found || u.Scale*scale != tt.scale || u.Dimension != tt.dim {
+			t.Errorf("Resolve(%q) = %v * %v %v (found %t), want %v %v", tt.symbol, u.Scale, scale, u.Dimension, found, tt.scale, tt.dim)
+		}
+	}
+	if _, _, found := sys.Resolve("kft"); found {
+		t.Error("Resolve(kft) found: SI prefixes must only bind to m and s")
+	}
+	if base, ok := sys.Base(unit.DimTime); !ok || base != "s" {
+		t.Errorf("Base(time) = %q, %t, want s", base, ok)
+	}
+}
+
+func TestBuilder_Errors(t *testing.T) {
+	_, err := unit.NewBuilder().
+		Unit("ft", 0.3048).
+		Base("m", unit.DimLength).Unit("m", 1).Unit("yd", 0).
+		Prefix("k", 1000, "mile").
+		Display("furlong").
+		Build()
+	if err == nil {
+		t.Fatal("Build() expected error")
+	}
+	// All problems are reported at once.
+	for _, want := range []string{"ft", "m already defined", "yd", "mile", "furlong"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Build() error %q does not mention %q", err, want)
+		}
+	}
+
+	_, err = unit.NewBuilder().
+		Config(unit.SystemConfig{CaseInsensitive: true}).
+		Base("m", unit.DimLength).SIPrefixes("m").
+		Build()
+	if err == nil {
+		t.Error("SIPrefixes in a case-insensitive System expected error")
+	}
+}
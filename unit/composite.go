@@ -0,0 +1,106 @@
+package unit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// compositeMultipliers separate factors inside a composite symbol.
+const compositeMultipliers = "*·⋅"
+
+// compositeOperators mark a symbol as composite: multipliers, '/' and '^'.
+const compositeOperators = compositeMultipliers + "/^"
+
+// AddComposite registers a composite symbol (e.g. "km/h", "mg/dL", "N·m") as a single unit.
+// Its scale and dimension are computed from the already registered components:
+// factors are joined by '*', '·' or '⋅', and every factor after a '/' is a divisor ("a/b/c" = a/(b*c)).
+// Components may carry prefixes and exponents, as superscripts or after '^' (e.g. "m/s²",
+// "kg*m/s^2"). Resolve accepts the same symbols without registration.
+//
+// The parser reads a unit past '/' when the longer symbol resolves, so "5km/h" parses
+// although the default Separators include '/' ("1m/2m" is still two parts).
+func (s *System) AddComposite(symbol string, opts ...UnitOption) error {
+	if err := s.checkMutable("add unit " + symbol); err != nil {
+		return err
+	}
+	scale, dim, err := s.evalComposite(symbol)
+	if err != nil {
+		return err
+	}
+	return s.Add(symbol, scale, dim, opts...)
+}
+
+// AddDerived registers symbol as a named unit computed from an expression over already
+// registered units, in the syntax of AddComposite, e.g. newtons and pascals:
+//
+//	sys.AddDerived("N", "kg*m/s^2")
+//	sys.AddDerived("Pa", "N/m^2")
+//
+// Scale and dimension follow from the expression, so they stay consistent with its units.
+func (s *System) AddDerived(symbol, expr string, opts ...UnitOption) error {
+	if err := s.checkMutable("add unit " + symbol); err != nil {
+		return err
+	}
+	scale, dim, err := s.evalComposite(expr)
+	if err != nil {
+		return fmt.Errorf("derived unit %s: %w", symbol, err)
+	}
+	return s.Add(symbol, scale, dim, opts...)
+}
+
+// evalComposite computes the total scale and dimension of a composite symbol.
+func (s *System) evalComposite(symbol string) (float64, Dimension, error) {
+	scale := 1.0
+	var dim Dimension
+	tagged := false
+
+	for i, group := range strings.Split(symbol, "/") {
+		divide := i > 0
+		factors := strings.FieldsFunc(group, func(r rune) bool {
+			return strings.ContainsRune(compositeMultipliers, r)
+		})
+		if len(factors) == 0 {
+			return 0, Dimension{}, fmt.Errorf("empty factor in composite unit %q", symbol)
+		}
+
+		for _, f := range factors {
+			base, exp := f, 1
+			if i := strings.IndexByte(f, '^'); i >= 0 {
+				n, err := strconv.Atoi(f[i+1:])
+				if err != nil || n == 0 {
+					return 0, Dimension{}, fmt.Errorf("invalid exponent in %s of composite unit %q", f, symbol)
+				}
+				base, exp = f[:i], n
+			}
+			u, prefixScale, ok := s.Resolve(base)
+			if !ok {
+				return 0, Dimension{}, fmt.Errorf("unknown unit %s in composite unit %q", base, symbol)
+			}
+			if !u.Linear() {
+				return 0, Dimension{}, fmt.Errorf("composite unit %q cannot contain non-linear unit %s", symbol, base)
+			}
+			if exp != 1 && u.Dimension.Extra != "" {
+				return 0, Dimension{}, fmt.Errorf("composite unit %q cannot raise non-SI dimension %s to a power", symbol, u.Dimension)
+			}
+			fScale, fDim := math.Pow(prefixScale*u.Scale, float64(exp)), u.Dimension.Pow(exp)
+
+			// Extra dimensions have no algebra: allow a single one, and only as a multiplier.
+			if fDim.Extra != "" {
+				if tagged || divide {
+					return 0, Dimension{}, fmt.Errorf("composite unit %q cannot combine non-SI dimension %s", symbol, fDim)
+				}
+				tagged = true
+			}
+
+			if divide {
+				fScale, fDim = 1/fScale, fDim.Pow(-1)
+			}
+			scale *= fScale
+			dim = dim.Mul(fDim)
+		}
+	}
+
+	return scale, dim, nil
+}
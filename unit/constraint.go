@@ -0,0 +1,107 @@
+package unit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrConstraint is wrapped by the errors of the built-in Constraints.
+var ErrConstraint = errors.New("unit constraint violated")
+
+// Constraint validates the value of a part written in a unit, expressed in that unit
+// with its prefix applied (e.g. 1500 for "1.5kns"). A non-nil error rejects the part.
+type Constraint func(value float64) error
+
+// WithConstraint attaches constraints to the unit; the parser checks them for every
+// part written in it, so domain validation lives in the System definition.
+func WithConstraint(constraints ...Constraint) UnitOption {
+	return func(u *Unit) {
+		ext := u.editExt()
+		ext.constraints = append(ext.constraints, constraints...)
+	}
+}
+
+// Check runs the unit's constraints against value and returns the first error.
+func (u Unit) Check(value float64) error {
+	return check(u.Constraints(), value)
+}
+
+// AddConstraint attaches constraints to the whole System; the parser checks them
+// against the total in base units, e.g. a TTL between 1s and 24h:
+//
+//	sys.AddConstraint(unit.MinValue(1e9), unit.MaxValue(24*3600e9))
+func (s *System) AddConstraint(constraints ...Constraint) error {
+	if err := s.checkMutable("add constraint"); err != nil {
+		return err
+	}
+	s.constraints = append(s.constraints, constraints...)
+	return nil
+}
+
+// Check runs the System's constraints against a total in base units and returns the
+// first error.
+func (s *System) Check(total float64) error {
+	return check(s.constraints, total)
+}
+
+func check(constraints []Constraint, value float64) error {
+	for _, c := range constraints {
+		if err := c(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IntegerValue requires whole values, e.g. for "ns" where fractions are meaningless.
+// A relative tolerance absorbs floating point noise from prefix scaling.
+func IntegerValue() Constraint {
+	return func(value float64) error {
+		if math.Abs(value-math.Round(value)) > 1e-9*math.Max(1, math.Abs(value)) {
+			return fmt.Errorf("%w: %g is not an integer", ErrConstraint, value)
+		}
+		return nil
+	}
+}
+
+// ValueRange requires values within [min, max], e.g. 0–100 for a percentage.
+func ValueRange(min, max float64) Constraint {
+	return func(value float64) error {
+		if value < min || value > max {
+			return fmt.Errorf("%w: %g is outside [%g,%g]", ErrConstraint, value, min, max)
+		}
+		return nil
+	}
+}
+
+// MinValue requires values of at least min.
+func MinValue(min float64) Constraint {
+	return func(value float64) error {
+		if value < min {
+			return fmt.Errorf("%w: %g is below %g", ErrConstraint, value, min)
+		}
+		return nil
+	}
+}
+
+// MaxValue requires values of at most max.
+func MaxValue(max float64) Constraint {
+	return func(value float64) error {
+		if value > max {
+			return fmt.Errorf("%w: %g is above %g", ErrConstraint, value, max)
+		}
+		return nil
+	}
+}
+
+// NonNegativeValue rejects values below zero. Unlike RejectNegative it applies to a
+// single unit rather than the whole System.
+func NonNegativeValue() Constraint {
+	return func(value float64) error {
+		if value < 0 {
+			return fmt.Errorf("%w: %g is negative", ErrConstraint, value)
+		}
+		return nil
+	}
+}
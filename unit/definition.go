@@ -0,0 +1,334 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Definition is a declarative description of a System, as stored in JSON files.
+type Definition struct {
+	Config   ConfigDefinition   `json:"config"`
+	Units    []UnitDefinition   `json:"units"`
+	Prefixes []PrefixDefinition `json:"prefixes"`
+
+	// Display lists the spellings units are displayed in (see System.SetDisplaySymbol).
+	Display []string `json:"display,omitempty"`
+}
+
+// ConfigDefinition is the declarative form of SystemConfig, with the same fields.
+// Policies are spelled as their constant names ("RejectNegative"), and runes as
+// one-character strings.
+type ConfigDefinition struct {
+	AllowMultiPart         bool    `json:"allowMultiPart,omitempty"`
+	CaseInsensitive        bool    `json:"caseInsensitive,omitempty"`
+	NormalizeUnicode       bool    `json:"normalizeUnicode,omitempty"`
+	AutoPlural             bool    `json:"autoPlural,omitempty"`
+	LongNames              bool    `json:"longNames,omitempty"`
+	ExponentPolicy         string  `json:"exponentPolicy,omitempty"`
+	Separators             string  `json:"separators,omitempty"`
+	DigitGroupSeparator    string  `json:"digitGroupSeparator,omitempty"`
+	DecimalSeparator       string  `json:"decimalSeparator,omitempty"`
+	AllowFractions         bool    `json:"allowFractions,omitempty"`
+	AllowRadixLiterals     bool    `json:"allowRadixLiterals,omitempty"`
+	NormalizeDigits        bool    `json:"normalizeDigits,omitempty"`
+	AllowUnderscoreDigits  bool    `json:"allowUnderscoreDigits,omitempty"`
+	NegativePolicy         string  `json:"negativePolicy,omitempty"`
+	SignPolicy             string  `json:"signPolicy,omitempty"`
+	SignedZero             bool    `json:"signedZero,omitempty"`
+	DefaultUnit            string  `json:"defaultUnit,omitempty"`
+	Epsilon                float64 `json:"epsilon,omitempty"`
+	PrecisionPolicy        string  `json:"precisionPolicy,omitempty"`
+	OverflowPolicy         string  `json:"overflowPolicy,omitempty"`
+	AllowInfinity          bool    `json:"allowInfinity,omitempty"`
+	MaxParts               int     `json:"maxParts,omitempty"`
+	MaxInputLength         int     `json:"maxInputLength,omitempty"`
+	StrictSyntax           bool    `json:"strictSyntax,omitempty"`
+	AllowUnitFirst         bool    `json:"allowUnitFirst,omitempty"`
+	RejectDuplicateUnits   bool    `json:"rejectDuplicateUnits,omitempty"`
+	RequireDescendingOrder bool    `json:"requireDescendingOrder,omitempty"`
+	RejectAmbiguousUnits   bool    `json:"rejectAmbiguousUnits,omitempty"`
+	ResolutionPolicy       string  `json:"resolutionPolicy,omitempty"`
+
+	Format *FormatDefinition `json:"format,omitempty"`
+}
+
+// FormatDefinition is the declarative form of FormatConfig.
+type FormatDefinition struct {
+	Precision      int    `json:"precision,omitempty"`
+	FixedPrecision bool   `json:"fixedPrecision,omitempty"`
+	PrefixPolicy   string `json:"prefixPolicy,omitempty"`
+	UnitSeparator  string `json:"unitSeparator,omitempty"`
+}
+
+// UnitDefinition describes a single unit.
+type UnitDefinition struct {
+	Symbol          string    `json:"symbol"`
+	Scale           float64   `json:"scale"`
+	Dimension       Dimension `json:"dimension"`
+	CaseSensitive   bool      `json:"caseSensitive,omitempty"`
+	CaseInsensitive bool      `json:"caseInsensitive,omitempty"`
+
+	// Aliases are other spellings of the unit (see System.AddAlias).
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// PrefixDefinition describes a prefix and the units it binds to.
+type PrefixDefinition struct {
+	Symbol string   `json:"symbol"`
+	Scale  float64  `json:"scale"`
+	Units  []string `json:"units"`
+}
+
+// DecodeDefinition reads a JSON System definition. Unknown fields are rejected.
+func DecodeDefinition(r io.Reader) (*Definition, error) {
+	var def Definition
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&def); err != nil {
+		return nil, fmt.Errorf("decode unit definition: %w", err)
+	}
+	return &def, nil
+}
+
+// LoadSystem reads a System definition in the given format ("json", or "yaml"/"yml"
+// with the same keys) and builds it, so deployments can define unit vocabularies
+// without recompiling:
+//
+//	config: {allowMultiPart: true}
+//	units:
+//	  - {symbol: m, scale: 1, dimension: {L: 1}}
+//	  - {symbol: ft, scale: 0.3048, dimension: {L: 1}}
+//	prefixes:
+//	  - {symbol: k, scale: 1000, units: [m]}
+//
+// YAML input is limited to block and flow collections, scalars and comments.
+func LoadSystem(r io.Reader, format string) (*System, error) {
+	def, err := DecodeDefinitionFormat(r, format)
+	if err != nil {
+		return nil, err
+	}
+	return def.Build()
+}
+
+// DecodeDefinitionFormat reads a System definition in the given format, like LoadSystem,
+// without building it.
+func DecodeDefinitionFormat(r io.Reader, format string) (*Definition, error) {
+	switch strings.ToLower(format) {
+	case "json":
+		return DecodeDefinition(r)
+	case "yaml", "yml":
+		return decodeDefinitionYAML(r)
+	}
+	return nil, fmt.Errorf("unsupported unit definition format %q", format)
+}
+
+// decodeDefinitionYAML reads a YAML System definition by way of its JSON equivalent,
+// so both formats share the same validation.
+func decodeDefinitionYAML(r io.Reader) (*Definition, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("decode unit definition: %w", err)
+	}
+	v, err := decodeYAML(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("decode unit definition: %w", err)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("decode unit definition: %w", err)
+	}
+	return DecodeDefinition(bytes.NewReader(data))
+}
+
+// Constant names of the policies, indexed by value.
+var (
+	exponentPolicyNames   = []string{"PreferExponent", "PreferPrefix", "RejectAmbiguousExponent"}
+	negativePolicyNames   = []string{"AllowNegative", "RejectNegative", "AbsoluteNegative"}
+	signPolicyNames       = []string{"SignPerPart", "SignLeading"}
+	precisionPolicyNames  = []string{"PrecisionStrict", "PrecisionRoundNearest", "PrecisionTruncate", "PrecisionFloor", "PrecisionCeil"}
+	overflowPolicyNames   = []string{"OverflowError", "OverflowSaturate"}
+	resolutionPolicyNames = []string{"ResolveExactFirst", "ResolveLongestUnit", "ResolvePrefixFirst"}
+	prefixPolicyNames     = []string{"PrefixAny", "PrefixDecimal", "PrefixBinary", "PrefixNone"}
+)
+
+// SystemConfig returns the SystemConfig described by the definition. It fails on
+// unknown policy names and separators that are not a single character.
+func (c ConfigDefinition) SystemConfig() (SystemConfig, error) {
+	config := SystemConfig{
+		AllowMultiPart:         c.AllowMultiPart,
+		CaseInsensitive:        c.CaseInsensitive,
+		NormalizeUnicode:       c.NormalizeUnicode,
+		AutoPlural:             c.AutoPlural,
+		LongNames:              c.LongNames,
+		Separators:             c.Separators,
+		AllowFractions:         c.AllowFractions,
+		AllowRadixLiterals:     c.AllowRadixLiterals,
+		NormalizeDigits:        c.NormalizeDigits,
+		AllowUnderscoreDigits:  c.AllowUnderscoreDigits,
+		SignedZero:             c.SignedZero,
+		DefaultUnit:            c.DefaultUnit,
+		Epsilon:                c.Epsilon,
+		AllowInfinity:          c.AllowInfinity,
+		MaxParts:               c.MaxParts,
+		MaxInputLength:         c.MaxInputLength,
+		StrictSyntax:           c.StrictSyntax,
+		AllowUnitFirst:         c.AllowUnitFirst,
+		RejectDuplicateUnits:   c.RejectDuplicateUnits,
+		RequireDescendingOrder: c.RequireDescendingOrder,
+		RejectAmbiguousUnits:   c.RejectAmbiguousUnits,
+	}
+	var errs []error
+	policy := func(names []string, name, field string) int {
+		if name == "" {
+			return 0
+		}
+		if i := slices.Index(names, name); i >= 0 {
+			return i
+		}
+		errs = append(errs, fmt.Errorf("config %s: unknown policy %q", field, name))
+		return 0
+	}
+	config.ExponentPolicy = ExponentPolicy(policy(exponentPolicyNames, c.ExponentPolicy, "exponentPolicy"))
+	config.NegativePolicy = NegativePolicy(policy(negativePolicyNames, c.NegativePolicy, "negativePolicy"))
+	config.SignPolicy = SignPolicy(policy(signPolicyNames, c.SignPolicy, "signPolicy"))
+	config.PrecisionPolicy = PrecisionPolicy(policy(precisionPolicyNames, c.PrecisionPolicy, "precisionPolicy"))
+	config.OverflowPolicy = OverflowPolicy(policy(overflowPolicyNames, c.OverflowPolicy, "overflowPolicy"))
+	config.ResolutionPolicy = ResolutionPolicy(policy(resolutionPolicyNames, c.ResolutionPolicy, "resolutionPolicy"))
+	if f := c.Format; f != nil {
+		config.Format = FormatConfig{
+			Precision:      f.Precision,
+			FixedPrecision: f.FixedPrecision,
+			PrefixPolicy:   PrefixPolicy(policy(prefixPolicyNames, f.PrefixPolicy, "format.prefixPolicy")),
+			UnitSeparator:  f.UnitSeparator,
+		}
+	}
+
+	char := func(s, field string) rune {
+		if s == "" {
+			return 0
+		}
+		r, n := utf8.DecodeRuneInString(s)
+		if n != len(s) || r == utf8.RuneError {
+			errs = append(errs, fmt.Errorf("config %s: %q is not a single character", field, s))
+		}
+		return r
+	}
+	config.DigitGroupSeparator = char(c.DigitGroupSeparator, "digitGroupSeparator")
+	config.DecimalSeparator = char(c.DecimalSeparator, "decimalSeparator")
+	return config, errors.Join(errs...)
+}
+
+// NewConfigDefinition returns the definition of config, so a System configured in Go can
+// be written out and loaded back with LoadSystem. It fails on policies that have no
+// name and on an Epsilon that JSON cannot represent.
+func NewConfigDefinition(config SystemConfig) (ConfigDefinition, error) {
+	c := ConfigDefinition{
+		AllowMultiPart:         config.AllowMultiPart,
+		CaseInsensitive:        config.CaseInsensitive,
+		NormalizeUnicode:       config.NormalizeUnicode,
+		AutoPlural:             config.AutoPlural,
+		LongNames:              config.LongNames,
+		Separators:             config.Separators,
+		AllowFractions:         config.AllowFractions,
+		AllowRadixLiterals:     config.AllowRadixLiterals,
+		NormalizeDigits:        config.NormalizeDigits,
+		AllowUnderscoreDigits:  config.AllowUnderscoreDigits,
+		SignedZero:             config.SignedZero,
+		DefaultUnit:            config.DefaultUnit,
+		Epsilon:                config.Epsilon,
+		AllowInfinity:          config.AllowInfinity,
+		MaxParts:               config.MaxParts,
+		MaxInputLength:         config.MaxInputLength,
+		StrictSyntax:           config.StrictSyntax,
+		AllowUnitFirst:         config.AllowUnitFirst,
+		RejectDuplicateUnits:   config.RejectDuplicateUnits,
+		RequireDescendingOrder: config.RequireDescendingOrder,
+		RejectAmbiguousUnits:   config.RejectAmbiguousUnits,
+	}
+	var errs []error
+	name := func(names []string, v int, field string) string {
+		if v == 0 {
+			return ""
+		}
+		if v < 0 || v >= len(names) {
+			errs = append(errs, fmt.Errorf("config %s: unknown policy %d", field, v))
+			return ""
+		}
+		return names[v]
+	}
+	c.ExponentPolicy = name(exponentPolicyNames, int(config.ExponentPolicy), "ExponentPolicy")
+	c.NegativePolicy = name(negativePolicyNames, int(config.NegativePolicy), "NegativePolicy")
+	c.SignPolicy = name(signPolicyNames, int(config.SignPolicy), "SignPolicy")
+	c.PrecisionPolicy = name(precisionPolicyNames, int(config.PrecisionPolicy), "PrecisionPolicy")
+	c.OverflowPolicy = name(overflowPolicyNames, int(config.OverflowPolicy), "OverflowPolicy")
+	c.ResolutionPolicy = name(resolutionPolicyNames, int(config.ResolutionPolicy), "ResolutionPolicy")
+	if f := config.Format; f != (FormatConfig{}) {
+		c.Format = &FormatDefinition{
+			Precision:      f.Precision,
+			FixedPrecision: f.FixedPrecision,
+			PrefixPolicy:   name(prefixPolicyNames, int(f.PrefixPolicy), "Format.PrefixPolicy"),
+			UnitSeparator:  f.UnitSeparator,
+		}
+	}
+	if config.DigitGroupSeparator != 0 {
+		c.DigitGroupSeparator = string(config.DigitGroupSeparator)
+	}
+	if config.DecimalSeparator != 0 {
+		c.DecimalSeparator = string(config.DecimalSeparator)
+	}
+	if math.IsNaN(config.Epsilon) || math.IsInf(config.Epsilon, 0) {
+		errs = append(errs, fmt.Errorf("config Epsilon: %g cannot be written", config.Epsilon))
+	}
+	return c, errors.Join(errs...)
+}
+
+// Build creates a System from the definition, validating every entry.
+func (d *Definition) Build() (*System, error) {
+	config, err := d.Config.SystemConfig()
+	if err != nil {
+		return nil, err
+	}
+	sys := NewSystem(config)
+
+	for _, u := range d.Units {
+		var opts []UnitOption
+		if u.CaseSensitive {
+			opts = append(opts, WithCaseSensitive())
+		}
+		if u.CaseInsensitive {
+			opts = append(opts, WithCaseInsensitive())
+		}
+		if err := sys.addChecked(u.Symbol, u.Scale, u.Dimension, opts); err != nil {
+			return nil, err
+		}
+		for _, alias := range u.Aliases {
+			if err := sys.AddAlias(alias, u.Symbol); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, p := range d.Prefixes {
+		if p.Symbol == "" {
+			return nil, fmt.Errorf("prefix definition without symbol")
+		}
+		if err := sys.AddPrefix(p.Symbol, p.Scale, p.Units...); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, symbol := range d.Display {
+		if err := sys.SetDisplaySymbol(symbol); err != nil {
+			return nil, err
+		}
+	}
+
+	return sys, nil
+}
@@ -0,0 +1,241 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func TestDefinition_Build(t *testing.T) {
+	const src = `{
+		"config": {"allowMultiPart": true, "caseInsensitive": true},
+		"units": [
+			{"symbol": "b", "scale": 1, "dimension": {"Extra": "storage"}, "caseSensitive": true},
+			{"symbol": "B", "scale": 8, "dimension": {"Extra": "storage"}, "caseSensitive": true, "aliases": ["Byte"]},
+			{"symbol": "s", "scale": 1, "dimension": {"T": 1}}
+		],
+		"prefixes": [
+			{"symbol": "Ki", "scale": 1024, "units": ["b", "B"]}
+		],
+		"display": ["B"]
+	}`
+
+	def, err := unit.DecodeDefinition(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("DecodeDefinition failed: %v", err)
+	}
+	sys, err := def.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if !sys.Config.AllowMultiPart || !sys.Config.CaseInsensitive {
+		t.Errorf("Build config = %+v, want multipart and case-insensitive", sys.Config)
+	}
+	u, prefixScale, found := sys.Resolve("kiB")
+	if !found || prefixScale*u.Scale != 8192 || !u.Dimension.Equals(unit.DimStorage) {
+		t.Errorf("Resolve(kiB) = %+v, %g, %v; want 8192 storage", u, prefixScale, found)
+	}
+	if got := sys.DisplaySymbol("byte"); got != "B" {
+		t.Errorf("DisplaySymbol(byte) = %q, want %q", got, "B")
+	}
+}
+
+func TestDefinition_Errors(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":  `{"unitz": []}`,
+		"bad json":       `{"units": [`,
+		"missing symbol": `{"units": [{"scale": 1}]}`,
+		"zero scale":     `{"units": [{"symbol": "m", "scale": 0}]}`,
+		"duplicate unit": `{"units": [{"symbol": "B", "scale": 8}, {"symbol": "B", "scale": 1}]}`,
+		"unit as alias":  `{"units": [{"symbol": "B", "scale": 8, "aliases": ["Byte"]}, {"symbol": "Byte", "scale": 8}]}`,
+		"unbound prefix": `{"prefixes": [{"symbol": "k", "scale": 1000, "units": ["m"]}]}`,
+		"bad display":    `{"display": ["m"]}`,
+	}
+
+	for name, src := range tests {
+		def, err := unit.DecodeDefinition(strings.NewReader(src))
+		if err == nil {
+			_, err = def.Build()
+		}
+		if err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestDefinition_BuildNonFiniteScale(t *testing.T) {
+	for _, scale := range []float64{math.NaN(), math.Inf(1)} {
+		def := unit.Definition{Units: []unit.UnitDefinition{{Symbol: "m", Scale: scale, Dimension: unit.DimLength}}}
+		if _, err := def.Build(); err == nil {
+			t.Errorf("Build with scale %g expected error, got nil", scale)
+		}
+	}
+}
+
+func TestLoadSystem(t *testing.T) {
+	const yamlSrc = `---
+# Storage units, YAML edition.
+config:
+  allowMultiPart: true
+  caseInsensitive: true
+units:
+  - symbol: b
+    scale: 1
+    dimension: {Extra: storage}
+    caseSensitive: true
+  - symbol: "B"
+    scale: 8 # bits
+    dimension:
+      Extra: 'storage'
+    caseSensitive: true
+    aliases: [Byte]
+  - {symbol: s, scale: 1, dimension: {T: 1}}
+prefixes:
+- symbol: Ki
+  scale: 1024
+  units: [b, B]
+display: [B]
+`
+	const jsonSrc = `{
+		"config": {"allowMultiPart": true, "caseInsensitive": true},
+		"units": [
+			{"symbol": "b", "scale": 1, "dimension": {"Extra": "storage"}, "caseSensitive": true},
+			{"symbol": "B", "scale": 8, "dimension": {"Extra": "storage"}, "caseSensitive": true, "aliases": ["Byte"]},
+			{"symbol": "s", "scale": 1, "dimension": {"T": 1}}
+		],
+		"prefixes": [{"symbol": "Ki", "scale": 1024, "units": ["b", "B"]}],
+		"display": ["B"]
+	}`
+
+	for format, src := range map[string]string{"yaml": yamlSrc, "JSON": jsonSrc} {
+		sys, err := unit.LoadSystem(strings.NewReader(src), format)
+		if err != nil {
+			t.Fatalf("LoadSystem(%s) failed: %v", format, err)
+		}
+		if !sys.Config.AllowMultiPart || !sys.Config.CaseInsensitive {
+			t.Errorf("%s: config = %+v, want multipart and case-insensitive", format, sys.Config)
+		}
+		u, prefixScale, found := sys.Resolve("kiB")
+		if !found || prefixScale*u.Scale != 8192 || !u.Dimension.Equals(unit.DimStorage) {
+			t.Errorf("%s: Resolve(kiB) = %+v, %g, %v; want 8192 storage", format, u, prefixScale, found)
+		}
+		if u, _, _ := sys.Resolve("s"); !u.Dimension.Equals(unit.DimTime) {
+			t.Errorf("%s: Resolve(s) dimension = %v, want time", format, u.Dimension)
+		}
+		if got := sys.DisplaySymbol("byte"); got != "B" {
+			t.Errorf("%s: DisplaySymbol(byte) = %q, want %q", format, got, "B")
+		}
+	}
+}
+
+func TestLoadSystem_UnsupportedYAML(t *testing.T) {
+	tests := map[string]struct{ src, want string }{
+		"anchor":         {"config: &c {allowMultiPart: true}", "line 1, column 9: anchors are not supported"},
+		"alias":          {"units: []\nprefixes: *p", "line 2, column 11: aliases are not supported"},
+		"tag":            {"units:\n  - symbol: !!str m", "line 2, column 13: tags are not supported"},
+		"flow tag":       {"units: [{symbol: !m}]", "line 1, column 18: tags are not supported"},
+		"block scalar":   {"display: |\n  m", "line 1, column 10: block scalars are not supported"},
+		"directive":      {"%YAML 1.2\nunits: []", "line 1, column 1: directives are not supported"},
+		"explicit key":   {"? units\n: []", "line 1, column 1: explicit keys are not supported"},
+		"merge key":      {"config:\n  <<: {allowMultiPart: true}", "line 2, column 3: merge keys are not supported"},
+		"documents":      {"units: []\n---\nunits: []", "line 2, column 1: multiple documents are not supported"},
+		"multi-line map": {"units: [{symbol: m,\n  scale: 1}]", "line 1, column 20: missing '}'"},
+	}
+
+	for name, tt := range tests {
+		_, err := unit.LoadSystem(strings.NewReader(tt.src), "yaml")
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want %q", name, err, tt.want)
+		}
+	}
+}
+
+func TestLoadSystem_Errors(t *testing.T) {
+	tests := map[string]struct{ src, format string }{
+		"unknown format":  {`{}`, "toml"},
+		"unknown field":   {"unitz: []", "yaml"},
+		"bad indentation": {"units:\n  - symbol: m\n     scale: 1", "yaml"},
+		"tab indentation": {"units:\n\t- symbol: m", "yaml"},
+		"duplicate key":   {"units: []\nunits: []", "yaml"},
+		"unclosed flow":   {"units: [{symbol: m", "yaml"},
+		"unclosed quote":  {"display: [\"m]", "yaml"},
+		"wrong type":      {"units: [{symbol: m, scale: one}]", "yaml"},
+		"unbound prefix":  {"prefixes: [{symbol: k, scale: 1000, units: [m]}]", "yaml"},
+		"bad json":        {`{"units": [`, "json"},
+	}
+
+	for name, tt := range tests {
+		if _, err := unit.LoadSystem(strings.NewReader(tt.src), tt.format); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestConfigDefinition_RoundTrip(t *testing.T) {
+	config := unit.SystemConfig{
+		AllowMultiPart:         true,
+		CaseInsensitive:        true,
+		NormalizeUnicode:       true,
+		AutoPlural:             true,
+		LongNames:              true,
+		ExponentPolicy:         unit.RejectAmbiguousExponent,
+		Separators:             " ,、",
+		DigitGroupSeparator:    '.',
+		DecimalSeparator:       ',',
+		AllowFractions:         true,
+		AllowRadixLiterals:     true,
+		NormalizeDigits:        true,
+		AllowUnderscoreDigits:  true,
+		NegativePolicy:         unit.AbsoluteNegative,
+		SignPolicy:             unit.SignLeading,
+		SignedZero:             true,
+		DefaultUnit:            "m",
+		Epsilon:                1e-9,
+		PrecisionPolicy:        unit.PrecisionCeil,
+		OverflowPolicy:         unit.OverflowSaturate,
+		AllowInfinity:          true,
+		MaxParts:               -1,
+		MaxInputLength:         64,
+		StrictSyntax:           true,
+		AllowUnitFirst:         true,
+		RejectDuplicateUnits:   true,
+		RequireDescendingOrder: true,
+		RejectAmbiguousUnits:   true,
+		ResolutionPolicy:       unit.ResolvePrefixFirst,
+		Format:                 unit.FormatConfig{Precision: 2, FixedPrecision: true, PrefixPolicy: unit.PrefixBinary, UnitSeparator: " "},
+	}
+	c, err := unit.NewConfigDefinition(config)
+	if err != nil {
+		t.Fatalf("NewConfigDefinition failed: %v", err)
+	}
+	data, err := json.Marshal(unit.Definition{
+		Config: c,
+		Units:  []unit.UnitDefinition{{Symbol: "m", Scale: 1, Dimension: unit.DimLength}},
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	sys, err := unit.LoadSystem(strings.NewReader(string(data)), "json")
+	if err != nil {
+		t.Fatalf("LoadSystem failed: %v", err)
+	}
+	if sys.Config != config {
+		t.Errorf("round trip config = %+v, want %+v", sys.Config, config)
+	}
+
+	if _, err := unit.NewConfigDefinition(unit.SystemConfig{NegativePolicy: 7}); err == nil {
+		t.Error("NewConfigDefinition with unknown policy expected error, got nil")
+	}
+	if _, err := unit.NewConfigDefinition(unit.SystemConfig{Epsilon: math.NaN()}); err == nil {
+		t.Error("NewConfigDefinition with NaN epsilon expected error, got nil")
+	}
+	for _, c := range []unit.ConfigDefinition{{SignPolicy: "Leading"}, {DecimalSeparator: ",,"}} {
+		if _, err := c.SystemConfig(); err == nil {
+			t.Errorf("%+v.SystemConfig() expected error, got nil", c)
+		}
+	}
+}
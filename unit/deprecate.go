@@ -0,0 +1,65 @@
+package unit
+
+import "fmt"
+
+// deprecation records a symbol passed to Deprecate and its suggested replacement.
+type deprecation struct {
+	symbol      string
+	replacement string
+}
+
+// Deprecate marks symbol (optionally prefixed, e.g. JEDEC "MB") as deprecated in favour
+// of replacement (e.g. "MiB"). It still resolves and parses as before, but parts written
+// in it report the replacement (see parser.Part.Deprecated and parser.DeprecationObserver),
+// so configs can be migrated without breaking them.
+//
+// Deprecation applies to the spelling as written: deprecating "MB" leaves "B" and "kB"
+// alone, and deprecating an alias leaves its unit alone.
+func (s *System) Deprecate(symbol, replacement string) error {
+	if err := s.checkMutable("deprecate " + symbol); err != nil {
+		return err
+	}
+	key, ok := s.deprecationKey(symbol)
+	if !ok {
+		return fmt.Errorf("cannot deprecate unknown unit: %s", symbol)
+	}
+	r, ok := s.ResolveFull(replacement)
+	if !ok {
+		return fmt.Errorf("cannot deprecate %s in favour of unknown unit: %s", symbol, replacement)
+	}
+	if old, _ := s.ResolveFull(symbol); !old.Dimension().Equals(r.Dimension()) {
+		return fmt.Errorf("cannot deprecate %s in favour of %s: dimensions %s and %s differ", symbol, replacement, old.Dimension(), r.Dimension())
+	}
+	if s.deprecated == nil {
+		s.deprecated = make(map[string]deprecation)
+	}
+	s.deprecated[key] = deprecation{symbol: symbol, replacement: replacement}
+	return nil
+}
+
+// Deprecation returns the replacement of symbol if it was deprecated with Deprecate.
+func (s *System) Deprecation(symbol string) (string, bool) {
+	if len(s.deprecated) == 0 {
+		return "", false
+	}
+	key, ok := s.deprecationKey(symbol)
+	if !ok {
+		return "", false
+	}
+	d, ok := s.deprecated[key]
+	return d.replacement, ok
+}
+
+// deprecationKey identifies the spelling of symbol: its prefix and unit as registered,
+// plus the alias it was written in, if any.
+func (s *System) deprecationKey(symbol string) (string, bool) {
+	r, ok := s.ResolveFull(symbol)
+	if !ok || r.Unit.Symbol == "" {
+		return "", false
+	}
+	alias := ""
+	if _, _, ok := s.lookupSymbol(r.Alias); !ok {
+		alias = s.normalizeKey(r.Alias)
+	}
+	return r.Prefix.Symbol + "\x00" + r.Unit.Symbol + "\x00" + alias, true
+}
@@ -22,12 +22,40 @@ func (d Dimension) Equals(other Dimension) bool {
 	return d == other
 }
 
+// Pow raises the dimension to the integer power n (e.g. L^1 -> L^2 for n=2).
+// The Extra tag is not algebraic and is returned unchanged.
+func (d Dimension) Pow(n int) Dimension {
+	return Dimension{
+		L: d.L * n, M: d.M * n, T: d.T * n, I: d.I * n,
+		K: d.K * n, N: d.N * n, J: d.J * n,
+		Extra: d.Extra,
+	}
+}
+
+// Mul combines two dimensions by adding their exponents (e.g. L^1 * T^-1 -> L^1 T^-1).
+// The Extra tag of whichever operand has one is kept; callers must not multiply two tagged dimensions.
+func (d Dimension) Mul(other Dimension) Dimension {
+	extra := d.Extra
+	if extra == "" {
+		extra = other.Extra
+	}
+	return Dimension{
+		L: d.L + other.L, M: d.M + other.M, T: d.T + other.T, I: d.I + other.I,
+		K: d.K + other.K, N: d.N + other.N, J: d.J + other.J,
+		Extra: extra,
+	}
+}
+
 // String returns a string representation of the dimension.
 func (d Dimension) String() string {
+	si := fmt.Sprintf("L^%d M^%d T^%d I^%d K^%d N^%d J^%d", d.L, d.M, d.T, d.I, d.K, d.N, d.J)
 	if d.Extra != "" {
-		return fmt.Sprintf("Dim(%s)", d.Extra)
+		if (d == Dimension{Extra: d.Extra}) {
+			return fmt.Sprintf("Dim(%s)", d.Extra)
+		}
+		return fmt.Sprintf("Dim(%s) %s", d.Extra, si)
 	}
-	return fmt.Sprintf("L^%d M^%d T^%d I^%d K^%d N^%d J^%d", d.L, d.M, d.T, d.I, d.K, d.N, d.J)
+	return si
 }
 
 // Common dimensions
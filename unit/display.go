@@ -0,0 +1,126 @@
+package unit
+
+import (
+	"math"
+	"sort"
+)
+
+// DisplayUnit is a prefix + unit combination suitable for rendering values.
+type DisplayUnit struct {
+	Symbol    string // Prefix and display symbol, e.g. "KiB"
+	Scale     float64
+	Offset    float64 // Offset of an affine unit, see WithOffset
+	Dimension Dimension
+
+	// FromBase converts base units into a function unit (see AddFunc); nil otherwise.
+	FromBase func(float64) float64
+
+	// PrefixScale is the scale of the prefix in Symbol, or 0 without one.
+	PrefixScale float64
+}
+
+// Linear reports whether d is neither affine nor a function unit (see Unit.Linear).
+func (d DisplayUnit) Linear() bool {
+	return d.Offset == 0 && d.FromBase == nil
+}
+
+// FromBaseValue converts v in base units into d.
+func (d DisplayUnit) FromBaseValue(v float64) float64 {
+	if d.FromBase != nil {
+		return d.FromBase(v)
+	}
+	return (v - d.Offset) / d.Scale
+}
+
+// DisplayUnits lists the combinations a formatter can choose from: for every unit, its
+// display symbol (see SetDisplaySymbol) alone and with each prefix bound to it.
+// Aliases are not listed separately. The result is sorted by dimension, then scale,
+// then symbol, so among units of equal scale the shortest symbol comes first.
+func (s *System) DisplayUnits() []DisplayUnit {
+	var out []DisplayUnit
+	for key, u := range s.units {
+		symbol := u.Symbol
+		if display, ok := s.displaySymbols[key]; ok {
+			symbol = display
+		}
+		_, fromBase := u.Funcs()
+		out = append(out, DisplayUnit{Symbol: symbol, Scale: u.Scale, Offset: u.Offset, Dimension: u.Dimension, FromBase: fromBase})
+		if !u.Linear() {
+			continue
+		}
+		for _, p := range s.prefixes {
+			if s.prefixAllowed(key, s.normalizeKey(p.Symbol)) {
+				out = append(out, DisplayUnit{Symbol: p.Symbol + symbol, Scale: p.Scale * u.Scale, Dimension: u.Dimension, PrefixScale: p.Scale})
+			}
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Dimension != b.Dimension {
+			return a.Dimension.String() < b.Dimension.String()
+		}
+		if a.Scale != b.Scale {
+			return a.Scale < b.Scale
+		}
+		return shorter(a.Symbol, b.Symbol)
+	})
+	return out
+}
+
+// shorter orders symbols by length, then lexicographically.
+func shorter(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// FormatConfig holds the defaults of parser.Format for a System, so that every value
+// of an application is formatted alike. Format options override them per call.
+type FormatConfig struct {
+	// Precision is the number of decimals if FixedPrecision is set. By default the
+	// shortest exact form is used.
+	Precision      int
+	FixedPrecision bool
+
+	// PrefixPolicy restricts the prefixes Format picks on its own. Units named with
+	// parser.WithUnits or SetPreferredUnit are used as given. Defaults to PrefixAny.
+	PrefixPolicy PrefixPolicy
+
+	// UnitSeparator is written between each value and its unit ("1.5 GiB"), and between
+	// the parts of a compound value. Empty writes them together ("1.5GiB").
+	UnitSeparator string
+}
+
+// PrefixPolicy selects the prefixes Format may choose from.
+type PrefixPolicy int
+
+const (
+	// PrefixAny uses every prefix bound to a unit.
+	PrefixAny PrefixPolicy = iota
+	// PrefixDecimal only uses prefixes that are powers of ten, such as the SI "k" and "m".
+	PrefixDecimal
+	// PrefixBinary only uses prefixes that are powers of two, such as the IEC "Ki" and "Mi".
+	PrefixBinary
+	// PrefixNone only uses units without a prefix.
+	PrefixNone
+)
+
+// Allows reports whether the policy accepts d.
+func (p PrefixPolicy) Allows(d DisplayUnit) bool {
+	if d.PrefixScale == 0 {
+		return true
+	}
+	switch p {
+	case PrefixDecimal:
+		e := math.Round(math.Log10(d.PrefixScale))
+		return math.Pow(10, e) == d.PrefixScale
+	case PrefixBinary:
+		frac, _ := math.Frexp(d.PrefixScale)
+		return frac == 0.5
+	case PrefixNone:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package unit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Fingerprint returns a stable hash (hex SHA-256) of the units, aliases, prefixes, prefix bindings,
+// display symbols, preferred units, base units, deprecations and configuration of the System. Two Systems parse alike if their
+// fingerprints match, so services can log it next to stored canonical values and
+// invalidate caches when unit definitions change.
+//
+// It does not depend on registration order. Unit constraints are functions and only
+// counted; changing what a constraint checks does not change the fingerprint.
+func (s *System) Fingerprint() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "config %+v\n", s.Config)
+	fmt.Fprintf(h, "constraints %d\n", len(s.constraints))
+
+	for _, key := range sortedKeys(s.units) {
+		u := s.units[key]
+		fmt.Fprintf(h, "unit %q %s %s %t %d\n", u.Symbol, strconv.FormatFloat(u.Scale, 'g', -1, 64), u.Dimension, u.CaseSensitive, len(u.Constraints()))
+		if u.CaseInsensitive {
+			fmt.Fprintf(h, "fold %q\n", u.Symbol)
+		}
+		if u.Offset != 0 {
+			fmt.Fprintf(h, "offset %q %s\n", u.Symbol, strconv.FormatFloat(u.Offset, 'g', -1, 64))
+		}
+		if toBase, _ := u.Funcs(); toBase != nil {
+			fmt.Fprintf(h, "func %q\n", u.Symbol)
+		}
+		for _, p := range sortedKeys(s.unitPrefixes[key]) {
+			if s.unitPrefixes[key][p] {
+				fmt.Fprintf(h, "bind %q %q\n", key, p)
+			} else {
+				fmt.Fprintf(h, "exclude %q %q\n", key, p)
+			}
+		}
+	}
+
+	for _, key := range sortedKeys(s.universalPrefixes) {
+		fmt.Fprintf(h, "universal %q\n", key)
+	}
+
+	for _, key := range sortedKeys(s.aliases) {
+		fmt.Fprintf(h, "alias %q %q\n", s.aliases[key].symbol, s.aliases[key].unit)
+	}
+
+	prefixes := append([]Prefix(nil), s.prefixes...)
+	sort.Slice(prefixes, func(i, j int) bool { return prefixes[i].Symbol < prefixes[j].Symbol })
+	for _, p := range prefixes {
+		fmt.Fprintf(h, "prefix %q %s %t %t\n", p.Symbol, strconv.FormatFloat(p.Scale, 'g', -1, 64), p.CaseSensitive, p.CaseInsensitive)
+	}
+
+	var bases []string
+	for dim, symbol := range s.bases {
+		bases = append(bases, fmt.Sprintf("base %s %q\n", dim, symbol))
+	}
+	sort.Strings(bases)
+	for _, line := range bases {
+		h.Write([]byte(line))
+	}
+
+	var preferred []string
+	for dim, symbol := range s.preferredUnits {
+		preferred = append(preferred, fmt.Sprintf("preferred %s %q\n", dim, symbol))
+	}
+	sort.Strings(preferred)
+	for _, line := range preferred {
+		h.Write([]byte(line))
+	}
+
+	for _, key := range sortedKeys(s.deprecated) {
+		fmt.Fprintf(h, "deprecate %q %q\n", s.deprecated[key].symbol, s.deprecated[key].replacement)
+	}
+
+	for _, key := range sortedKeys(s.displaySymbols) {
+		fmt.Fprintf(h, "display %q %q\n", s.units[key].Symbol, s.displaySymbols[key])
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
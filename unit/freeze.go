@@ -0,0 +1,63 @@
+package unit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrFrozen is returned when mutating a System produced by Freeze.
+var ErrFrozen = errors.New("system is frozen")
+
+// Freeze returns an immutable snapshot of the System that can be shared across
+// goroutines, e.g. while plugins keep adding units to the original. Every mutating
+// method of the snapshot (Add, AddPrefix, Remove, Merge, Rebase, ...) returns an error
+// matching ErrFrozen, and prefix keys are precomputed for lookups. Its Config must not
+// be modified either. Clone and CloneWith return mutable copies.
+func (s *System) Freeze() *System {
+	frozen := s.Clone()
+	frozen.frozen = true
+	frozen.prefixKeys = make([]string, len(frozen.prefixes))
+	for i, p := range frozen.prefixes {
+		frozen.prefixKeys[i] = frozen.normalizeKey(p.Symbol)
+	}
+	return frozen
+}
+
+// Frozen reports whether the System was produced by Freeze.
+func (s *System) Frozen() bool {
+	return s.frozen
+}
+
+// checkMutable reports an ErrFrozen error for frozen Systems.
+func (s *System) checkMutable(op string) error {
+	if s.frozen {
+		return fmt.Errorf("cannot %s: %w", op, ErrFrozen)
+	}
+	return nil
+}
+
+// prefixKey returns the normalized key of s.prefixes[i].
+func (s *System) prefixKey(i int) string {
+	if s.prefixKeys != nil {
+		return s.prefixKeys[i]
+	}
+	return s.normalizeKey(s.prefixes[i].Symbol)
+}
+
+// View returns a frozen System that shares the registrations of s but resolves symbols
+// under config, e.g. to accept plurals for one parse (see parser.WithConfig) without
+// cloning s. CaseInsensitive and NormalizeUnicode decide how symbols are keyed and are
+// kept from s; use CloneWith to change them. The view sees later changes to s, so it
+// must not be used while s is modified concurrently. If config resolves like s, View
+// returns s itself.
+func (s *System) View(config SystemConfig) *System {
+	config.CaseInsensitive, config.NormalizeUnicode = s.Config.CaseInsensitive, s.Config.NormalizeUnicode
+	if config.AutoPlural == s.Config.AutoPlural && config.LongNames == s.Config.LongNames &&
+		config.RejectAmbiguousUnits == s.Config.RejectAmbiguousUnits && config.ResolutionPolicy == s.Config.ResolutionPolicy {
+		return s
+	}
+	view := *s
+	view.Config = config
+	view.frozen = true
+	return &view
+}
@@ -0,0 +1,137 @@
+package unit
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// FuzzyMatch is the result of ResolveFuzzy.
+type FuzzyMatch struct {
+	Resolution
+	// Symbol is the spelling that resolved, e.g. "sec" for "Sec.".
+	Symbol string
+	// Exact reports whether the symbol resolved as written. Inexact matches are best
+	// guesses that interactive tools should confirm ("did you mean sec?").
+	Exact bool
+}
+
+// ResolveFuzzy resolves symbol like ResolveFull and, failing that, tries harder: it
+// ignores surrounding spaces and trailing periods ("sec."), diacritics ("mètre") and
+// case ("KIB" for "KiB"). When several registered spellings fit, the one closest in
+// case to the input wins. It is meant for interactive tools that prefer best-effort
+// matching over strict rejection; Resolve itself stays strict.
+func (s *System) ResolveFuzzy(symbol string) (FuzzyMatch, bool) {
+	if r, ok := s.ResolveFull(symbol); ok {
+		return FuzzyMatch{Resolution: r, Symbol: symbol, Exact: true}, true
+	}
+
+	trimmed := strings.TrimRight(strings.TrimSpace(symbol), ".")
+	if trimmed == "" {
+		return FuzzyMatch{}, false
+	}
+	plain := stripDiacritics(s.normalizeInput(trimmed))
+	for _, candidate := range []string{trimmed, plain, strings.ToLower(plain)} {
+		if r, ok := s.ResolveFull(candidate); ok {
+			return FuzzyMatch{Resolution: r, Symbol: candidate}, true
+		}
+	}
+
+	// Registered spellings, with and without prefix, that equal the input up to case.
+	folded := strings.ToLower(plain)
+	var candidates []string
+	for _, word := range s.spellings() {
+		w := strings.ToLower(stripDiacritics(word))
+		head, ok := strings.CutSuffix(folded, w)
+		if !ok {
+			continue
+		}
+		if head == "" {
+			candidates = append(candidates, word)
+			continue
+		}
+		for _, p := range s.prefixes {
+			if strings.ToLower(stripDiacritics(p.Symbol)) == head {
+				candidates = append(candidates, p.Symbol+word)
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		di, dj := caseDistance(plain, stripDiacritics(candidates[i])), caseDistance(plain, stripDiacritics(candidates[j]))
+		if di != dj {
+			return di < dj
+		}
+		return candidates[i] < candidates[j]
+	})
+	for _, candidate := range candidates {
+		if r, ok := s.ResolveFull(candidate); ok {
+			return FuzzyMatch{Resolution: r, Symbol: candidate}, true
+		}
+	}
+	return FuzzyMatch{}, false
+}
+
+// FuzzyResolver returns a Resolver that matches like ResolveFuzzy, for
+// parser.WithResolver. The parser cannot tell inexact matches apart, so tools that
+// need confirmation should check the unit part with ResolveFuzzy.
+func (s *System) FuzzyResolver() Resolver {
+	return ResolverFunc(func(symbol string) (Unit, float64, bool) {
+		m, ok := s.ResolveFuzzy(symbol)
+		if !ok {
+			return Unit{}, 0, false
+		}
+		return s.Resolve(m.Symbol)
+	})
+}
+
+// spellings returns the symbols of all units and aliases as registered.
+func (s *System) spellings() []string {
+	words := make([]string, 0, len(s.units)+len(s.aliases))
+	for _, u := range s.units {
+		words = append(words, u.Symbol)
+	}
+	for _, a := range s.aliases {
+		words = append(words, a.symbol)
+	}
+	return words
+}
+
+// diacritics maps accented Latin letters to their base letter.
+var diacritics = map[rune]rune{
+	'à': 'a', 'á': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a',
+	'ç': 'c', 'è': 'e', 'é': 'e', 'ê': 'e', 'ë': 'e',
+	'ì': 'i', 'í': 'i', 'î': 'i', 'ï': 'i', 'ñ': 'n',
+	'ò': 'o', 'ó': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ø': 'o',
+	'ù': 'u', 'ú': 'u', 'û': 'u', 'ü': 'u', 'ý': 'y', 'ÿ': 'y',
+}
+
+// stripDiacritics replaces accented Latin letters with their base letter, keeping case.
+func stripDiacritics(s string) string {
+	return strings.Map(func(r rune) rune {
+		lower := unicode.ToLower(r)
+		base, ok := diacritics[lower]
+		if !ok {
+			return r
+		}
+		if lower != r {
+			return unicode.ToUpper(base)
+		}
+		return base
+	}, s)
+}
+
+// caseDistance counts the letters of a and b that differ, assuming they are equal up
+// to case.
+func caseDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	n := 0
+	for i := 0; i < len(ra) && i < len(rb); i++ {
+		if ra[i] != rb[i] {
+			n++
+		}
+	}
+	if len(ra) != len(rb) {
+		n += max(len(ra), len(rb)) - min(len(ra), len(rb))
+	}
+	return n
+}
@@ -0,0 +1,57 @@
+package unit
+
+import "sort"
+
+// Units returns all registered units, e.g. to generate help text or autocompletion.
+// Aliases are listed separately. The result is sorted by dimension, then scale, then symbol.
+func (s *System) Units() []Unit {
+	out := make([]Unit, 0, len(s.units))
+	for _, u := range s.units {
+		out = append(out, u)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Dimension != b.Dimension {
+			return a.Dimension.String() < b.Dimension.String()
+		}
+		if a.Scale != b.Scale {
+			return a.Scale < b.Scale
+		}
+		return shorter(a.Symbol, b.Symbol)
+	})
+	return out
+}
+
+// Prefixes returns all registered prefixes, sorted by scale, then symbol.
+func (s *System) Prefixes() []Prefix {
+	out := append([]Prefix(nil), s.prefixes...)
+	sortPrefixes(out)
+	return out
+}
+
+// Bindings returns the prefixes that apply to the unit with the given symbol, sorted
+// by scale, then symbol. It returns nil for unknown units.
+func (s *System) Bindings(symbol string) []Prefix {
+	key, _, ok := s.lookupUnit(symbol)
+	if !ok {
+		return nil
+	}
+	var out []Prefix
+	for _, p := range s.prefixes {
+		if s.prefixAllowed(key, s.normalizeKey(p.Symbol)) {
+			out = append(out, p)
+		}
+	}
+	sortPrefixes(out)
+	return out
+}
+
+// sortPrefixes orders prefixes by scale, then symbol.
+func sortPrefixes(p []Prefix) {
+	sort.Slice(p, func(i, j int) bool {
+		if p[i].Scale != p[j].Scale {
+			return p[i].Scale < p[j].Scale
+		}
+		return shorter(p[i].Symbol, p[j].Symbol)
+	})
+}
@@ -0,0 +1,143 @@
+package unit
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// longNames maps long unit names to the symbols they may be registered under; see
+// SystemConfig.LongNames. A name only matches a unit of its dimension, so "minute"
+// never resolves to the meter "m".
+var longNames = []struct {
+	names   []string
+	symbols []string
+	dim     Dimension
+}{
+	{[]string{"second"}, []string{"s", "sec"}, DimTime},
+	{[]string{"millisecond"}, []string{"ms"}, DimTime},
+	{[]string{"microsecond"}, []string{"µs", "us"}, DimTime},
+	{[]string{"nanosecond"}, []string{"ns"}, DimTime},
+	{[]string{"minute"}, []string{"min", "m"}, DimTime},
+	{[]string{"hour"}, []string{"h", "hr"}, DimTime},
+	{[]string{"day"}, []string{"d"}, DimTime},
+	{[]string{"week"}, []string{"w", "wk"}, DimTime},
+	{[]string{"meter", "metre"}, []string{"m"}, DimLength},
+	{[]string{"inch"}, []string{"in"}, DimLength},
+	{[]string{"foot", "feet"}, []string{"ft"}, DimLength},
+	{[]string{"yard"}, []string{"yd"}, DimLength},
+	{[]string{"mile"}, []string{"mi"}, DimLength},
+	{[]string{"gram", "gramme"}, []string{"g"}, DimMass},
+	{[]string{"tonne"}, []string{"t"}, DimMass},
+	{[]string{"pound"}, []string{"lb"}, DimMass},
+	{[]string{"ounce"}, []string{"oz"}, DimMass},
+	{[]string{"kelvin"}, []string{"K"}, DimTemp},
+	{[]string{"ampere", "amp"}, []string{"A"}, DimCurrent},
+	{[]string{"mole"}, []string{"mol"}, DimAmount},
+	{[]string{"candela"}, []string{"cd"}, DimLuminous},
+	{[]string{"bit"}, []string{"b"}, DimStorage},
+	{[]string{"byte"}, []string{"B"}, DimStorage},
+}
+
+// longPrefixes maps long prefix names to the symbols they may be registered under.
+var longPrefixes = []struct {
+	name    string
+	symbols []string
+}{
+	{"kibi", []string{"Ki"}}, {"mebi", []string{"Mi"}}, {"gibi", []string{"Gi"}},
+	{"tebi", []string{"Ti"}}, {"pebi", []string{"Pi"}}, {"exbi", []string{"Ei"}},
+	{"quecto", []string{"q"}}, {"ronto", []string{"r"}}, {"yocto", []string{"y"}},
+	{"zepto", []string{"z"}}, {"atto", []string{"a"}}, {"femto", []string{"f"}},
+	{"pico", []string{"p"}}, {"nano", []string{"n"}}, {"micro", []string{"µ", "u"}},
+	{"milli", []string{"m"}}, {"centi", []string{"c"}}, {"deci", []string{"d"}},
+	{"deca", []string{"da"}}, {"deka", []string{"da"}}, {"hecto", []string{"h"}},
+	{"kilo", []string{"k", "K"}}, {"mega", []string{"M"}}, {"giga", []string{"G"}},
+	{"tera", []string{"T"}}, {"peta", []string{"P"}}, {"exa", []string{"E"}},
+	{"zetta", []string{"Z"}}, {"yotta", []string{"Y"}}, {"ronna", []string{"R"}},
+	{"quetta", []string{"Q"}},
+}
+
+// lookupWord finds the unit spelled by w, which is a plural stem if plural is set.
+// Plurals of symbols and aliases need AutoPlural and a word symbol; long names only
+// need LongNames.
+func (s *System) lookupWord(w string, plural bool) (string, Unit, bool) {
+	if key, u, ok := s.lookupSymbol(w); ok && (!plural || s.Config.AutoPlural && isWord(u.Symbol)) {
+		return key, u, true
+	}
+	if a, ok := s.aliases[s.normalizeKey(w)]; ok && (!plural || s.Config.AutoPlural && isWord(a.symbol)) {
+		return a.unit, s.units[a.unit], true
+	}
+	if s.Config.LongNames {
+		return s.lookupLongName(w)
+	}
+	return "", Unit{}, false
+}
+
+// lookupLongName resolves a long unit name such as "meter" to its registered symbol.
+func (s *System) lookupLongName(name string) (string, Unit, bool) {
+	name = strings.ToLower(name)
+	for _, ln := range longNames {
+		if !slices.Contains(ln.names, name) {
+			continue
+		}
+		for _, sym := range ln.symbols {
+			if key, u, ok := s.lookupSymbol(sym); ok && u.Dimension.Equals(ln.dim) {
+				return key, u, true
+			}
+		}
+	}
+	return "", Unit{}, false
+}
+
+// resolveLongPrefix resolves a unit written with a long prefix name ("kilometers").
+func (s *System) resolveLongPrefix(symbol string) (Resolution, bool) {
+	lower := strings.ToLower(symbol)
+	for _, lp := range longPrefixes {
+		if len(lower) <= len(lp.name) || !strings.HasPrefix(lower, lp.name) {
+			continue
+		}
+		baseSymbol := symbol[len(lp.name):]
+		uKey, u, ok := s.lookupUnit(baseSymbol)
+		if !ok || !u.Linear() {
+			continue
+		}
+		for _, sym := range lp.symbols {
+			if p, ok := s.LookupPrefix(sym); ok && s.prefixAllowed(uKey, s.normalizeKey(p.Symbol)) {
+				return Resolution{Unit: u, Prefix: p, Alias: baseSymbol, Exponent: 1}, true
+			}
+		}
+	}
+	return Resolution{}, false
+}
+
+// pluralStems returns the singular forms w may be the plural of: "inches" -> "inch",
+// "Bytes" -> "Byte". Suffixes match in any case.
+func pluralStems(w string) []string {
+	lower := strings.ToLower(w)
+	var stems []string
+	if stem, ok := strings.CutSuffix(lower, "es"); ok {
+		for _, end := range []string{"s", "x", "z", "ch", "sh"} {
+			if strings.HasSuffix(stem, end) {
+				stems = append(stems, w[:len(stem)])
+				break
+			}
+		}
+	}
+	if strings.HasSuffix(lower, "s") && len(w) > 1 {
+		stems = append(stems, w[:len(w)-1])
+	}
+	return stems
+}
+
+// isWord reports whether symbol is a word of at least two letters, so that "ms" is
+// never read as the plural of "m".
+func isWord(symbol string) bool {
+	n := 0
+	for _, r := range symbol {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+		n++
+	}
+	return n >= 2
+}
@@ -0,0 +1,210 @@
+package unit
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ConflictPolicy controls how Merge handles a symbol defined differently in both Systems.
+type ConflictPolicy int
+
+const (
+	// ConflictError fails the merge and leaves the receiver unchanged.
+	ConflictError ConflictPolicy = iota
+	// ConflictKeep keeps the receiver's definition and skips the other one, along with
+	// its prefix bindings.
+	ConflictKeep
+	// ConflictOverwrite replaces the receiver's definition with the other one. An
+	// overwritten unit loses its previous prefix bindings; an overwritten prefix changes
+	// scale for every unit it is bound to.
+	ConflictOverwrite
+)
+
+// Merge imports the units, aliases, prefixes, prefix bindings, display symbols,
+// preferred units, base units, constraints and deprecations of other, e.g. to compose length,
+// mass and time into one physics System. Symbols are re-keyed under the receiver's
+// Config, which is kept as is. Identical definitions are not conflicts; others are
+// resolved by policy. On error the receiver is unchanged.
+func (s *System) Merge(other *System, policy ConflictPolicy) error {
+	if err := s.checkMutable("merge"); err != nil {
+		return err
+	}
+	merged := s.Clone()
+	if err := merged.merge(other, policy); err != nil {
+		return err
+	}
+	*s = *merged
+	return nil
+}
+
+func (s *System) merge(other *System, policy ConflictPolicy) error {
+	// Units, in key order so that conflicts are reported deterministically.
+	otherKeys := make([]string, 0, len(other.units))
+	for k := range other.units {
+		otherKeys = append(otherKeys, k)
+	}
+	sort.Strings(otherKeys)
+
+	// unitKeys maps the keys of other's imported units to keys in s.
+	unitKeys := make(map[string]string, len(other.units))
+	for _, oKey := range otherKeys {
+		u := other.units[oKey]
+		key := s.unitKey(u)
+		prev, ok := s.units[key]
+		if a, isAlias := s.aliases[s.normalizeKey(u.Symbol)]; !ok && isAlias {
+			prev, ok = s.units[a.unit], true
+		}
+		if ok && !sameUnit(prev, u) {
+			switch policy {
+			case ConflictError:
+				return fmt.Errorf("unit %s conflicts with %s", u.Symbol, prev.Symbol)
+			case ConflictKeep:
+				continue
+			}
+			if err := s.Remove(u.Symbol); err != nil {
+				return err
+			}
+		}
+		s.units[key] = u
+		unitKeys[oKey] = key
+	}
+
+	// Aliases of imported units.
+	for _, k := range sortedKeys(other.aliases) {
+		a := other.aliases[k]
+		uKey, ok := unitKeys[a.unit]
+		if !ok {
+			continue
+		}
+		if key, prev, found := s.lookupUnit(a.symbol); found && key != uKey {
+			switch policy {
+			case ConflictError:
+				return fmt.Errorf("alias %s conflicts with %s", a.symbol, prev.Symbol)
+			case ConflictKeep:
+				continue
+			}
+			if err := s.Remove(a.symbol); err != nil {
+				return err
+			}
+		}
+		s.aliases[s.normalizeKey(a.symbol)] = unitAlias{symbol: a.symbol, unit: uKey}
+	}
+
+	// Prefixes; prefixKeys maps the keys of other's imported prefixes to keys in s.
+	prefixKeys := make(map[string]string, len(other.prefixes))
+	for _, p := range other.prefixes {
+		if prev, ok := s.LookupPrefix(p.Symbol); !ok {
+			s.prefixes = append(s.prefixes, p)
+		} else if prev.Scale != p.Scale {
+			switch policy {
+			case ConflictError:
+				return fmt.Errorf("prefix %s conflicts with %s", p.Symbol, prev.Symbol)
+			case ConflictKeep:
+				continue
+			}
+			if err := s.OverwritePrefix(p.Symbol, p.Scale); err != nil {
+				return err
+			}
+		}
+		prefixKeys[other.normalizeKey(p.Symbol)] = s.normalizeKey(p.Symbol)
+	}
+	// Sort prefixes by length (longest first)
+	sort.Slice(s.prefixes, func(i, j int) bool {
+		return len(s.prefixes[i].Symbol) > len(s.prefixes[j].Symbol)
+	})
+
+	// Bindings between imported units and prefixes.
+	for oKey, pSet := range other.unitPrefixes {
+		uKey, ok := unitKeys[oKey]
+		if !ok {
+			continue
+		}
+		for opKey, allowed := range pSet {
+			pKey, ok := prefixKeys[opKey]
+			if !ok {
+				continue
+			}
+			if s.unitPrefixes[uKey] == nil {
+				s.unitPrefixes[uKey] = make(map[string]bool)
+			}
+			s.unitPrefixes[uKey][pKey] = allowed
+		}
+	}
+	for opKey := range other.universalPrefixes {
+		if pKey, ok := prefixKeys[opKey]; ok {
+			s.universalPrefixes[pKey] = true
+		}
+	}
+
+	// Display symbols of imported units, as long as they still spell the unit.
+	for _, oKey := range sortedKeys(other.displaySymbols) {
+		sym := other.displaySymbols[oKey]
+		uKey, ok := unitKeys[oKey]
+		if !ok {
+			continue
+		}
+		if key, _, found := s.lookupUnit(sym); !found || key != uKey {
+			continue
+		}
+		if prev, ok := s.displaySymbols[uKey]; ok && prev != sym {
+			switch policy {
+			case ConflictError:
+				return fmt.Errorf("display symbol %s conflicts with %s", sym, prev)
+			case ConflictKeep:
+				continue
+			}
+		}
+		s.displaySymbols[uKey] = sym
+	}
+
+	// Preferred units, as long as they still resolve to their dimension.
+	for dim, symbol := range other.preferredUnits {
+		if r, ok := s.ResolveFull(symbol); !ok || !r.Dimension().Equals(dim) {
+			continue
+		}
+		if prev, ok := s.preferredUnits[dim]; ok && prev != symbol {
+			switch policy {
+			case ConflictError:
+				return fmt.Errorf("preferred unit %s conflicts with %s", symbol, prev)
+			case ConflictKeep:
+				continue
+			}
+		}
+		s.preferredUnits[dim] = symbol
+	}
+
+	// Base units, as long as they still have Scale 1.
+	for dim, symbol := range other.bases {
+		if prev, ok := s.bases[dim]; ok && prev != symbol {
+			switch policy {
+			case ConflictError:
+				return fmt.Errorf("base unit %s conflicts with %s", symbol, prev)
+			case ConflictKeep:
+				continue
+			}
+		}
+		_ = s.SetBase(dim, symbol) // fails only if symbol no longer has Scale 1
+	}
+
+	s.constraints = append(s.constraints, other.constraints...)
+
+	// Deprecations, as long as both spellings still resolve.
+	for _, k := range sortedKeys(other.deprecated) {
+		d := other.deprecated[k]
+		if prev, ok := s.Deprecation(d.symbol); ok && prev != d.replacement {
+			switch policy {
+			case ConflictError:
+				return fmt.Errorf("deprecation of %s conflicts: %s vs %s", d.symbol, d.replacement, prev)
+			case ConflictKeep:
+				continue
+			}
+		}
+		_ = s.Deprecate(d.symbol, d.replacement) // fails only if a spelling no longer resolves
+	}
+	return nil
+}
+
+// sameUnit reports whether a and b define the same unit. Constraints are not compared.
+func sameUnit(a, b Unit) bool {
+	return conversionOf(a) == conversionOf(b) && a.CaseSensitive == b.CaseSensitive && a.CaseInsensitive == b.CaseInsensitive
+}
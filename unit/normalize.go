@@ -0,0 +1,47 @@
+package unit
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// unicodeLookalikes maps compatibility characters to the form NFKC gives them, for
+// symbols that users type in several ways. Superscript digits are left alone: they
+// are exponents, not lookalikes.
+var unicodeLookalikes = map[rune]string{
+	'µ': "μ",  // micro sign -> Greek small mu
+	'Ω': "Ω",  // ohm sign -> Greek capital omega
+	'K': "K",  // kelvin sign -> Latin K
+	'Å': "Å",  // angstrom sign -> Latin A with ring
+	'℃': "°C", // degree Celsius
+	'℉': "°F", // degree Fahrenheit
+	'ℓ': "l",  // script small l (litre)
+}
+
+// normalizeUnicode maps lookalikes and full-width ASCII ("ｋｇ") to one spelling.
+func normalizeUnicode(s string) string {
+	ascii := true
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		switch {
+		case unicodeLookalikes[r] != "":
+			b.WriteString(unicodeLookalikes[r])
+		case r >= '！' && r <= '～':
+			b.WriteRune(r - '！' + '!')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,26 @@
+package unit
+
+import "fmt"
+
+// SetPreferredUnit makes Format render values of dim in symbol (optionally prefixed,
+// e.g. "GiB"), independently of the base unit used for arithmetic.
+func (s *System) SetPreferredUnit(dim Dimension, symbol string) error {
+	if err := s.checkMutable("set preferred unit " + symbol); err != nil {
+		return err
+	}
+	r, ok := s.ResolveFull(symbol)
+	if !ok {
+		return fmt.Errorf("cannot prefer unknown unit: %s", symbol)
+	}
+	if !r.Dimension().Equals(dim) {
+		return fmt.Errorf("cannot prefer %s for dimension %s: unit has dimension %s", symbol, dim, r.Dimension())
+	}
+	s.preferredUnits[dim] = symbol
+	return nil
+}
+
+// PreferredUnit returns the unit set by SetPreferredUnit for dim.
+func (s *System) PreferredUnit(dim Dimension) (string, bool) {
+	symbol, ok := s.preferredUnits[dim]
+	return symbol, ok
+}
@@ -0,0 +1,131 @@
+package unit
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Rebase rescales the System so that symbol (optionally prefixed, e.g. "B" or "KiB")
+// gets Scale 1, e.g. rebasing storage from bits to Bytes. Parse results of integer
+// type count the base unit, so this decides what "1" means.
+//
+// Only units carrying the base's dimension are affected, including derived ones:
+// after rebasing to "B", a "B/s" composite is rescaled as well, while time units are not.
+// Scales are divided as decimal numbers ("1e-3" / "1e-9" = 1e6 exactly).
+// The symbol's dimension must be a single base dimension (e.g. L or storage).
+func (s *System) Rebase(symbol string) error {
+	if err := s.checkMutable("rebase to " + symbol); err != nil {
+		return err
+	}
+	r, ok := s.ResolveFull(symbol)
+	if !ok {
+		return fmt.Errorf("unknown unit: %s", symbol)
+	}
+	if r.Exponent != 1 {
+		return fmt.Errorf("cannot rebase to %s: exponents are not allowed", symbol)
+	}
+	power, err := baseDimensionPower(r.Unit.Dimension)
+	if err != nil {
+		return fmt.Errorf("cannot rebase to %s: %w", symbol, err)
+	}
+
+	base := decimalRat(r.Scale())
+	if base == nil || base.Sign() <= 0 {
+		return fmt.Errorf("cannot rebase to %s: scale %g is not positive", symbol, r.Scale())
+	}
+	rescale := func(scale float64, dim Dimension) float64 {
+		k := power(dim)
+		v := decimalRat(scale)
+		if k == 0 || v == nil {
+			return scale
+		}
+		f := new(big.Rat).Set(base)
+		if k < 0 {
+			f.Inv(f)
+			k = -k
+		}
+		div := big.NewRat(1, 1)
+		for ; k > 0; k-- {
+			div.Mul(div, f)
+		}
+		q, _ := v.Quo(v, div).Float64()
+		return q
+	}
+
+	for key, u := range s.units {
+		u.Scale = rescale(u.Scale, u.Dimension)
+		u.Offset = rescale(u.Offset, u.Dimension)
+		if toBase, fromBase := u.Funcs(); toBase != nil {
+			ext := u.editExt()
+			ext.toBase, ext.fromBase = rescaleFuncs(toBase, fromBase, rescale(1, u.Dimension))
+		}
+		s.units[key] = u
+	}
+
+	// symbol is the new base; declarations of derived dimensions no longer hold.
+	for dim := range s.bases {
+		if power(dim) != 0 {
+			delete(s.bases, dim)
+		}
+	}
+	if s.bases != nil {
+		s.bases[r.Unit.Dimension] = symbol
+	}
+	return nil
+}
+
+// rescaleFuncs wraps the conversions of a function unit for a base unit that is 1/ratio
+// of the previous one.
+func rescaleFuncs(toBase, fromBase func(float64) float64, ratio float64) (to, from func(float64) float64) {
+	if ratio == 1 {
+		return toBase, fromBase
+	}
+	to = func(v float64) float64 { return toBase(v) * ratio }
+	from = func(v float64) float64 { return fromBase(v / ratio) }
+	return to, from
+}
+
+// baseDimensionPower checks that dim is a single base dimension and returns a function
+// reporting how often that base dimension occurs in another dimension.
+func baseDimensionPower(dim Dimension) (func(Dimension) int, error) {
+	if dim.Extra != "" {
+		if (dim != Dimension{Extra: dim.Extra}) {
+			return nil, fmt.Errorf("dimension %s is not a base dimension", dim)
+		}
+		// Extra tags have no exponent algebra and only occur once in a numerator.
+		return func(d Dimension) int {
+			if d.Extra == dim.Extra {
+				return 1
+			}
+			return 0
+		}, nil
+	}
+
+	exps := func(d Dimension) [7]int { return [7]int{d.L, d.M, d.T, d.I, d.K, d.N, d.J} }
+	index := -1
+	for i, e := range exps(dim) {
+		switch {
+		case e == 0:
+		case e == 1 && index < 0:
+			index = i
+		default:
+			return nil, fmt.Errorf("dimension %s is not a base dimension", dim)
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("dimensionless units cannot be a base")
+	}
+	return func(d Dimension) int { return exps(d)[index] }, nil
+}
+
+// decimalRat returns the shortest decimal representation of f as an exact rational,
+// so that scales written as decimals (1e-3) divide without binary noise.
+// It returns nil for infinities and NaN.
+func decimalRat(f float64) *big.Rat {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	if !ok {
+		return nil
+	}
+	return r
+}
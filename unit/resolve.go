@@ -0,0 +1,147 @@
+package unit
+
+import (
+	"math"
+	"strings"
+)
+
+// Resolution describes how a symbol was resolved against a System.
+type Resolution struct {
+	// Unit is the canonical registered unit, without any exponent applied. For an
+	// unregistered composite symbol (e.g. "kg*m/s^2") it is computed from the factors.
+	Unit Unit
+	// Prefix is the matched prefix as registered; its Symbol is empty if none matched.
+	Prefix Prefix
+	// Alias is the unit part of the symbol as it was written (e.g. "bytes" for unit "Bytes").
+	Alias string
+	// Exponent is the superscript exponent applied to the prefixed unit (1 if none).
+	Exponent int
+}
+
+// PrefixScale returns the prefix scale raised to the exponent (1 if no prefix matched).
+func (r Resolution) PrefixScale() float64 {
+	scale := 1.0
+	if r.Prefix.Symbol != "" {
+		scale = r.Prefix.Scale
+	}
+	return math.Pow(scale, float64(r.Exponent))
+}
+
+// Scale returns the total scale of the resolved symbol relative to the base unit.
+func (r Resolution) Scale() float64 {
+	return r.PrefixScale() * math.Pow(r.Unit.Scale, float64(r.Exponent))
+}
+
+// Dimension returns the dimension of the resolved symbol, exponent included.
+func (r Resolution) Dimension() Dimension {
+	return r.Unit.Dimension.Pow(r.Exponent)
+}
+
+// Resolve attempts to resolve a symbol into a Unit and a scaling factor.
+//
+// A trailing Unicode superscript exponent (e.g. "m²", "s⁻¹", "cm³") raises both the
+// prefixed unit's scale and its dimension to that power. Symbols registered verbatim
+// with a superscript still take priority.
+//
+// Unregistered composite symbols such as "m/s", "kg*m/s^2" or "W/m²" are split into
+// factors as in AddComposite, multiplying scales and combining dimensions. The returned
+// prefix scale is 1 for them: prefixes are folded into the unit's scale.
+//
+// Resolve only reports the prefix scale; ResolveFull also reports which prefix matched
+// (e.g. "Ki" + "B" for "KiB").
+func (s *System) Resolve(symbol string) (Unit, float64, bool) {
+	symbol = s.normalizeInput(symbol)
+	r, ok := s.ResolveFull(symbol)
+	if !ok {
+		return Unit{}, 0, false
+	}
+	u := r.Unit
+	if r.Exponent != 1 {
+		u.Symbol += symbol[len(r.Prefix.Symbol)+len(r.Alias):]
+		u.Scale = math.Pow(u.Scale, float64(r.Exponent))
+		u.Dimension = r.Dimension()
+	}
+	return u, r.PrefixScale(), true
+}
+
+// ResolveFull resolves a symbol like Resolve, but reports the canonical unit together
+// with the prefix, spelling and exponent that matched.
+func (s *System) ResolveFull(symbol string) (Resolution, bool) {
+	symbol = s.normalizeInput(symbol)
+	if r, ok := s.resolveSimple(symbol); ok {
+		return r, true
+	}
+
+	if strings.ContainsAny(symbol, compositeOperators) {
+		scale, dim, err := s.evalComposite(symbol)
+		if err != nil {
+			return Resolution{}, false
+		}
+		return Resolution{Unit: Unit{Symbol: symbol, Scale: scale, Dimension: dim}, Alias: symbol, Exponent: 1}, true
+	}
+
+	base, exp, ok := splitSuperscript(symbol)
+	if !ok {
+		return Resolution{}, false
+	}
+	r, found := s.resolveSimple(base)
+	// Non-SI dimensions (Extra) and non-linear units have no exponent algebra.
+	if !found || r.Unit.Dimension.Extra != "" || !r.Unit.Linear() {
+		return Resolution{}, false
+	}
+	r.Exponent = exp
+	return r, true
+}
+
+// resolveSimple resolves a plain unit symbol with an optional prefix.
+func (s *System) resolveSimple(symbol string) (Resolution, bool) {
+	if s.Config.RejectAmbiguousUnits {
+		if _, ok := s.Ambiguity(symbol); ok {
+			return Resolution{}, false
+		}
+	}
+
+	policy := s.Config.ResolutionPolicy
+
+	// 1. Exact Match Priority
+	if policy != ResolvePrefixFirst {
+		if _, u, ok := s.lookupUnit(symbol); ok {
+			return Resolution{Unit: u, Alias: symbol, Exponent: 1}, true
+		}
+	}
+
+	// 2. Prefix + Unit Match, longest prefix first unless the longest unit is preferred
+	for n := range s.prefixes {
+		i := n
+		if policy == ResolveLongestUnit {
+			i = len(s.prefixes) - 1 - n
+		}
+		p := s.prefixes[i]
+		pLen := len(p.Symbol)
+		pKey := s.prefixKey(i)
+		if len(symbol) > pLen && s.prefixMatches(i, symbol[:pLen]) {
+			// Keep the remainder in its original case so case-sensitive units can match.
+			baseSymbol := symbol[pLen:]
+
+			// Check if the remainder is a valid unit; non-linear units take no prefix.
+			if uKey, u, ok := s.lookupUnit(baseSymbol); ok && u.Linear() {
+				// Check if the prefix is allowed for this unit (Whitelist check)
+				if s.prefixAllowed(uKey, pKey) {
+					return Resolution{Unit: u, Prefix: p, Alias: baseSymbol, Exponent: 1}, true
+				}
+			}
+		}
+	}
+	if policy == ResolvePrefixFirst {
+		if _, u, ok := s.lookupUnit(symbol); ok {
+			return Resolution{Unit: u, Alias: symbol, Exponent: 1}, true
+		}
+	}
+
+	// 3. Long Prefix Names
+	if s.Config.LongNames {
+		return s.resolveLongPrefix(symbol)
+	}
+
+	return Resolution{}, false
+}
@@ -0,0 +1,28 @@
+package unit
+
+// Resolver looks up unit symbols for the parser. System is the default implementation;
+// wrap one to add caching, telemetry or tenant-specific overrides, and pass it to the
+// parser with parser.WithResolver.
+type Resolver interface {
+	// Resolve returns the unit of symbol and the scale of its prefix (1 if none),
+	// like System.Resolve.
+	Resolve(symbol string) (Unit, float64, bool)
+}
+
+var _ Resolver = (*System)(nil)
+
+// ResolverFunc adapts a function to the Resolver interface, e.g. to override a few
+// symbols before falling back to a System:
+//
+//	r := unit.ResolverFunc(func(symbol string) (unit.Unit, float64, bool) {
+//		if symbol == "GB" {
+//			return sys.Resolve("GiB") // tenant counts in binary units
+//		}
+//		return sys.Resolve(symbol)
+//	})
+type ResolverFunc func(symbol string) (Unit, float64, bool)
+
+// Resolve calls f(symbol).
+func (f ResolverFunc) Resolve(symbol string) (Unit, float64, bool) {
+	return f(symbol)
+}
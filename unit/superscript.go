@@ -0,0 +1,54 @@
+package unit
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// superscriptDigits maps Unicode superscript digits to their values.
+var superscriptDigits = map[rune]int{
+	'⁰': 0, '¹': 1, '²': 2, '³': 3, '⁴': 4,
+	'⁵': 5, '⁶': 6, '⁷': 7, '⁸': 8, '⁹': 9,
+}
+
+// superscriptMinus is the superscript minus sign (U+207B).
+const superscriptMinus = '⁻'
+
+// splitSuperscript splits a trailing superscript exponent from symbol (e.g. "s⁻¹" -> "s", -1).
+// It reports false if symbol has no exponent or the exponent is malformed.
+func splitSuperscript(symbol string) (string, int, bool) {
+	end := len(symbol)
+	for end > 0 {
+		r, size := utf8.DecodeLastRuneInString(symbol[:end])
+		if _, ok := superscriptDigits[r]; !ok {
+			break
+		}
+		end -= size
+	}
+	if end == len(symbol) {
+		return "", 0, false
+	}
+
+	digits := symbol[end:]
+	base := symbol[:end]
+	negative := false
+	if strings.HasSuffix(base, string(superscriptMinus)) {
+		negative = true
+		base = strings.TrimSuffix(base, string(superscriptMinus))
+	}
+	if base == "" {
+		return "", 0, false
+	}
+
+	exp := 0
+	for _, r := range digits {
+		exp = exp*10 + superscriptDigits[r]
+	}
+	if exp == 0 {
+		return "", 0, false
+	}
+	if negative {
+		exp = -exp
+	}
+	return base, exp, true
+}
@@ -13,55 +13,398 @@ type SystemConfig struct {
 	AllowMultiPart bool
 
 	// CaseInsensitive normalizes input to lowercase.
+	// Individual units can opt out via WithCaseSensitive, and prefixes via SetPrefixCase.
+	// Conversely, WithCaseInsensitive and SetPrefixCase opt in on case-sensitive Systems.
 	CaseInsensitive bool
 
+	// NormalizeUnicode matches symbols after mapping compatibility characters to one
+	// form, as NFKC does: the micro sign "µ" and Greek "μ", the ohm sign "Ω" and "Ω",
+	// "℃" and "°C", full-width "ｋｇ" and "kg". Registered symbols keep their spelling.
+	NormalizeUnicode bool
+
+	// AutoPlural also accepts the English plural of units and aliases spelled as words
+	// of two or more letters: "seconds" for "second", "inches" for "inch". Abbreviations
+	// such as "m" never take a plural, so "ms" is not read as "m".
+	AutoPlural bool
+
+	// LongNames accepts the SI long names of registered units ("meter", "metres", "hours")
+	// and of their prefixes ("kilometers", "kibibytes"), case-insensitively. A long name
+	// only resolves to a unit of its dimension, so "minute" is never the meter "m".
+	LongNames bool
+
+	// ExponentPolicy decides whether 'e'/'E' after a number starts an exponent
+	// ("1e5B") or a unit/prefix such as exa ("1EB"). Defaults to PreferExponent.
+	ExponentPolicy ExponentPolicy
+
 	// Separators allowed between parts (ignored during parsing).
+	// Each rune is a separator, so multi-byte characters such as "、" or "—" are allowed.
 	// Defaults to " \t\n\r,;|/" if empty.
 	Separators string
+
+	// DigitGroupSeparator, if set, is accepted between groups of three digits in the integer
+	// part of a number, so "1,000,000 B" (',') or "1 000 000 B" (' ') is a single number.
+	// Zero disables digit grouping.
+	DigitGroupSeparator rune
+
+	// DecimalSeparator is the decimal mark of numbers; zero means '.'.
+	// With ',' ("1,5 GB" = 1.5 GB), a comma directly between digits is always the decimal
+	// mark, while other commas may still separate parts; '.' is then rejected inside numbers
+	// unless it is the DigitGroupSeparator ("1.000,5").
+	DecimalSeparator rune
+
+	// AllowFractions accepts fractions and mixed numbers as values: "1/2 cup", "1 1/2h",
+	// "½ m". The fraction is kept exact until it is scaled ("1/3 h" = 1200 s).
+	// A '/' directly between digits is then part of the number, not a separator.
+	AllowFractions bool
+
+	// AllowRadixLiterals accepts integer literals with a 0x, 0o or 0b prefix ("0x1000 B").
+	// Hex digits include a-f, so units starting with those letters need a separator.
+	AllowRadixLiterals bool
+
+	// NormalizeDigits maps non-ASCII decimal digits (full-width "１２３", Arabic-Indic "١٢٣", ...)
+	// to ASCII before parsing, for input copied from localized UIs.
+	NormalizeDigits bool
+
+	// AllowUnderscoreDigits accepts Go-style underscores between digits ("1_500ms").
+	AllowUnderscoreDigits bool
+
+	// NegativePolicy decides how negative part values (e.g. "-5MB") are handled.
+	// Defaults to AllowNegative.
+	NegativePolicy NegativePolicy
+
+	// SignPolicy decides which parts of a multi-part value may carry a sign.
+	// Defaults to SignPerPart.
+	SignPolicy SignPolicy
+
+	// SignedZero makes "-0" count as negative for NegativePolicy, so RejectNegative
+	// rejects it. By default "-0" is plain zero.
+	SignedZero bool
+
+	// DefaultUnit is the unit (optionally prefixed, e.g. "KiB") of numbers written without
+	// one, so that "1024" means 1024 B or "30" means 30 s. Empty reports a missing unit.
+	DefaultUnit string
+
+	// Epsilon is the tolerance, in base units, within which a float result snaps to the
+	// nearest integer (e.g. 29.9999999999995 -> 30) to absorb floating point noise.
+	// Systems whose base unit is tiny relative to typical values need a larger one.
+	// Zero means 1e-12.
+	Epsilon float64
+
+	// PrecisionPolicy decides what integer targets do with parts that remain fractional
+	// beyond Epsilon (e.g. "0.5ns" into time.Duration). Defaults to PrecisionStrict.
+	PrecisionPolicy PrecisionPolicy
+
+	// OverflowPolicy decides what integer targets do with values beyond their range
+	// (e.g. "100EiB" into int64). Defaults to OverflowError. ParseInt rejects number
+	// literals beyond 2^64 under either policy, since it reads them exactly.
+	OverflowPolicy OverflowPolicy
+
+	// AllowInfinity lets float targets return ±Inf for numbers or parts beyond the float64
+	// range ("1e400 B") instead of failing with parser.ErrNotFinite. NaN is always rejected.
+	AllowInfinity bool
+
+	// MaxParts bounds the number of parts of a multi-part value, so that inputs such as
+	// "1s1s1s…" repeated millions of times fail early. Zero means 100; negative disables the limit.
+	MaxParts int
+
+	// MaxInputLength rejects inputs longer than this many bytes before they are scanned,
+	// for services parsing untrusted strings. Zero disables the check.
+	MaxInputLength int
+
+	// StrictSyntax rejects scientific notation ("1e3B"), a leading '+' ("+5m"), a leading
+	// '.' (".5h") and empty input, so Parse can validate against a documented grammar
+	// such as Kubernetes-style quantities.
+	StrictSyntax bool
+
+	// AllowUnitFirst also accepts parts written unit before value ("GB 5", "$5"), which
+	// parse like "5 GB". A part is read unit-first only if it does not start with a number.
+	AllowUnitFirst bool
+
+	// RejectDuplicateUnits rejects inputs that use the same unit twice ("1h 2h", also
+	// through aliases like "1h 2hour"), which are usually typos. Units with different
+	// prefixes ("1s 500ms") are distinct.
+	RejectDuplicateUnits bool
+
+	// RequireDescendingOrder rejects parts with a larger unit than an earlier part, so
+	// "1h30m5s" parses but a hand-typed "5s1h" is reported.
+	RequireDescendingOrder bool
+
+	// RejectAmbiguousUnits makes Resolve fail on symbols with several meanings (see
+	// System.Ambiguity) instead of preferring the exact unit, e.g. "min" with a unit
+	// "in" and a prefix "m" registered as well.
+	RejectAmbiguousUnits bool
+
+	// ResolutionPolicy decides which reading of a symbol Resolve picks when it is both a
+	// unit and a prefixed unit, or splits into prefix and unit in several ways.
+	// Defaults to ResolveExactFirst.
+	ResolutionPolicy ResolutionPolicy
+
+	// Format holds the defaults of parser.Format (see FormatConfig).
+	Format FormatConfig
 }
 
+// ExponentPolicy resolves the ambiguity between scientific notation and units starting with 'e'/'E'.
+type ExponentPolicy int
+
+const (
+	// PreferExponent reads 'e'/'E' followed by digits as an exponent ("1e5B" = 100000 B).
+	// An 'e' not followed by digits always starts the unit ("1EB" = 1 exabyte).
+	PreferExponent ExponentPolicy = iota
+	// PreferPrefix disables scientific notation, so 'e'/'E' always starts the unit.
+	PreferPrefix
+	// RejectAmbiguousExponent errors on scientific notation whenever the exponent letter
+	// is also a registered prefix or unit symbol of the System.
+	RejectAmbiguousExponent
+)
+
+// ResolutionPolicy controls the order in which Resolve tries the readings of a symbol.
+type ResolutionPolicy int
+
+const (
+	// ResolveExactFirst tries the symbol as a unit, then splits off the longest matching
+	// prefix: "min" is the minute even if "m" and "in" are registered, "dam" is "da"+"m".
+	ResolveExactFirst ResolutionPolicy = iota
+	// ResolveLongestUnit prefers the longest registered unit: the symbol as a unit, then
+	// the shortest matching prefix, so "kmin" is "k"+"min" even if a prefix "km" exists.
+	ResolveLongestUnit
+	// ResolvePrefixFirst splits off the longest matching prefix before trying the symbol
+	// as a unit, so "min" is milli-inch when "m" is bound to "in".
+	ResolvePrefixFirst
+)
+
+// NegativePolicy controls whether quantities may be negative.
+type NegativePolicy int
+
+const (
+	// AllowNegative accepts negative values as written ("-5m" = -5 m).
+	AllowNegative NegativePolicy = iota
+	// RejectNegative errors on any negative part, for quantities such as byte counts.
+	RejectNegative
+	// AbsoluteNegative accepts a minus sign but uses the magnitude ("-5m" = 5 m).
+	AbsoluteNegative
+)
+
+// SignPolicy controls where signs may appear in multi-part values.
+// A leading '+' is always accepted and doubled signs ("+-3s") are always rejected.
+type SignPolicy int
+
+const (
+	// SignPerPart lets every part carry its own sign: "1h -30m" = 30m.
+	SignPerPart SignPolicy = iota
+	// SignLeading only accepts a sign before the first part and applies it to the whole
+	// quantity, like time.ParseDuration: "-1h30m" = -(1h30m), while "1h-30m" is an error.
+	SignLeading
+)
+
+// PrecisionPolicy controls how fractional parts are converted into integer targets.
+type PrecisionPolicy int
+
+const (
+	// PrecisionStrict reports a precision loss error.
+	PrecisionStrict PrecisionPolicy = iota
+	// PrecisionRoundNearest rounds each part to the nearest integer, halves away from zero.
+	PrecisionRoundNearest
+	// PrecisionTruncate drops the fraction of each part, rounding toward zero.
+	PrecisionTruncate
+	// PrecisionFloor rounds each part toward negative infinity.
+	PrecisionFloor
+	// PrecisionCeil rounds each part toward positive infinity.
+	PrecisionCeil
+)
+
+// OverflowPolicy controls how values beyond the range of an integer target are handled.
+type OverflowPolicy int
+
+const (
+	// OverflowError reports an error matching parser.ErrOverflow.
+	OverflowError OverflowPolicy = iota
+	// OverflowSaturate clamps the value to the minimum or maximum of the target type.
+	OverflowSaturate
+)
+
 // System is a registry for units and prefixes.
 type System struct {
 	units    map[string]Unit
 	prefixes []Prefix
 	Config   SystemConfig
 
-	// unitPrefixes maps unit symbol -> allowed prefix symbols.
+	// unitPrefixes maps unit symbol -> allowed prefix symbols; false excludes a universal prefix.
 	unitPrefixes map[string]map[string]bool
+
+	// universalPrefixes holds the prefix symbols that apply to all units (see AddUniversalPrefix).
+	universalPrefixes map[string]bool
+
+	// aliases maps a normalized alias -> the unit it spells (see AddAlias).
+	aliases map[string]unitAlias
+
+	// displaySymbols maps a unit's registry key -> the spelling it is displayed in: its
+	// own symbol or one of its aliases (see SetDisplaySymbol).
+	displaySymbols map[string]string
+
+	// preferredUnits maps a dimension -> the unit Format renders it in.
+	preferredUnits map[Dimension]string
+
+	// constraints validate parse totals in base units (see AddConstraint).
+	constraints []Constraint
+
+	// bases maps a dimension -> its declared base unit (see SetBase).
+	bases map[Dimension]string
+
+	// deprecated maps a spelling (see deprecationKey) -> its deprecation (see Deprecate).
+	deprecated map[string]deprecation
+
+	// frozen marks a snapshot made by Freeze; prefixKeys caches its normalized prefix symbols.
+	frozen     bool
+	prefixKeys []string
+}
+
+// conversion identifies how a unit converts to base units, so that units registered
+// in two Systems can be compared (see sameUnit). Function units cannot be compared and
+// are told apart by symbol, in fn.
+type conversion struct {
+	scale  float64
+	offset float64
+	dim    Dimension
+	fn     string
+}
+
+// conversionOf returns the conversion of u.
+func conversionOf(u Unit) conversion {
+	g := conversion{scale: u.Scale, offset: u.Offset, dim: u.Dimension}
+	if toBase, _ := u.Funcs(); toBase != nil {
+		g.fn = u.Symbol
+	}
+	return g
 }
 
 // NewSystem creates a new unit system with the given configuration.
 func NewSystem(config SystemConfig) *System {
 	return &System{
-		units:        make(map[string]Unit),
-		prefixes:     make([]Prefix, 0),
-		unitPrefixes: make(map[string]map[string]bool),
-		Config:       config,
+		units:             make(map[string]Unit),
+		prefixes:          make([]Prefix, 0),
+		unitPrefixes:      make(map[string]map[string]bool),
+		universalPrefixes: make(map[string]bool),
+		aliases:           make(map[string]unitAlias),
+		displaySymbols:    make(map[string]string),
+		preferredUnits:    make(map[Dimension]string),
+		Config:            config,
 	}
 }
 
 // normalizeKey adjusts the key based on case sensitivity settings.
 func (s *System) normalizeKey(k string) string {
+	if s.Config.NormalizeUnicode {
+		k = normalizeUnicode(k)
+	}
 	if s.Config.CaseInsensitive {
 		return strings.ToLower(k)
 	}
 	return k
 }
 
-// Add registers a new unit.
-func (s *System) Add(symbol string, scale float64, dim Dimension) {
+// normalizeInput applies NormalizeUnicode to a symbol, keeping its case.
+func (s *System) normalizeInput(symbol string) string {
+	if s.Config.NormalizeUnicode {
+		return normalizeUnicode(symbol)
+	}
+	return symbol
+}
+
+// unitKey returns the registry key of a unit, honoring its case-sensitivity override.
+func (s *System) unitKey(u Unit) string {
+	if u.CaseSensitive {
+		return s.normalizeInput(u.Symbol)
+	}
+	if u.CaseInsensitive {
+		return strings.ToLower(s.normalizeInput(u.Symbol))
+	}
+	return s.normalizeKey(u.Symbol)
+}
+
+// lookupUnit finds a registered unit by symbol, alias, long name or plural and returns
+// its registry key. Units take precedence over aliases, and exact spellings over plurals.
+func (s *System) lookupUnit(symbol string) (string, Unit, bool) {
+	if key, u, ok := s.lookupWord(symbol, false); ok {
+		return key, u, true
+	}
+	if s.Config.AutoPlural || s.Config.LongNames {
+		for _, stem := range pluralStems(symbol) {
+			if key, u, ok := s.lookupWord(stem, true); ok {
+				return key, u, true
+			}
+		}
+	}
+	return "", Unit{}, false
+}
+
+// lookupSymbol finds a registered unit by its own symbol and returns its registry key.
+// Case-sensitive units are matched exactly before falling back to the normalized key.
+func (s *System) lookupSymbol(symbol string) (string, Unit, bool) {
+	if u, ok := s.units[symbol]; ok && u.CaseSensitive {
+		return symbol, u, true
+	}
 	key := s.normalizeKey(symbol)
-	s.units[key] = Unit{Symbol: symbol, Scale: scale, Dimension: dim}
+	if u, ok := s.units[key]; ok && !u.CaseSensitive {
+		return key, u, true
+	}
+	if lower := strings.ToLower(key); !s.Config.CaseInsensitive {
+		if u, ok := s.units[lower]; ok && u.CaseInsensitive {
+			return lower, u, true
+		}
+	}
+	return "", Unit{}, false
+}
+
+// Add registers a new unit. It only fails on frozen Systems.
+func (s *System) Add(symbol string, scale float64, dim Dimension, opts ...UnitOption) error {
+	if err := s.checkMutable("add unit " + symbol); err != nil {
+		return err
+	}
+	u := Unit{Symbol: symbol, Scale: scale, Dimension: dim}
+	for _, opt := range opts {
+		opt(&u)
+	}
+	s.units[s.unitKey(u)] = u
+	return nil
+}
+
+// AddFunc registers a non-linear unit converted by a pair of functions, e.g. decibels
+// of a power ratio:
+//
+//	sys.AddFunc("dB",
+//		func(v float64) float64 { return math.Pow(10, v/10) },
+//		func(r float64) float64 { return 10 * math.Log10(r) },
+//		unit.DimDimensionless)
+//
+// Like affine units, function units take no prefixes and stand alone in an input.
+func (s *System) AddFunc(symbol string, toBase, fromBase func(float64) float64, dim Dimension, opts ...UnitOption) error {
+	if toBase == nil || fromBase == nil {
+		return fmt.Errorf("unit %s needs both conversion functions", symbol)
+	}
+	return s.Add(symbol, 1, dim, append(opts, func(u *Unit) {
+		ext := u.editExt()
+		ext.toBase, ext.fromBase = toBase, fromBase
+	})...)
+}
+
+// AddWithMeta registers a new unit with documentation, e.g. for help generators:
+//
+//	sys.AddWithMeta("GiB", 1<<30, unit.DimStorage, unit.UnitMeta{Name: "gibibyte", Description: "2^30 bytes"})
+func (s *System) AddWithMeta(symbol string, scale float64, dim Dimension, meta UnitMeta, opts ...UnitOption) error {
+	return s.Add(symbol, scale, dim, append(opts, WithMeta(meta))...)
 }
 
 // AddPrefix registers a new prefix and binds it to specific units.
 func (s *System) AddPrefix(prefixSymbol string, scale float64, targetUnits ...string) error {
+	if err := s.checkMutable("add prefix " + prefixSymbol); err != nil {
+		return err
+	}
 	pKey := s.normalizeKey(prefixSymbol)
 
 	// 1. Register or update prefix definition
 	exists := false
 	for _, p := range s.prefixes {
-		if p.Symbol == pKey {
+		if s.normalizeKey(p.Symbol) == pKey {
 			if p.Scale != scale {
 				return fmt.Errorf("prefix %s already defined with different scale", prefixSymbol)
 			}
@@ -70,7 +413,8 @@ func (s *System) AddPrefix(prefixSymbol string, scale float64, targetUnits ...st
 		}
 	}
 	if !exists {
-		s.prefixes = append(s.prefixes, Prefix{Symbol: pKey, Scale: scale})
+		// Keep the spelling as registered; matching always goes through normalizeKey.
+		s.prefixes = append(s.prefixes, Prefix{Symbol: prefixSymbol, Scale: scale})
 		// Sort prefixes by length (longest first)
 		sort.Slice(s.prefixes, func(i, j int) bool {
 			return len(s.prefixes[i].Symbol) > len(s.prefixes[j].Symbol)
@@ -79,9 +423,8 @@ func (s *System) AddPrefix(prefixSymbol string, scale float64, targetUnits ...st
 
 	// 2. Bind to target units
 	for _, uSymbol := range targetUnits {
-		uKey := s.normalizeKey(uSymbol)
-
-		if _, ok := s.units[uKey]; !ok {
+		uKey, _, ok := s.lookupUnit(uSymbol)
+		if !ok {
 			return fmt.Errorf("cannot bind prefix to unknown unit: %s", uSymbol)
 		}
 
@@ -94,40 +437,264 @@ func (s *System) AddPrefix(prefixSymbol string, scale float64, targetUnits ...st
 	return nil
 }
 
-// Clone creates a deep copy of the current System.
+// AddUniversalPrefix registers a prefix that applies to every unit, including units
+// added later. UnbindPrefix excludes single units again.
+func (s *System) AddUniversalPrefix(prefixSymbol string, scale float64) error {
+	if err := s.AddPrefix(prefixSymbol, scale); err != nil {
+		return err
+	}
+	s.universalPrefixes[s.normalizeKey(prefixSymbol)] = true
+	return nil
+}
+
+// prefixAllowed reports whether the prefix with key pKey applies to the unit with key uKey.
+func (s *System) prefixAllowed(uKey, pKey string) bool {
+	if allowed, ok := s.unitPrefixes[uKey][pKey]; ok {
+		return allowed
+	}
+	return s.universalPrefixes[pKey]
+}
+
+// LookupPrefix returns the registered prefix with the given symbol.
+func (s *System) LookupPrefix(symbol string) (Prefix, bool) {
+	pKey := s.normalizeKey(symbol)
+	for _, p := range s.prefixes {
+		if s.normalizeKey(p.Symbol) == pKey {
+			return p, true
+		}
+	}
+	return Prefix{}, false
+}
+
+// Clone creates a deep copy of the current System. The copy of a frozen System is mutable.
 func (s *System) Clone() *System {
-	// 1. Copy Config
 	newSys := NewSystem(s.Config)
+	// Keys are kept as they are, so copying cannot fail.
+	_ = s.copyInto(newSys, "")
+	return newSys
+}
+
+// CloneWith creates a deep copy of the System with its configuration adjusted by override,
+// e.g. a stricter variant of a shared System:
+//
+//	strict, err := sys.CloneWith(func(c *unit.SystemConfig) { c.AllowMultiPart = false })
+//
+// The original System is never modified. If the override toggles CaseInsensitive or
+// NormalizeUnicode, units and prefix bindings are re-keyed; an error naming the toggled
+// setting is returned when two symbols would become indistinguishable (e.g. "m" and "M"
+// in a case-insensitive copy).
+func (s *System) CloneWith(override func(*SystemConfig)) (*System, error) {
+	config := s.Config
+	if override != nil {
+		override(&config)
+	}
+	var changed []string
+	if config.CaseInsensitive != s.Config.CaseInsensitive {
+		changed = append(changed, fmt.Sprintf("CaseInsensitive=%t", config.CaseInsensitive))
+	}
+	if config.NormalizeUnicode != s.Config.NormalizeUnicode {
+		changed = append(changed, fmt.Sprintf("NormalizeUnicode=%t", config.NormalizeUnicode))
+	}
+
+	newSys := NewSystem(config)
+	if err := s.copyInto(newSys, strings.Join(changed, " and ")); err != nil {
+		return nil, err
+	}
+	return newSys, nil
+}
+
+// copyInto copies the registrations of s into dst, a System fresh from NewSystem.
+// An empty rekeyed keeps the keys of s; otherwise it names the settings that changed
+// how dst keys symbols, and units, aliases, prefixes and their bindings are re-keyed
+// under dst. Symbols that become indistinguishable are reported with rekeyed.
+func (s *System) copyInto(dst *System, rekeyed string) error {
+	rekey := rekeyed != ""
 
-	// 2. Copy Units
-	for k, u := range s.units {
-		newSys.units[k] = u
+	// 1. Units
+	unitKeys := make(map[string]string, len(s.units))
+	for oldKey, u := range s.units {
+		key := oldKey
+		if rekey {
+			key = dst.unitKey(u)
+			if prev, ok := dst.units[key]; ok {
+				return fmt.Errorf("units %s and %s collide with %s", prev.Symbol, u.Symbol, rekeyed)
+			}
+		}
+		dst.units[key] = u
+		unitKeys[oldKey] = key
 	}
 
-	// 3. Copy Prefixes
+	// 2. Prefixes; the registered spelling is kept, so only keys change.
+	prefixKeys := make(map[string]string, len(s.prefixes))
+	if rekey {
+		seen := make(map[string]string, len(s.prefixes))
+		for _, p := range s.prefixes {
+			key := dst.normalizeKey(p.Symbol)
+			if prev, ok := seen[key]; ok {
+				return fmt.Errorf("prefixes %s and %s collide with %s", prev, p.Symbol, rekeyed)
+			}
+			seen[key] = p.Symbol
+			prefixKeys[s.normalizeKey(p.Symbol)] = key
+		}
+	}
 	if len(s.prefixes) > 0 {
-		newSys.prefixes = make([]Prefix, len(s.prefixes))
-		copy(newSys.prefixes, s.prefixes)
+		dst.prefixes = make([]Prefix, len(s.prefixes))
+		copy(dst.prefixes, s.prefixes)
+	}
+	prefixKey := func(key string) string {
+		if k, ok := prefixKeys[key]; ok {
+			return k
+		}
+		return key
 	}
 
-	// 4. Copy Bindings (Deep Copy)
+	// 3. Bindings (Deep Copy)
 	for uKey, pSet := range s.unitPrefixes {
-		newSet := make(map[string]bool)
+		newSet := make(map[string]bool, len(pSet))
 		for pKey, allowed := range pSet {
-			newSet[pKey] = allowed
+			newSet[prefixKey(pKey)] = allowed
 		}
-		newSys.unitPrefixes[uKey] = newSet
+		dst.unitPrefixes[unitKeys[uKey]] = newSet
+	}
+	for pKey := range s.universalPrefixes {
+		dst.universalPrefixes[prefixKey(pKey)] = true
 	}
 
-	return newSys
+	// 4. Aliases
+	for k, a := range s.aliases {
+		if rekey {
+			k = dst.normalizeKey(a.symbol)
+			if _, _, ok := dst.lookupUnit(a.symbol); ok {
+				return fmt.Errorf("alias %s collides with %s", a.symbol, rekeyed)
+			}
+		}
+		dst.aliases[k] = unitAlias{symbol: a.symbol, unit: unitKeys[a.unit]}
+	}
+
+	// 5. Display Symbols
+	for k, sym := range s.displaySymbols {
+		dst.displaySymbols[unitKeys[k]] = sym
+	}
+
+	// 6. Preferred Units
+	for dim, symbol := range s.preferredUnits {
+		dst.preferredUnits[dim] = symbol
+	}
+
+	// 7. Constraints
+	dst.constraints = append([]Constraint(nil), s.constraints...)
+
+	// 8. Base Units
+	for dim, symbol := range s.bases {
+		if dst.bases == nil {
+			dst.bases = make(map[Dimension]string, len(s.bases))
+		}
+		dst.bases[dim] = symbol
+	}
+
+	// 9. Deprecations
+	for k, d := range s.deprecated {
+		if rekey {
+			key, ok := dst.deprecationKey(d.symbol)
+			if !ok {
+				continue
+			}
+			k = key
+		}
+		if dst.deprecated == nil {
+			dst.deprecated = make(map[string]deprecation, len(s.deprecated))
+		}
+		dst.deprecated[k] = d
+	}
+
+	return nil
+}
+
+// SetDisplaySymbol marks a spelling of a unit, i.e. its own symbol or one of its aliases
+// (see AddAlias), as the one to display it in, e.g. "µs" for a unit registered as "us".
+// Other units are not affected, even if they share scale and dimension.
+func (s *System) SetDisplaySymbol(symbol string) error {
+	if err := s.checkMutable("set display symbol " + symbol); err != nil {
+		return err
+	}
+	key, spelling, ok := s.spellingOf(symbol)
+	if !ok {
+		return fmt.Errorf("cannot set display symbol to unknown unit: %s", symbol)
+	}
+	s.displaySymbols[key] = spelling
+	return nil
+}
+
+// DisplaySymbol returns the symbol used to display a unit: the spelling marked with
+// SetDisplaySymbol if any, otherwise the unit's registered symbol.
+// Unknown symbols are returned unchanged.
+func (s *System) DisplaySymbol(symbol string) string {
+	key, u, ok := s.lookupUnit(symbol)
+	if !ok {
+		return symbol
+	}
+	if display, ok := s.displaySymbols[key]; ok {
+		return display
+	}
+	return u.Symbol
+}
+
+// spellingOf returns the registry key of the unit symbol spells and symbol as registered,
+// i.e. the unit's own symbol or the alias. Other spellings (plurals, long names) are
+// returned as written.
+func (s *System) spellingOf(symbol string) (string, string, bool) {
+	key, u, ok := s.lookupUnit(symbol)
+	if !ok {
+		return "", "", false
+	}
+	if k, _, ok := s.lookupSymbol(symbol); ok && k == key {
+		return key, u.Symbol, true
+	}
+	if a, ok := s.aliases[s.normalizeKey(symbol)]; ok && a.unit == key {
+		return key, a.symbol, true
+	}
+	return key, symbol, true
+}
+
+// SetPrefixCase overrides the System-wide CaseInsensitive setting for a prefix, e.g.
+// to accept "kib" for "KiB" in a case-sensitive System:
+//
+//	sys.SetPrefixCase("Ki", false)
+func (s *System) SetPrefixCase(symbol string, caseSensitive bool) error {
+	if err := s.checkMutable("set case of prefix " + symbol); err != nil {
+		return err
+	}
+	pKey := s.normalizeKey(symbol)
+	for i, p := range s.prefixes {
+		if s.normalizeKey(p.Symbol) == pKey {
+			s.prefixes[i].CaseSensitive, s.prefixes[i].CaseInsensitive = caseSensitive, !caseSensitive
+			return nil
+		}
+	}
+	return fmt.Errorf("prefix %s not found", symbol)
+}
+
+// prefixMatches reports whether text spells the i-th prefix, honoring its case override.
+func (s *System) prefixMatches(i int, text string) bool {
+	p := s.prefixes[i]
+	switch {
+	case p.CaseSensitive:
+		return s.normalizeInput(text) == s.normalizeInput(p.Symbol)
+	case p.CaseInsensitive:
+		return strings.ToLower(s.normalizeInput(text)) == strings.ToLower(s.normalizeInput(p.Symbol))
+	}
+	return s.normalizeKey(text) == s.prefixKey(i)
 }
 
 // OverwritePrefix updates the scale of an existing prefix.
 func (s *System) OverwritePrefix(symbol string, newScale float64) error {
+	if err := s.checkMutable("overwrite prefix " + symbol); err != nil {
+		return err
+	}
 	pKey := s.normalizeKey(symbol)
 
 	for i, p := range s.prefixes {
-		if p.Symbol == pKey {
+		if s.normalizeKey(p.Symbol) == pKey {
 			// Update scale directly
 			s.prefixes[i].Scale = newScale
 			return nil
@@ -136,31 +703,74 @@ func (s *System) OverwritePrefix(symbol string, newScale float64) error {
 	return fmt.Errorf("prefix %s not found in system, use AddPrefix instead", symbol)
 }
 
-// Resolve attempts to resolve a symbol into a Unit and a scaling factor.
-func (s *System) Resolve(symbol string) (Unit, float64, bool) {
-	lookupSymbol := s.normalizeKey(symbol)
-
-	// 1. Exact Match Priority
-	if u, ok := s.units[lookupSymbol]; ok {
-		return u, 1.0, true
+// Remove unregisters a unit with its prefix bindings and aliases, e.g. to strip units
+// from a Clone of a standard System. Removing an alias leaves its unit registered.
+func (s *System) Remove(symbol string) error {
+	if err := s.checkMutable("remove unit " + symbol); err != nil {
+		return err
+	}
+	uKey, _, ok := s.lookupSymbol(symbol)
+	if !ok {
+		if a, isAlias := s.aliases[s.normalizeKey(symbol)]; isAlias {
+			delete(s.aliases, s.normalizeKey(symbol))
+			if s.displaySymbols[a.unit] == a.symbol {
+				delete(s.displaySymbols, a.unit)
+			}
+			return nil
+		}
+		return fmt.Errorf("unit %s not found in system", symbol)
 	}
+	delete(s.units, uKey)
+	delete(s.unitPrefixes, uKey)
+	for k, a := range s.aliases {
+		if a.unit == uKey {
+			delete(s.aliases, k)
+		}
+	}
+	delete(s.displaySymbols, uKey)
+	return nil
+}
 
-	// 2. Prefix + Unit Match
-	for _, p := range s.prefixes {
-		pLen := len(p.Symbol)
-		if len(lookupSymbol) > pLen && lookupSymbol[:pLen] == p.Symbol {
-			baseSymbol := lookupSymbol[pLen:]
-
-			// Check if the remainder is a valid unit
-			if u, ok := s.units[baseSymbol]; ok {
-				// Check if the prefix is allowed for this unit (Whitelist check)
-				allowedPrefixes, hasList := s.unitPrefixes[baseSymbol]
-				if hasList && allowedPrefixes[p.Symbol] {
-					return u, p.Scale, true
-				}
+// RemovePrefix unregisters a prefix and unbinds it from all units.
+func (s *System) RemovePrefix(symbol string) error {
+	if err := s.checkMutable("remove prefix " + symbol); err != nil {
+		return err
+	}
+	pKey := s.normalizeKey(symbol)
+	for i, p := range s.prefixes {
+		if s.normalizeKey(p.Symbol) == pKey {
+			s.prefixes = append(s.prefixes[:i], s.prefixes[i+1:]...)
+			for _, bound := range s.unitPrefixes {
+				delete(bound, pKey)
 			}
+			delete(s.universalPrefixes, pKey)
+			return nil
 		}
 	}
+	return fmt.Errorf("prefix %s not found in system", symbol)
+}
 
-	return Unit{}, 0, false
+// UnbindPrefix stops a prefix from applying to a unit; both stay registered, so the
+// prefix still applies to its other units. This also excludes units from a universal prefix.
+func (s *System) UnbindPrefix(prefixSymbol, unitSymbol string) error {
+	if err := s.checkMutable("unbind prefix " + prefixSymbol); err != nil {
+		return err
+	}
+	uKey, _, ok := s.lookupUnit(unitSymbol)
+	if !ok {
+		return fmt.Errorf("unit %s not found in system", unitSymbol)
+	}
+	pKey := s.normalizeKey(prefixSymbol)
+	if !s.prefixAllowed(uKey, pKey) {
+		return fmt.Errorf("prefix %s is not bound to unit %s", prefixSymbol, unitSymbol)
+	}
+	if !s.universalPrefixes[pKey] {
+		delete(s.unitPrefixes[uKey], pKey)
+		return nil
+	}
+	if s.unitPrefixes[uKey] == nil {
+		s.unitPrefixes[uKey] = make(map[string]bool)
+	}
+	s.unitPrefixes[uKey][pKey] = false
+	return nil
 }
@@ -1,6 +1,10 @@
 package unit_test
 
 import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/armourstill/str2quantity/unit"
@@ -81,6 +85,46 @@ func TestSystem_CloneAndOverwrite(t *testing.T) {
 	}
 }
 
+func TestSystem_CloneWith(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true})
+	sys.Add("B", 1.0, unit.DimStorage)
+	sys.Add("b", 0.125, unit.DimStorage)
+	sys.Add("m", 1.0, unit.DimLength)
+	sys.AddPrefix("Ki", 1024, "B")
+
+	single, err := sys.CloneWith(func(c *unit.SystemConfig) { c.AllowMultiPart = false })
+	if err != nil {
+		t.Fatalf("CloneWith(AllowMultiPart=false) error = %v", err)
+	}
+	if single.Config.AllowMultiPart || !sys.Config.AllowMultiPart {
+		t.Errorf("CloneWith config: clone=%t original=%t, want false/true", single.Config.AllowMultiPart, sys.Config.AllowMultiPart)
+	}
+	if _, scale, ok := single.Resolve("KiB"); !ok || scale != 1024 {
+		t.Errorf("clone Resolve(KiB) = %g, %t, want 1024, true", scale, ok)
+	}
+
+	// "B" and "b" collide once case is ignored.
+	if _, err := sys.CloneWith(func(c *unit.SystemConfig) { c.CaseInsensitive = true }); err == nil || !strings.Contains(err.Error(), "CaseInsensitive=true") {
+		t.Errorf("CloneWith(CaseInsensitive=true) with B/b error = %v, want a collision naming CaseInsensitive", err)
+	}
+
+	lengths := unit.NewSystem(unit.SystemConfig{})
+	lengths.Add("m", 1.0, unit.DimLength)
+	lengths.AddPrefix("k", 1000, "m")
+	folded, err := lengths.CloneWith(func(c *unit.SystemConfig) { c.CaseInsensitive = true })
+	if err != nil {
+		t.Fatalf("CloneWith(CaseInsensitive=true) error = %v", err)
+	}
+	for _, in := range []string{"km", "KM", "Km"} {
+		if _, scale, ok := folded.Resolve(in); !ok || scale != 1000 {
+			t.Errorf("folded Resolve(%q) = %g, %t, want 1000, true", in, scale, ok)
+		}
+	}
+	if _, _, ok := lengths.Resolve("KM"); ok {
+		t.Error("original Resolve(KM) succeeded, want case-sensitive original")
+	}
+}
+
 func TestSystem_CaseInsensitive(t *testing.T) {
 	sys := unit.NewSystem(unit.SystemConfig{CaseInsensitive: true})
 	sys.Add("m", 1.0, unit.DimLength)
@@ -94,3 +138,1292 @@ func TestSystem_CaseInsensitive(t *testing.T) {
 		}
 	}
 }
+
+func TestSystem_CaseSensitiveOverride(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{CaseInsensitive: true})
+	sys.Add("b", 1.0, unit.DimStorage, unit.WithCaseSensitive())
+	sys.Add("B", 8.0, unit.DimStorage, unit.WithCaseSensitive())
+	sys.Add("bit", 1.0, unit.DimStorage)
+	if err := sys.AddPrefix("k", 1024, "b", "B", "bit"); err != nil {
+		t.Fatalf("failed to add prefix: %v", err)
+	}
+
+	tests := []struct {
+		input     string
+		wantScale float64
+		found     bool
+	}{
+		{"b", 1, true},
+		{"B", 8, true},
+		{"BIT", 1, true},   // Folded
+		{"kb", 1024, true}, // Folded prefix, exact unit
+		{"Kb", 1024, true},
+		{"KB", 8192, true},
+		{"kBit", 1024, true},
+	}
+
+	for _, tt := range tests {
+		u, prefixScale, found := sys.Resolve(tt.input)
+		if found != tt.found {
+			t.Errorf("Resolve(%q) found = %v, want %v", tt.input, found, tt.found)
+			continue
+		}
+		if got := prefixScale * u.Scale; found && got != tt.wantScale {
+			t.Errorf("Resolve(%q) scale = %g, want %g", tt.input, got, tt.wantScale)
+		}
+	}
+}
+
+func TestSystem_ResolveSuperscript(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("m", 1.0, unit.DimLength)
+	sys.Add("s", 1.0, unit.DimTime)
+	sys.Add("B", 8.0, unit.DimStorage)
+	sys.AddPrefix("c", 0.01, "m")
+
+	tests := []struct {
+		input     string
+		wantScale float64
+		wantDim   unit.Dimension
+		found     bool
+	}{
+		{"m²", 1, unit.Dimension{L: 2}, true},
+		{"cm³", 1e-6, unit.Dimension{L: 3}, true},
+		{"s⁻¹", 1, unit.Dimension{T: -1}, true},
+		{"s⁻", 0, unit.Dimension{}, false}, // Missing digits
+		{"²", 0, unit.Dimension{}, false},  // Missing base
+		{"m⁰", 0, unit.Dimension{}, false}, // Zero exponent
+		{"B²", 0, unit.Dimension{}, false}, // Extra dimensions have no exponents
+	}
+
+	for _, tt := range tests {
+		u, prefixScale, found := sys.Resolve(tt.input)
+		if found != tt.found {
+			t.Errorf("Resolve(%q) found = %v, want %v", tt.input, found, tt.found)
+			continue
+		}
+		if !found {
+			continue
+		}
+		if got := prefixScale * u.Scale; math.Abs(got-tt.wantScale) > 1e-18 {
+			t.Errorf("Resolve(%q) scale = %g, want %g", tt.input, got, tt.wantScale)
+		}
+		if !u.Dimension.Equals(tt.wantDim) {
+			t.Errorf("Resolve(%q) dimension = %s, want %s", tt.input, u.Dimension, tt.wantDim)
+		}
+	}
+}
+
+func TestSystem_AddComposite(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("m", 1.0, unit.DimLength)
+	sys.Add("g", 0.001, unit.DimMass)
+	sys.Add("L", 0.001, unit.Dimension{L: 3})
+	sys.Add("h", 3600, unit.DimTime)
+	sys.Add("s", 1, unit.DimTime)
+	sys.Add("N", 1, unit.Dimension{M: 1, L: 1, T: -2})
+	sys.Add("B", 8, unit.DimStorage)
+	sys.AddPrefix("k", 1000, "m", "g")
+	sys.AddPrefix("m", 0.001, "g")
+	sys.AddPrefix("d", 0.1, "L")
+
+	tests := []struct {
+		symbol    string
+		wantScale float64
+		wantDim   unit.Dimension
+	}{
+		{"km/h", 1000.0 / 3600, unit.Dimension{L: 1, T: -1}},
+		{"mg/dL", 1e-6 / 1e-4, unit.Dimension{M: 1, L: -3}},
+		{"N·m", 1, unit.Dimension{M: 1, L: 2, T: -2}},
+		{"kg*m/s²", 1, unit.Dimension{M: 1, L: 1, T: -2}},
+		{"B/s", 8, unit.Dimension{T: -1, Extra: "storage"}},
+	}
+
+	for _, tt := range tests {
+		if err := sys.AddComposite(tt.symbol); err != nil {
+			t.Errorf("AddComposite(%q) unexpected error: %v", tt.symbol, err)
+			continue
+		}
+		u, prefixScale, found := sys.Resolve(tt.symbol)
+		if !found {
+			t.Errorf("Resolve(%q) not found after AddComposite", tt.symbol)
+			continue
+		}
+		if got := prefixScale * u.Scale; math.Abs(got-tt.wantScale) > 1e-12 {
+			t.Errorf("Resolve(%q) scale = %g, want %g", tt.symbol, got, tt.wantScale)
+		}
+		if !u.Dimension.Equals(tt.wantDim) {
+			t.Errorf("Resolve(%q) dimension = %s, want %s", tt.symbol, u.Dimension, tt.wantDim)
+		}
+	}
+
+	for _, bad := range []string{"km/x", "m//s", "s/B", "B*B"} {
+		if err := sys.AddComposite(bad); err == nil {
+			t.Errorf("AddComposite(%q) expected error, got nil", bad)
+		}
+	}
+}
+
+func TestSystem_AddDerived(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("m", 1.0, unit.DimLength)
+	sys.Add("g", 0.001, unit.DimMass)
+	sys.Add("s", 1, unit.DimTime)
+	sys.AddPrefix("k", 1000, "m", "g")
+	sys.AddPrefix("d", 0.1, "m")
+
+	derived := []struct {
+		symbol, expr string
+		wantScale    float64
+		wantDim      unit.Dimension
+	}{
+		{"N", "kg*m/s^2", 1, unit.Dimension{M: 1, L: 1, T: -2}},
+		{"J", "N*m", 1, unit.Dimension{M: 1, L: 2, T: -2}},
+		{"Pa", "N/m²", 1, unit.Dimension{M: 1, L: -1, T: -2}},
+		{"L", "dm^3", 1e-3, unit.Dimension{L: 3}},
+	}
+	for _, tt := range derived {
+		if err := sys.AddDerived(tt.symbol, tt.expr); err != nil {
+			t.Fatalf("AddDerived(%q, %q) error: %v", tt.symbol, tt.expr, err)
+		}
+		u, _, found := sys.Resolve(tt.symbol)
+		if !found || math.Abs(u.Scale-tt.wantScale) > 1e-15 || !u.Dimension.Equals(tt.wantDim) {
+			t.Errorf("Resolve(%q) = %g %s, %t, want %g %s", tt.symbol, u.Scale, u.Dimension, found, tt.wantScale, tt.wantDim)
+		}
+	}
+
+	if err := sys.AddDerived("W", "J/x"); err == nil {
+		t.Error("AddDerived(W, J/x) expected error for unknown unit, got nil")
+	}
+	if _, _, found := sys.Resolve("W"); found {
+		t.Error("Resolve(W) found after failed AddDerived")
+	}
+}
+
+func TestSystem_ResolveCompound(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("m", 1.0, unit.DimLength)
+	sys.Add("g", 0.001, unit.DimMass)
+	sys.Add("s", 1, unit.DimTime)
+	sys.Add("h", 3600, unit.DimTime)
+	sys.Add("W", 1, unit.Dimension{M: 1, L: 2, T: -3})
+	sys.Add("B", 8, unit.DimStorage)
+	sys.AddPrefix("k", 1000, "m", "g", "W")
+
+	tests := []struct {
+		symbol    string
+		wantScale float64
+		wantDim   unit.Dimension
+	}{
+		{"m/s", 1, unit.Dimension{L: 1, T: -1}},
+		{"km/h", 1000.0 / 3600, unit.Dimension{L: 1, T: -1}},
+		{"kg*m/s^2", 1, unit.Dimension{M: 1, L: 1, T: -2}},
+		{"kg·m·s^-2", 1, unit.Dimension{M: 1, L: 1, T: -2}},
+		{"kW/m^2", 1000, unit.Dimension{M: 1, T: -3}},
+		{"W/m²", 1, unit.Dimension{M: 1, T: -3}},
+		{"m^3", 1, unit.Dimension{L: 3}},
+		{"B/s", 8, unit.Dimension{T: -1, Extra: "storage"}},
+	}
+	for _, tt := range tests {
+		u, prefixScale, found := sys.Resolve(tt.symbol)
+		if !found {
+			t.Errorf("Resolve(%q) not found", tt.symbol)
+			continue
+		}
+		if got := prefixScale * u.Scale; math.Abs(got-tt.wantScale) > 1e-12 {
+			t.Errorf("Resolve(%q) scale = %g, want %g", tt.symbol, got, tt.wantScale)
+		}
+		if !u.Dimension.Equals(tt.wantDim) {
+			t.Errorf("Resolve(%q) dimension = %s, want %s", tt.symbol, u.Dimension, tt.wantDim)
+		}
+	}
+
+	for _, bad := range []string{"m/x", "m//s", "m/", "s^", "s^0", "s^x", "B^2", "s/B"} {
+		if _, _, found := sys.Resolve(bad); found {
+			t.Errorf("Resolve(%q) found, want not found", bad)
+		}
+	}
+	if got := len(sys.Units()); got != 6 {
+		t.Errorf("len(Units()) = %d after resolving compounds, want 6 (nothing registered)", got)
+	}
+}
+
+func TestSystem_DisplaySymbol(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{CaseInsensitive: true})
+	sys.Add("us", 1e3, unit.DimTime)
+	sys.AddAlias("µs", "us")
+	sys.Add("ms", 1e6, unit.DimTime)
+	sys.Add("B", 8, unit.DimStorage, unit.WithCaseSensitive())
+	sys.AddAlias("Byte", "B")
+	sys.Add("o", 8, unit.DimStorage) // Octet: same scale as B, but a unit of its own
+
+	// No display symbol set: the registered symbol is used.
+	if got := sys.DisplaySymbol("us"); got != "us" {
+		t.Errorf("DisplaySymbol(us) = %q, want %q", got, "us")
+	}
+
+	if err := sys.SetDisplaySymbol("µs"); err != nil {
+		t.Fatalf("SetDisplaySymbol failed: %v", err)
+	}
+	if err := sys.SetDisplaySymbol("B"); err != nil {
+		t.Fatalf("SetDisplaySymbol failed: %v", err)
+	}
+	if err := sys.SetDisplaySymbol("x"); err == nil {
+		t.Error("SetDisplaySymbol(x) expected error for unknown unit")
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"us", "µs"},
+		{"US", "µs"},
+		{"µs", "µs"},
+		{"ms", "ms"},
+		{"bytes", "bytes"},
+		{"BYTE", "B"},
+		{"o", "o"},
+		{"x", "x"}, // Unknown
+	}
+	for _, tt := range tests {
+		if got := sys.DisplaySymbol(tt.input); got != tt.want {
+			t.Errorf("DisplaySymbol(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	// Display symbols survive Clone.
+	if got := sys.Clone().DisplaySymbol("us"); got != "µs" {
+		t.Errorf("Clone().DisplaySymbol(us) = %q, want %q", got, "µs")
+	}
+}
+
+func TestSystem_ResolveFull(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{CaseInsensitive: true})
+	sys.Add("Bytes", 8, unit.DimStorage)
+	sys.Add("m", 1, unit.DimLength)
+	sys.AddPrefix("Ki", 1024, "Bytes")
+	sys.AddPrefix("c", 0.01, "m")
+
+	tests := []struct {
+		input      string
+		wantUnit   string
+		wantPrefix string
+		wantAlias  string
+		wantExp    int
+		wantScale  float64
+	}{
+		{"bytes", "Bytes", "", "bytes", 1, 8},
+		{"KIBYTES", "Bytes", "Ki", "BYTES", 1, 8192},
+		{"cm²", "m", "c", "m", 2, 1e-4},
+	}
+
+	for _, tt := range tests {
+		r, found := sys.ResolveFull(tt.input)
+		if !found {
+			t.Errorf("ResolveFull(%q) not found", tt.input)
+			continue
+		}
+		if r.Unit.Symbol != tt.wantUnit || r.Prefix.Symbol != tt.wantPrefix || r.Alias != tt.wantAlias || r.Exponent != tt.wantExp {
+			t.Errorf("ResolveFull(%q) = unit %q, prefix %q, alias %q, exp %d; want %q, %q, %q, %d",
+				tt.input, r.Unit.Symbol, r.Prefix.Symbol, r.Alias, r.Exponent,
+				tt.wantUnit, tt.wantPrefix, tt.wantAlias, tt.wantExp)
+		}
+		if math.Abs(r.Scale()-tt.wantScale) > 1e-15 {
+			t.Errorf("ResolveFull(%q).Scale() = %g, want %g", tt.input, r.Scale(), tt.wantScale)
+		}
+	}
+
+	if _, found := sys.ResolveFull("x"); found {
+		t.Error("ResolveFull(x) found, want not found")
+	}
+}
+
+func TestSystem_DisplayUnits(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("B", 8, unit.DimStorage)
+	sys.AddAlias("Byte", "B")
+	sys.AddAlias("Bytes", "B")
+	sys.Add("o", 8, unit.DimStorage)
+	sys.AddPrefix("Ki", 1024, "B")
+
+	var got []string
+	for _, d := range sys.DisplayUnits() {
+		got = append(got, d.Symbol)
+	}
+	// One entry per unit (aliases are not listed), plus its prefixed forms.
+	want := []string{"B", "o", "KiB"}
+	if !slices.Equal(got, want) {
+		t.Errorf("DisplayUnits() = %v, want %v", got, want)
+	}
+
+	sys.SetDisplaySymbol("Bytes")
+	got = got[:0]
+	for _, d := range sys.DisplayUnits() {
+		got = append(got, d.Symbol)
+	}
+	want = []string{"o", "Bytes", "KiBytes"}
+	if !slices.Equal(got, want) {
+		t.Errorf("DisplayUnits() after SetDisplaySymbol(Bytes) = %v, want %v", got, want)
+	}
+}
+
+func TestSystem_Rebase(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("b", 1, unit.DimStorage)
+	sys.Add("B", 8, unit.DimStorage)
+	sys.AddAlias("Byte", "B")
+	sys.Add("ns", 1, unit.DimTime)
+	sys.Add("s", 1e9, unit.DimTime)
+	sys.AddPrefix("Ki", 1024, "B")
+	if err := sys.AddComposite("B/s"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sys.SetDisplaySymbol("Byte"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sys.Rebase("B"); err != nil {
+		t.Fatalf("Rebase(B) error = %v", err)
+	}
+
+	tests := []struct {
+		symbol string
+		want   float64
+	}{
+		{"B", 1},
+		{"b", 0.125},
+		{"KiB", 1024},
+		{"B/s", 1e-9}, // Bytes per ns
+		{"s", 1e9},    // other dimensions keep their scale
+	}
+	for _, tt := range tests {
+		u, prefixScale, ok := sys.Resolve(tt.symbol)
+		if !ok || u.Scale*prefixScale != tt.want {
+			t.Errorf("after Rebase(B): Resolve(%q) scale = %g, want %g", tt.symbol, u.Scale*prefixScale, tt.want)
+		}
+	}
+	if got := sys.DisplaySymbol("B"); got != "Byte" {
+		t.Errorf("after Rebase(B): DisplaySymbol(B) = %q, want %q", got, "Byte")
+	}
+
+	// Decimal scales divide exactly.
+	if err := sys.Rebase("s"); err != nil {
+		t.Fatalf("Rebase(s) error = %v", err)
+	}
+	if u, _, _ := sys.Resolve("ns"); u.Scale != 1e-9 {
+		t.Errorf("after Rebase(s): ns scale = %v, want 1e-9", u.Scale)
+	}
+	if u, _, _ := sys.Resolve("B/s"); u.Scale != 1 {
+		t.Errorf("after Rebase(s): B/s scale = %v, want 1", u.Scale)
+	}
+
+	for _, symbol := range []string{"xyz", "B/s"} {
+		if err := sys.Rebase(symbol); err == nil {
+			t.Errorf("Rebase(%q) expected error", symbol)
+		}
+	}
+}
+
+func TestSystem_Fingerprint(t *testing.T) {
+	build := func(order []string) *unit.System {
+		sys := unit.NewSystem(unit.SystemConfig{AllowMultiPart: true})
+		for _, sym := range order {
+			sys.Add(sym, map[string]float64{"s": 1, "m": 60, "h": 3600}[sym], unit.DimTime)
+		}
+		sys.AddPrefix("m", 1e-3, "s")
+		return sys
+	}
+
+	a, b := build([]string{"s", "m", "h"}), build([]string{"h", "m", "s"})
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Error("Fingerprint depends on registration order")
+	}
+	if a.Fingerprint() != a.Clone().Fingerprint() {
+		t.Error("Fingerprint of a Clone differs")
+	}
+
+	changes := map[string]func(*unit.System){
+		"unit scale": func(s *unit.System) { s.Add("h", 3601, unit.DimTime) },
+		"new unit":   func(s *unit.System) { s.Add("d", 86400, unit.DimTime) },
+		"prefix":     func(s *unit.System) { s.OverwritePrefix("m", 1e-6) },
+		"binding":    func(s *unit.System) { s.AddPrefix("m", 1e-3, "h") },
+		"config":     func(s *unit.System) { s.Config.AllowMultiPart = false },
+		"display":    func(s *unit.System) { s.SetDisplaySymbol("m") },
+		"preferred":  func(s *unit.System) { s.SetPreferredUnit(unit.DimTime, "m") },
+	}
+	for name, change := range changes {
+		sys := build([]string{"s", "m", "h"})
+		change(sys)
+		if sys.Fingerprint() == a.Fingerprint() {
+			t.Errorf("Fingerprint unchanged after changing the %s", name)
+		}
+	}
+}
+
+func TestSystem_Remove(t *testing.T) {
+	base := unit.NewSystem(unit.SystemConfig{})
+	base.Add("B", 8, unit.DimStorage)
+	base.Add("b", 1, unit.DimStorage)
+	base.Add("Byte", 8, unit.DimStorage)
+	base.SetDisplaySymbol("B")
+	if err := base.AddPrefix("k", 1024, "B", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := base.AddPrefix("K", 1024, "B", "b"); err != nil {
+		t.Fatal(err)
+	}
+
+	sys := base.Clone()
+	if err := sys.RemovePrefix("k"); err != nil {
+		t.Fatalf("RemovePrefix(k) error: %v", err)
+	}
+	if err := sys.UnbindPrefix("K", "b"); err != nil {
+		t.Fatalf("UnbindPrefix(K, b) error: %v", err)
+	}
+	if err := sys.Remove("B"); err != nil {
+		t.Fatalf("Remove(B) error: %v", err)
+	}
+
+	for symbol, want := range map[string]bool{"kB": false, "kb": false, "Kb": false, "B": false, "KB": false, "b": true, "Byte": true} {
+		if _, _, found := sys.Resolve(symbol); found != want {
+			t.Errorf("Resolve(%q) after removal found = %t, want %t", symbol, found, want)
+		}
+	}
+	if got := sys.DisplaySymbol("Byte"); got != "Byte" {
+		t.Errorf("DisplaySymbol(Byte) after Remove(B) = %q, want Byte", got)
+	}
+	if _, ok := sys.LookupPrefix("k"); ok {
+		t.Error("LookupPrefix(k) found a removed prefix")
+	}
+
+	// The original is untouched.
+	for _, symbol := range []string{"kB", "kb", "Kb", "B"} {
+		if _, _, found := base.Resolve(symbol); !found {
+			t.Errorf("original Resolve(%q) not found after removal from clone", symbol)
+		}
+	}
+
+	if err := sys.Remove("B"); err == nil {
+		t.Error("Remove(B) twice expected error")
+	}
+	if err := sys.RemovePrefix("k"); err == nil {
+		t.Error("RemovePrefix(k) twice expected error")
+	}
+	if err := sys.UnbindPrefix("K", "b"); err == nil {
+		t.Error("UnbindPrefix(K, b) twice expected error")
+	}
+}
+
+func TestSystem_Enumerate(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("s", 1, unit.DimTime)
+	sys.Add("h", 3600, unit.DimTime)
+	sys.Add("m", 1, unit.DimLength)
+	sys.AddPrefix("k", 1000, "m")
+	sys.AddPrefix("m", 0.001, "m", "s")
+
+	var units []string
+	for _, u := range sys.Units() {
+		units = append(units, u.Symbol)
+	}
+	// Grouped by dimension (in Dimension.String order), then by scale.
+	if want := []string{"s", "h", "m"}; !slices.Equal(units, want) {
+		t.Errorf("Units() = %v, want %v", units, want)
+	}
+
+	symbols := func(prefixes []unit.Prefix) []string {
+		var out []string
+		for _, p := range prefixes {
+			out = append(out, p.Symbol)
+		}
+		return out
+	}
+	if got, want := symbols(sys.Prefixes()), []string{"m", "k"}; !slices.Equal(got, want) {
+		t.Errorf("Prefixes() = %v, want %v", got, want)
+	}
+	if got, want := symbols(sys.Bindings("m")), []string{"m", "k"}; !slices.Equal(got, want) {
+		t.Errorf("Bindings(m) = %v, want %v", got, want)
+	}
+	if got, want := symbols(sys.Bindings("s")), []string{"m"}; !slices.Equal(got, want) {
+		t.Errorf("Bindings(s) = %v, want %v", got, want)
+	}
+	if got := sys.Bindings("h"); got != nil {
+		t.Errorf("Bindings(h) = %v, want none", got)
+	}
+	if got := sys.Bindings("parsec"); got != nil {
+		t.Errorf("Bindings(parsec) = %v, want nil", got)
+	}
+}
+
+func TestSystem_Merge(t *testing.T) {
+	newLength := func() *unit.System {
+		sys := unit.NewSystem(unit.SystemConfig{})
+		sys.Add("m", 1, unit.DimLength)
+		sys.AddPrefix("k", 1000, "m")
+		return sys
+	}
+	mass := unit.NewSystem(unit.SystemConfig{})
+	mass.Add("g", 0.001, unit.DimMass)
+	mass.AddPrefix("k", 1000, "g")
+	mass.AddPrefix("m", 0.001, "g")
+
+	physics := newLength()
+	if err := physics.Merge(mass, unit.ConflictError); err != nil {
+		t.Fatalf("Merge(mass) error: %v", err)
+	}
+	for symbol, want := range map[string]float64{"km": 1000, "kg": 1, "mg": 1e-6} {
+		u, scale, found := physics.Resolve(symbol)
+		if !found || u.Scale*scale != want {
+			t.Errorf("Resolve(%q) = %v * %v (found %t), want scale %v", symbol, u.Scale, scale, found, want)
+		}
+	}
+	if _, _, found := physics.Resolve("mm"); found {
+		t.Error("Resolve(mm) found: merged prefix m must only bind to g")
+	}
+
+	// "m" means minute here and "k" a different scale.
+	clock := unit.NewSystem(unit.SystemConfig{})
+	clock.Add("s", 1, unit.DimTime)
+	clock.Add("m", 60, unit.DimTime)
+	clock.AddPrefix("k", 1024, "s")
+
+	sys := newLength()
+	if err := sys.Merge(clock, unit.ConflictError); err == nil {
+		t.Error("Merge with ConflictError expected error")
+	}
+	if _, _, found := sys.Resolve("s"); found {
+		t.Error("failed Merge modified the receiver")
+	}
+
+	if err := sys.Merge(clock, unit.ConflictKeep); err != nil {
+		t.Fatalf("Merge with ConflictKeep error: %v", err)
+	}
+	if u, scale, _ := sys.Resolve("km"); u.Dimension != unit.DimLength || scale != 1000 {
+		t.Errorf("ConflictKeep: km = %v * %v, want length * 1000", u.Dimension, scale)
+	}
+	if _, _, found := sys.Resolve("ks"); found {
+		t.Error("ConflictKeep: skipped prefix k was bound to s")
+	}
+
+	sys = newLength()
+	if err := sys.Merge(clock, unit.ConflictOverwrite); err != nil {
+		t.Fatalf("Merge with ConflictOverwrite error: %v", err)
+	}
+	if u, _, _ := sys.Resolve("m"); u.Dimension != unit.DimTime {
+		t.Errorf("ConflictOverwrite: m dimension = %v, want time", u.Dimension)
+	}
+	if _, _, found := sys.Resolve("km"); found {
+		t.Error("ConflictOverwrite: overwritten unit m kept its bindings")
+	}
+	if _, scale, _ := sys.Resolve("ks"); scale != 1024 {
+		t.Errorf("ConflictOverwrite: ks scale = %v, want 1024", scale)
+	}
+}
+
+func TestSystem_Freeze(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{CaseInsensitive: true})
+	sys.Add("B", 8, unit.DimStorage)
+	sys.AddPrefix("Ki", 1024, "B")
+
+	frozen := sys.Freeze()
+	if !frozen.Frozen() || sys.Frozen() {
+		t.Fatalf("Frozen() = %t, original %t; want true, false", frozen.Frozen(), sys.Frozen())
+	}
+	if _, scale, found := frozen.Resolve("kib"); !found || scale != 1024 {
+		t.Errorf("frozen Resolve(kib) = %v (found %t), want 1024", scale, found)
+	}
+
+	mutations := map[string]func() error{
+		"Add":              func() error { return frozen.Add("b", 1, unit.DimStorage) },
+		"AddPrefix":        func() error { return frozen.AddPrefix("Mi", 1<<20, "B") },
+		"AddComposite":     func() error { return frozen.AddComposite("B*B") },
+		"AddConstraint":    func() error { return frozen.AddConstraint(unit.MinValue(0)) },
+		"Deprecate":        func() error { return frozen.Deprecate("KiB", "B") },
+		"SetBase":          func() error { return frozen.SetBase(unit.DimStorage, "B") },
+		"OverwritePrefix":  func() error { return frozen.OverwritePrefix("Ki", 1000) },
+		"SetDisplaySymbol": func() error { return frozen.SetDisplaySymbol("B") },
+		"Remove":           func() error { return frozen.Remove("B") },
+		"RemovePrefix":     func() error { return frozen.RemovePrefix("Ki") },
+		"UnbindPrefix":     func() error { return frozen.UnbindPrefix("Ki", "B") },
+		"Merge":            func() error { return frozen.Merge(sys, unit.ConflictOverwrite) },
+		"Rebase":           func() error { return frozen.Rebase("B") },
+	}
+	for name, mutate := range mutations {
+		if err := mutate(); !errors.Is(err, unit.ErrFrozen) {
+			t.Errorf("%s on frozen System error = %v, want ErrFrozen", name, err)
+		}
+	}
+	if _, scale, _ := frozen.Resolve("KiB"); scale != 1024 {
+		t.Errorf("frozen KiB scale = %v after rejected mutations, want 1024", scale)
+	}
+
+	// Later changes to the original do not leak into the snapshot.
+	sys.Add("bit", 1, unit.DimStorage)
+	if _, _, found := frozen.Resolve("bit"); found {
+		t.Error("unit added to the original is visible in the frozen snapshot")
+	}
+
+	clone := frozen.Clone()
+	if clone.Frozen() {
+		t.Error("Clone of a frozen System is frozen")
+	}
+	if err := clone.Add("bit", 1, unit.DimStorage); err != nil {
+		t.Errorf("Add on Clone of a frozen System error: %v", err)
+	}
+}
+
+func TestSystem_View(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("second", 1, unit.DimTime)
+
+	if view := sys.View(sys.Config); view != sys {
+		t.Error("View with an unchanged config is not the System itself")
+	}
+	config := sys.Config
+	config.AutoPlural = true
+	config.CaseInsensitive = true // fixed at registration, ignored
+	view := sys.View(config)
+	if _, _, found := view.Resolve("seconds"); !found {
+		t.Error("view with AutoPlural does not resolve seconds")
+	}
+	if _, _, found := view.Resolve("SECOND"); found {
+		t.Error("view resolves SECOND, want CaseInsensitive kept from the System")
+	}
+	if _, _, found := sys.Resolve("seconds"); found {
+		t.Error("View changed the System")
+	}
+	if err := view.Add("minute", 60, unit.DimTime); !errors.Is(err, unit.ErrFrozen) {
+		t.Errorf("Add on view error = %v, want ErrFrozen", err)
+	}
+}
+
+func TestSystem_AddAlias(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{CaseInsensitive: true})
+	sys.Add("B", 8, unit.DimStorage, unit.WithCaseSensitive())
+	sys.Add("b", 1, unit.DimStorage, unit.WithCaseSensitive())
+	if err := sys.AddAlias("Byte", "B"); err != nil {
+		t.Fatalf("AddAlias(Byte, B) error: %v", err)
+	}
+	if err := sys.AddAlias("Bytes", "byte"); err != nil {
+		t.Fatalf("AddAlias(Bytes, byte) error: %v", err)
+	}
+	// Bindings added after the alias apply to it as well.
+	sys.AddPrefix("Ki", 1024, "B")
+
+	for _, symbol := range []string{"BYTES", "KiByte", "kibytes"} {
+		u, _, found := sys.Resolve(symbol)
+		if !found || u.Symbol != "B" {
+			t.Errorf("Resolve(%q) = %q (found %t), want unit B", symbol, u.Symbol, found)
+		}
+	}
+	if got := len(sys.Units()); got != 2 {
+		t.Errorf("len(Units()) = %d, want 2: aliases are not separate units", got)
+	}
+	if got, want := sys.Aliases("Byte"), []string{"Byte", "Bytes"}; !slices.Equal(got, want) {
+		t.Errorf("Aliases(Byte) = %v, want %v", got, want)
+	}
+
+	for _, tt := range []struct{ alias, canonical string }{
+		{"bit", "bogus"}, // unknown unit
+		{"", "b"},        // empty alias
+		{"b", "B"},       // existing unit
+		{"byte", "b"},    // alias of another unit
+	} {
+		if err := sys.AddAlias(tt.alias, tt.canonical); err == nil {
+			t.Errorf("AddAlias(%q, %q) expected error", tt.alias, tt.canonical)
+		}
+	}
+	if err := sys.AddAlias("BYTE", "B"); err != nil {
+		t.Errorf("re-adding alias BYTE of B error: %v", err)
+	}
+
+	clone := sys.Clone()
+	if err := clone.Remove("Bytes"); err != nil {
+		t.Fatalf("Remove(Bytes) error: %v", err)
+	}
+	if _, _, found := clone.Resolve("Bytes"); found {
+		t.Error("removed alias Bytes still resolves")
+	}
+	if _, _, found := clone.Resolve("Byte"); !found {
+		t.Error("removing alias Bytes removed its unit")
+	}
+	if err := clone.Remove("B"); err != nil {
+		t.Fatalf("Remove(B) error: %v", err)
+	}
+	if _, _, found := clone.Resolve("Byte"); found {
+		t.Error("alias Byte still resolves after removing its unit")
+	}
+	if _, _, found := sys.Resolve("Bytes"); !found {
+		t.Error("Remove on a clone removed the original's alias")
+	}
+}
+
+func TestSystem_PluralsAndLongNames(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{AutoPlural: true, LongNames: true})
+	sys.Add("s", 1, unit.DimTime)
+	sys.Add("m", 60, unit.DimTime) // minute
+	sys.Add("inch", 0.0254, unit.DimLength)
+	sys.Add("g", 0.001, unit.DimMass)
+	sys.Add("B", 8, unit.DimStorage)
+	sys.AddAlias("byte", "B")
+	sys.AddPrefix("k", 1000, "g", "s")
+	sys.AddPrefix("Ki", 1024, "B")
+
+	tests := []struct {
+		symbol string
+		unit   string
+		prefix float64
+	}{
+		{"inches", "inch", 1},
+		{"bytes", "B", 1},
+		{"seconds", "s", 1},
+		{"Minute", "m", 1},
+		{"grammes", "g", 1},
+		{"kilograms", "g", 1000},
+		{"kibibytes", "B", 1024},
+	}
+	for _, tt := range tests {
+		r, found := sys.ResolveFull(tt.symbol)
+		if !found || r.Unit.Symbol != tt.unit || r.PrefixScale() != tt.prefix {
+			t.Errorf("ResolveFull(%q) = %v * %q (found %t), want %v * %q", tt.symbol, r.PrefixScale(), r.Unit.Symbol, found, tt.prefix, tt.unit)
+		}
+	}
+
+	for _, symbol := range []string{
+		"ms",       // abbreviations take no plural
+		"Bs",       // same through aliases
+		"meter",    // "m" is a minute here
+		"kiloinch", // k is not bound to inch
+	} {
+		if _, _, found := sys.Resolve(symbol); found {
+			t.Errorf("Resolve(%q) found, want not found", symbol)
+		}
+	}
+
+	plain := unit.NewSystem(unit.SystemConfig{})
+	plain.Add("inch", 0.0254, unit.DimLength)
+	plain.Add("s", 1, unit.DimTime)
+	for _, symbol := range []string{"inches", "second"} {
+		if _, _, found := plain.Resolve(symbol); found {
+			t.Errorf("Resolve(%q) found without AutoPlural and LongNames", symbol)
+		}
+	}
+}
+
+func TestSystem_AddWithMeta(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	meta := unit.UnitMeta{Name: "gibibyte", Description: "2^30 bytes", URL: "https://en.wikipedia.org/wiki/Gibibyte"}
+	if err := sys.AddWithMeta("GiB", 1<<30, unit.DimStorage, meta); err != nil {
+		t.Fatalf("AddWithMeta error: %v", err)
+	}
+	sys.Add("B", 1, unit.DimStorage)
+
+	u, _, found := sys.Resolve("GiB")
+	if !found || u.Meta != meta {
+		t.Errorf("Resolve(GiB).Meta = %+v, want %+v", u.Meta, meta)
+	}
+	if got := sys.Units()[1].Meta.Name; got != "gibibyte" {
+		t.Errorf("Units()[1].Meta.Name = %q, want gibibyte", got)
+	}
+	if got := u.Meta.PluralName(); got != "gibibytes" {
+		t.Errorf("PluralName() = %q, want gibibytes", got)
+	}
+	if got := (unit.UnitMeta{Name: "foot", Plural: "feet"}).PluralName(); got != "feet" {
+		t.Errorf("PluralName() = %q, want feet", got)
+	}
+	if got := (unit.UnitMeta{}).PluralName(); got != "" {
+		t.Errorf("PluralName() without Name = %q, want empty", got)
+	}
+}
+
+func TestSystem_AddUniversalPrefix(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("m", 1, unit.DimLength)
+	sys.Add("g", 0.001, unit.DimMass)
+	sys.Add("K", 1, unit.DimTemp)
+	if err := sys.AddUniversalPrefix("k", 1000); err != nil {
+		t.Fatalf("AddUniversalPrefix(k) error: %v", err)
+	}
+	sys.Add("s", 1, unit.DimTime) // added after the prefix
+	if err := sys.UnbindPrefix("k", "K"); err != nil {
+		t.Fatalf("UnbindPrefix(k, K) error: %v", err)
+	}
+
+	for symbol, want := range map[string]bool{"km": true, "kg": true, "ks": true, "kK": false} {
+		if _, _, found := sys.Resolve(symbol); found != want {
+			t.Errorf("Resolve(%q) found = %t, want %t", symbol, found, want)
+		}
+	}
+	if got := len(sys.Bindings("s")); got != 1 {
+		t.Errorf("len(Bindings(s)) = %d, want 1", got)
+	}
+	if err := sys.UnbindPrefix("k", "K"); err == nil {
+		t.Error("UnbindPrefix(k, K) twice expected error")
+	}
+
+	// Binding explicitly again lifts the exclusion.
+	if err := sys.AddPrefix("k", 1000, "K"); err != nil {
+		t.Fatalf("AddPrefix(k, K) error: %v", err)
+	}
+	if _, _, found := sys.Resolve("kK"); !found {
+		t.Error("Resolve(kK) not found after binding k again")
+	}
+
+	if err := sys.AddUniversalPrefix("k", 1024); err == nil {
+		t.Error("AddUniversalPrefix(k, 1024) expected scale conflict")
+	}
+	sys.RemovePrefix("k")
+	if _, _, found := sys.Resolve("ks"); found {
+		t.Error("Resolve(ks) found after RemovePrefix(k)")
+	}
+}
+
+func TestSystem_AddFunc(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	power := unit.Dimension{Extra: "power"}
+	sys.Add("W", 1, power)
+	err := sys.AddFunc("dBm",
+		func(v float64) float64 { return 1e-3 * math.Pow(10, v/10) },
+		func(w float64) float64 { return 10 * math.Log10(w/1e-3) },
+		power)
+	if err != nil {
+		t.Fatalf("AddFunc(dBm) error: %v", err)
+	}
+	sys.AddPrefix("m", 1e-3, "W", "dBm")
+
+	u, _, found := sys.Resolve("dBm")
+	if !found || u.Linear() {
+		t.Fatalf("Resolve(dBm) = %+v, %t, want a non-linear unit", u, found)
+	}
+	if got := u.ToBaseValue(30); math.Abs(got-1) > 1e-12 {
+		t.Errorf("ToBaseValue(30 dBm) = %v, want 1 W", got)
+	}
+	if got := u.FromBaseValue(0.01); math.Abs(got-10) > 1e-12 {
+		t.Errorf("FromBaseValue(10 mW) = %v, want 10 dBm", got)
+	}
+	if _, _, found := sys.Resolve("mdBm"); found {
+		t.Error("Resolve(mdBm) found, function units take no prefixes")
+	}
+	if w, _, _ := sys.Resolve("W"); !w.Linear() || w.ToBaseValue(2) != 2 {
+		t.Errorf("Resolve(W) = %+v, want a linear unit", w)
+	}
+
+	// Rebasing to milliwatts rescales the conversions, but not those of a clone.
+	watts := sys.Clone()
+	if err := sys.Rebase("mW"); err != nil {
+		t.Fatalf("Rebase(mW) error: %v", err)
+	}
+	if w, _, _ := watts.Resolve("dBm"); math.Abs(w.ToBaseValue(30)-1) > 1e-12 {
+		t.Errorf("clone ToBaseValue(30 dBm) after Rebase = %v, want 1 W", w.ToBaseValue(30))
+	}
+	u, _, _ = sys.Resolve("dBm")
+	if got := u.ToBaseValue(0); math.Abs(got-1) > 1e-12 {
+		t.Errorf("ToBaseValue(0 dBm) after Rebase = %v, want 1 mW", got)
+	}
+	if got := u.FromBaseValue(1000); math.Abs(got-30) > 1e-12 {
+		t.Errorf("FromBaseValue(1000 mW) after Rebase = %v, want 30 dBm", got)
+	}
+
+	if err := sys.AddFunc("dB", nil, math.Log10, unit.DimDimensionless); err == nil {
+		t.Error("AddFunc without toBase expected error, got nil")
+	}
+}
+
+func TestUnit_Comparable(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("s", 1, unit.DimTime, unit.WithConstraint(unit.MinValue(0)))
+	sys.AddFunc("dB", func(v float64) float64 { return math.Pow(10, v/10) }, func(r float64) float64 { return 10 * math.Log10(r) }, unit.DimDimensionless)
+
+	seen := make(map[unit.Unit]string)
+	for _, symbol := range []string{"s", "dB"} {
+		u, _, _ := sys.Resolve(symbol)
+		seen[u] = symbol
+	}
+	for _, symbol := range []string{"s", "dB"} {
+		if u, _, _ := sys.Resolve(symbol); seen[u] != symbol {
+			t.Errorf("map lookup of Resolve(%q) = %q, want %q", symbol, seen[u], symbol)
+		}
+	}
+
+	s, _, _ := sys.Resolve("s")
+	if len(s.Constraints()) != 1 || s.Check(-1) == nil {
+		t.Errorf("Resolve(s).Constraints() = %d constraints, want 1 rejecting -1", len(s.Constraints()))
+	}
+	if toBase, fromBase := s.Funcs(); toBase != nil || fromBase != nil {
+		t.Error("Resolve(s).Funcs() non-nil for a linear unit")
+	}
+	dB, _, _ := sys.Resolve("dB")
+	if toBase, fromBase := dB.Funcs(); toBase == nil || fromBase == nil {
+		t.Error("Resolve(dB).Funcs() nil for a function unit")
+	}
+}
+
+func TestSystem_Ambiguities(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("m", 60, unit.DimTime) // minute
+	sys.Add("min", 60, unit.DimTime)
+	sys.Add("s", 1, unit.DimTime)
+	sys.Add("ms", 1e-3, unit.DimTime)
+	sys.Add("in", 0.0254, unit.DimLength)
+	sys.AddPrefix("m", 1e-3, "s", "in")
+
+	got := sys.Ambiguities()
+	if len(got) != 1 || got[0].String() != "min: min | m+in" {
+		t.Fatalf("Ambiguities() = %v, want [min: min | m+in]", got)
+	}
+	if r := got[0].Readings[1]; r.Prefix.Symbol != "m" || r.Unit.Symbol != "in" {
+		t.Errorf("second reading = %+v, want m + in", r)
+	}
+	if _, ok := sys.Ambiguity("ms"); ok {
+		t.Error("Ambiguity(ms) found, but both readings mean the same")
+	}
+
+	// By default the exact unit wins; strict Systems refuse to guess.
+	if u, _, found := sys.Resolve("min"); !found || u.Symbol != "min" {
+		t.Errorf("Resolve(min) = %q, %t, want min", u.Symbol, found)
+	}
+	sys.Config.RejectAmbiguousUnits = true
+	if _, _, found := sys.Resolve("min"); found {
+		t.Error("Resolve(min) found with RejectAmbiguousUnits")
+	}
+	if _, _, found := sys.Resolve("ms"); !found {
+		t.Error("Resolve(ms) not found with RejectAmbiguousUnits")
+	}
+}
+
+func TestSystem_NormalizeUnicode(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{NormalizeUnicode: true})
+	sys.Add("s", 1, unit.DimTime)
+	sys.Add("Ω", 1, unit.Dimension{Extra: "resistance"}) // ohm sign U+2126
+	sys.Add("kg", 1, unit.DimMass)
+	sys.Add("°C", 1, unit.DimTemp)
+	sys.AddPrefix("µ", 1e-6, "s") // micro sign
+
+	tests := []struct {
+		input     string
+		wantScale float64
+	}{
+		{"µs", 1e-6},
+		{"μs", 1e-6}, // Greek mu
+		{"Ω", 1},     // Greek omega
+		{"ｋｇ", 1},
+		{"℃", 1},
+	}
+	for _, tt := range tests {
+		u, prefixScale, found := sys.Resolve(tt.input)
+		if !found || prefixScale*u.Scale != tt.wantScale {
+			t.Errorf("Resolve(%q) = %g, %t, want %g", tt.input, prefixScale*u.Scale, found, tt.wantScale)
+		}
+	}
+	if u, _, _ := sys.Resolve("Ω"); u.Symbol != "Ω" {
+		t.Errorf("Resolve(Greek omega).Symbol = %q, want the registered ohm sign", u.Symbol)
+	}
+
+	strict := unit.NewSystem(unit.SystemConfig{})
+	strict.Add("µs", 1e-6, unit.DimTime)
+	if _, _, found := strict.Resolve("μs"); found {
+		t.Error("Resolve(Greek mu) found without NormalizeUnicode")
+	}
+	normalized, err := strict.CloneWith(func(c *unit.SystemConfig) { c.NormalizeUnicode = true })
+	if err != nil {
+		t.Fatalf("CloneWith(NormalizeUnicode) error: %v", err)
+	}
+	if _, _, found := normalized.Resolve("μs"); !found {
+		t.Error("Resolve(Greek mu) not found after CloneWith(NormalizeUnicode)")
+	}
+
+	// Micro sign and Greek mu become one symbol once normalized.
+	strict.Add("μs", 1e-6, unit.DimTime)
+	_, err = strict.CloneWith(func(c *unit.SystemConfig) { c.NormalizeUnicode = true })
+	if err == nil || !strings.Contains(err.Error(), "NormalizeUnicode=true") || strings.Contains(err.Error(), "CaseInsensitive") {
+		t.Errorf("CloneWith(NormalizeUnicode) with µs/μs error = %v, want a collision naming only NormalizeUnicode", err)
+	}
+}
+
+func TestSystem_CaseOverrides(t *testing.T) {
+	// Case-sensitive System: "B" and "b" differ, but "KiB" may be written "kib".
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("b", 1, unit.DimStorage)
+	sys.Add("B", 8, unit.DimStorage)
+	sys.Add("Byte", 8, unit.DimStorage, unit.WithCaseInsensitive())
+	sys.AddPrefix("Ki", 1024, "B", "b", "Byte")
+	sys.AddPrefix("M", 1e6, "B")
+	if err := sys.SetPrefixCase("Ki", false); err != nil {
+		t.Fatalf("SetPrefixCase(Ki) error: %v", err)
+	}
+
+	tests := []struct {
+		input     string
+		wantScale float64
+		wantFound bool
+	}{
+		{"B", 8, true},
+		{"b", 1, true},
+		{"KiB", 8192, true},
+		{"kiB", 8192, true},
+		{"KIb", 1024, true},
+		{"BYTE", 8, true},
+		{"kibyte", 8192, true},
+		{"MB", 8e6, true},
+		{"mB", 0, false}, // "M" keeps the System's case sensitivity
+	}
+	for _, tt := range tests {
+		u, prefixScale, found := sys.Resolve(tt.input)
+		if found != tt.wantFound || (found && prefixScale*u.Scale != tt.wantScale) {
+			t.Errorf("Resolve(%q) = %g, %t, want %g, %t", tt.input, prefixScale*u.Scale, found, tt.wantScale, tt.wantFound)
+		}
+	}
+
+	// Case-insensitive System: a prefix can opt out as well.
+	ci := unit.NewSystem(unit.SystemConfig{CaseInsensitive: true})
+	ci.Add("m", 1, unit.DimLength)
+	ci.AddPrefix("M", 1e6, "m")
+	if err := ci.SetPrefixCase("M", true); err != nil {
+		t.Fatalf("SetPrefixCase(M) error: %v", err)
+	}
+	if _, prefixScale, found := ci.Resolve("MM"); !found || prefixScale != 1e6 {
+		t.Errorf("Resolve(MM) = %g, %t, want 1e6", prefixScale, found)
+	}
+	if _, _, found := ci.Resolve("mm"); found {
+		t.Error("Resolve(mm) found, but prefix M is case-sensitive")
+	}
+	if err := ci.SetPrefixCase("x", true); err == nil {
+		t.Error("SetPrefixCase(x) expected error for unknown prefix, got nil")
+	}
+}
+
+func TestSystem_Validate(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("m", 1, unit.DimLength)
+	sys.Add("km", 1000, unit.DimLength)
+	sys.Add("g", 0.001, unit.DimMass) // no base unit for mass
+	sys.Add("in", 0.0254, unit.DimLength)
+	sys.Add("min", 60, unit.DimTime)
+	sys.Add("s", 1, unit.DimTime)
+	sys.Add("m/s", 1, unit.Dimension{L: 1, T: -1})
+	sys.AddPrefix("m", 1e-3, "in") // "min" also reads as milli-inch
+	sys.AddPrefix("x", 10)         // bound to nothing
+
+	var got []string
+	for _, f := range sys.Validate() {
+		got = append(got, f.String())
+	}
+	want := []string{
+		"dimension L^0 M^1 T^0 I^0 K^0 N^0 J^0 has no unit with scale 1",
+		"ambiguous symbol min: min | m+in",
+		"prefix m is spelled like unit m",
+		"prefix x is bound to no unit",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Validate() =\n%q\nwant\n%q", got, want)
+	}
+
+	clean := unit.NewSystem(unit.SystemConfig{})
+	clean.Add("s", 1, unit.DimTime)
+	clean.AddPrefix("m", 1e-3, "s")
+	if findings := clean.Validate(); len(findings) != 0 {
+		t.Errorf("Validate() on a consistent System = %v, want none", findings)
+	}
+}
+
+func TestSystem_Deprecate(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{CaseInsensitive: true})
+	sys.Add("B", 1, unit.DimStorage)
+	sys.Add("s", 1, unit.DimTime)
+	sys.AddAlias("Byte", "B")
+	sys.AddPrefix("M", 1<<20, "B")
+	sys.AddPrefix("Mi", 1<<20, "B")
+	if err := sys.Deprecate("MB", "MiB"); err != nil {
+		t.Fatalf("Deprecate(MB) error: %v", err)
+	}
+	if err := sys.Deprecate("Byte", "B"); err != nil {
+		t.Fatalf("Deprecate(Byte) error: %v", err)
+	}
+
+	tests := []struct {
+		input string
+		want  string
+		found bool
+	}{
+		{"MB", "MiB", true},
+		{"mb", "MiB", true},
+		{"Byte", "B", true},
+		{"B", "", false},
+		{"MiB", "", false},
+		{"MByte", "", false},
+		{"x", "", false},
+	}
+	for _, tt := range tests {
+		got, found := sys.Deprecation(tt.input)
+		if got != tt.want || found != tt.found {
+			t.Errorf("Deprecation(%q) = %q, %t, want %q, %t", tt.input, got, found, tt.want, tt.found)
+		}
+	}
+	if _, _, found := sys.Resolve("MB"); !found {
+		t.Error("Resolve(MB) not found after Deprecate")
+	}
+
+	for _, bad := range [][2]string{{"x", "B"}, {"MB", "x"}, {"MB", "s"}} {
+		if err := sys.Deprecate(bad[0], bad[1]); err == nil {
+			t.Errorf("Deprecate(%q, %q) expected error, got nil", bad[0], bad[1])
+		}
+	}
+
+	clone := sys.Clone()
+	if _, found := clone.Deprecation("MB"); !found || clone.Fingerprint() != sys.Fingerprint() {
+		t.Error("Clone lost the deprecation of MB")
+	}
+	cs, err := sys.CloneWith(func(c *unit.SystemConfig) { c.CaseInsensitive = false })
+	if err != nil {
+		t.Fatalf("CloneWith error: %v", err)
+	}
+	if _, found := cs.Deprecation("MB"); !found {
+		t.Error("CloneWith lost the deprecation of MB")
+	}
+
+	plain := unit.NewSystem(unit.SystemConfig{CaseInsensitive: true})
+	before := plain.Fingerprint()
+	if err := plain.Merge(sys, unit.ConflictError); err != nil {
+		t.Fatalf("Merge error: %v", err)
+	}
+	if _, found := plain.Deprecation("MB"); !found || plain.Fingerprint() == before {
+		t.Error("Merge lost the deprecation of MB")
+	}
+}
+
+func TestSystem_SetBase(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("s", 1, unit.DimTime)
+	sys.Add("ms", 0.001, unit.DimTime)
+	sys.Add("m", 1, unit.DimLength)
+	sys.Add("m/s", 1, unit.Dimension{L: 1, T: -1})
+	sys.Add("degC", 1, unit.DimTemp, unit.WithOffset(273.15))
+	sys.AddPrefix("k", 1000, "m")
+
+	if err := sys.SetBase(unit.DimTime, "s"); err != nil {
+		t.Fatalf("SetBase(s) error: %v", err)
+	}
+	if base, ok := sys.Base(unit.DimTime); !ok || base != "s" {
+		t.Errorf("Base(time) = %q, %t, want s", base, ok)
+	}
+	if _, ok := sys.Base(unit.DimLength); ok {
+		t.Error("Base(length) found before SetBase")
+	}
+
+	bad := []struct {
+		dim    unit.Dimension
+		symbol string
+	}{
+		{unit.DimTime, "ms"},   // scale 0.001
+		{unit.DimTime, "m"},    // wrong dimension
+		{unit.DimLength, "km"}, // prefixed scale 1000
+		{unit.DimTemp, "degC"}, // not linear
+		{unit.DimTime, "x"},    // unknown
+	}
+	for _, tt := range bad {
+		if err := sys.SetBase(tt.dim, tt.symbol); err == nil {
+			t.Errorf("SetBase(%v, %q) expected error, got nil", tt.dim, tt.symbol)
+		}
+	}
+
+	// Rebasing moves the declaration and drops those of derived dimensions.
+	sys.SetBase(unit.DimLength, "m")
+	sys.SetBase(unit.Dimension{L: 1, T: -1}, "m/s")
+	if err := sys.Rebase("km"); err != nil {
+		t.Fatalf("Rebase(km) error: %v", err)
+	}
+	if base, _ := sys.Base(unit.DimLength); base != "km" {
+		t.Errorf("Base(length) after Rebase = %q, want km", base)
+	}
+	if _, ok := sys.Base(unit.Dimension{L: 1, T: -1}); ok {
+		t.Error("Base(velocity) kept after Rebase(km)")
+	}
+
+	// Validate reports declarations that no longer hold.
+	sys.Remove("s")
+	var found bool
+	for _, f := range sys.Validate() {
+		found = found || (f.Kind == unit.FindingNoBaseUnit && f.Symbol == unit.DimTime.String())
+	}
+	if !found {
+		t.Errorf("Validate() = %v, want a FindingNoBaseUnit for removed base unit s", sys.Validate())
+	}
+}
+
+func TestSystem_ResolveFuzzy(t *testing.T) {
+	sys := unit.NewSystem(unit.SystemConfig{})
+	sys.Add("s", 1, unit.DimTime)
+	sys.Add("sec", 1, unit.DimTime)
+	sys.Add("m", 1, unit.DimLength)
+	sys.Add("mètre", 1, unit.DimLength)
+	sys.Add("b", 1, unit.DimStorage)
+	sys.Add("B", 8, unit.DimStorage)
+	sys.AddPrefix("Ki", 1024, "b", "B")
+	sys.AddPrefix("k", 1000, "m")
+
+	tests := []struct {
+		input  string
+		symbol string
+		exact  bool
+		found  bool
+	}{
+		{"sec", "sec", true, true},
+		{"sec.", "sec", false, true},
+		{" Sec. ", "sec", false, true},
+		{"metre", "mètre", false, true},
+		{"METRE", "mètre", false, true},
+		{"KIB", "KiB", false, true},
+		{"kib", "Kib", false, true},
+		{"KM", "km", false, true},
+		{"x.", "", false, false},
+		{".", "", false, false},
+	}
+	for _, tt := range tests {
+		m, found := sys.ResolveFuzzy(tt.input)
+		if found != tt.found || m.Symbol != tt.symbol || m.Exact != tt.exact {
+			t.Errorf("ResolveFuzzy(%q) = %q, exact %t, found %t; want %q, %t, %t", tt.input, m.Symbol, m.Exact, found, tt.symbol, tt.exact, tt.found)
+		}
+	}
+	if _, _, found := sys.Resolve("sec."); found {
+		t.Error("Resolve(sec.) found: only ResolveFuzzy may guess")
+	}
+
+	r := sys.FuzzyResolver()
+	if u, scale, found := r.Resolve("KIB"); !found || u.Scale*scale != 8192 {
+		t.Errorf("FuzzyResolver().Resolve(KIB) = %v, %v, %t, want 8192", u.Scale, scale, found)
+	}
+}
+
+func TestSystem_ResolutionPolicy(t *testing.T) {
+	build := func(policy unit.ResolutionPolicy) *unit.System {
+		sys := unit.NewSystem(unit.SystemConfig{ResolutionPolicy: policy})
+		sys.Add("min", 60, unit.DimTime)
+		sys.Add("in", 0.0254, unit.DimLength)
+		sys.Add("m", 1, unit.DimLength)
+		sys.AddPrefix("m", 1e-3, "in")
+		sys.AddPrefix("k", 1e3, "min", "m")
+		sys.AddPrefix("km", 1e6, "in") // contrived, to split "kmin" two ways
+		return sys
+	}
+
+	tests := []struct {
+		policy unit.ResolutionPolicy
+		symbol string
+		prefix string
+		unit   string
+	}{
+		{unit.ResolveExactFirst, "min", "", "min"},
+		{unit.ResolveExactFirst, "kmin", "km", "in"},
+		{unit.ResolveLongestUnit, "min", "", "min"},
+		{unit.ResolveLongestUnit, "kmin", "k", "min"},
+		{unit.ResolvePrefixFirst, "min", "m", "in"},
+		{unit.ResolvePrefixFirst, "kmin", "km", "in"},
+		{unit.ResolvePrefixFirst, "m", "", "m"},
+	}
+	for _, tt := range tests {
+		r, found := build(tt.policy).ResolveFull(tt.symbol)
+		if !found || r.Prefix.Symbol != tt.prefix || r.Unit.Symbol != tt.unit {
+			t.Errorf("policy %d: ResolveFull(%q) = %q+%q (found %t), want %q+%q", tt.policy, tt.symbol, r.Prefix.Symbol, r.Unit.Symbol, found, tt.prefix, tt.unit)
+		}
+	}
+}
@@ -5,10 +5,142 @@ type Unit struct {
 	Symbol    string
 	Dimension Dimension
 	Scale     float64 // Scale relative to the base unit of the dimension (e.g. 1000 for km if base is m)
+
+	// Offset is added after scaling, in base units, for affine units such as degrees
+	// Celsius over kelvin: base = value*Scale + Offset (see WithOffset).
+	Offset float64
+
+	// CaseSensitive keeps the symbol matched exactly even when the System is case-insensitive.
+	CaseSensitive bool
+	// CaseInsensitive matches the symbol in any case even when the System is case-sensitive.
+	CaseInsensitive bool
+
+	// Meta documents the unit for formatters and help text; it does not affect parsing.
+	Meta UnitMeta
+
+	// ext holds the conversion functions and constraints, which are not comparable,
+	// so that Units can still be compared with == and used as map keys.
+	ext *unitExt
+}
+
+// unitExt is the part of a Unit set by AddFunc and WithConstraint. It is shared by
+// copies of the Unit and replaced, never modified, when the Unit changes.
+type unitExt struct {
+	toBase, fromBase func(float64) float64
+	constraints      []Constraint
+}
+
+// editExt gives u its own copy of its extension to modify.
+func (u *Unit) editExt() *unitExt {
+	ext := &unitExt{}
+	if u.ext != nil {
+		*ext = *u.ext
+		ext.constraints = ext.constraints[:len(ext.constraints):len(ext.constraints)]
+	}
+	u.ext = ext
+	return ext
+}
+
+// Funcs returns the functions converting values of a non-linear unit such as decibels
+// into and out of base units (see AddFunc), or nil for units defined by Scale and
+// Offset.
+func (u Unit) Funcs() (toBase, fromBase func(float64) float64) {
+	if u.ext == nil {
+		return nil, nil
+	}
+	return u.ext.toBase, u.ext.fromBase
+}
+
+// Constraints returns the constraints validating the values written in this unit
+// (see WithConstraint).
+func (u Unit) Constraints() []Constraint {
+	if u.ext == nil {
+		return nil
+	}
+	return u.ext.constraints
+}
+
+// Linear reports whether values in u are proportional to base units, so that they take
+// prefixes and add up across parts. Affine and function units are not linear.
+func (u Unit) Linear() bool {
+	toBase, _ := u.Funcs()
+	return u.Offset == 0 && toBase == nil
+}
+
+// ToBaseValue converts v written in u into base units.
+func (u Unit) ToBaseValue(v float64) float64 {
+	if toBase, _ := u.Funcs(); toBase != nil {
+		return toBase(v)
+	}
+	return v*u.Scale + u.Offset
+}
+
+// FromBaseValue converts v in base units into u, the inverse of ToBaseValue.
+func (u Unit) FromBaseValue(v float64) float64 {
+	if _, fromBase := u.Funcs(); fromBase != nil {
+		return fromBase(v)
+	}
+	return (v - u.Offset) / u.Scale
+}
+
+// UnitMeta is optional documentation of a unit, set with AddWithMeta or WithMeta.
+type UnitMeta struct {
+	Name        string // Long name, e.g. "gibibyte"
+	Plural      string // Plural of Name; empty means Name + "s"
+	Description string // e.g. "2^30 bytes"
+	URL         string // Reference for the definition
+}
+
+// PluralName returns Plural, or Name with an "s" appended if Plural is empty.
+func (m UnitMeta) PluralName() string {
+	if m.Plural != "" || m.Name == "" {
+		return m.Plural
+	}
+	return m.Name + "s"
+}
+
+// UnitOption configures a Unit at registration time.
+type UnitOption func(*Unit)
+
+// WithOffset makes the unit affine: a value v is v*Scale + offset in base units, e.g.
+// WithOffset(273.15) for degrees Celsius over kelvin. The parser rejects prefixes and
+// multi-part inputs on such units, since offsets do not add up.
+func WithOffset(offset float64) UnitOption {
+	return func(u *Unit) {
+		u.Offset = offset
+	}
+}
+
+// WithMeta attaches documentation to the unit.
+func WithMeta(meta UnitMeta) UnitOption {
+	return func(u *Unit) {
+		u.Meta = meta
+	}
+}
+
+// WithCaseSensitive opts the unit out of the System-wide CaseInsensitive setting,
+// so that e.g. "b" (bit) and "B" (Byte) can coexist in a case-insensitive System.
+func WithCaseSensitive() UnitOption {
+	return func(u *Unit) {
+		u.CaseSensitive = true
+	}
+}
+
+// WithCaseInsensitive is the converse of WithCaseSensitive: the unit is matched in any
+// case even when the System is case-sensitive. Its lowercase spelling must not be
+// registered as another unit.
+func WithCaseInsensitive() UnitOption {
+	return func(u *Unit) {
+		u.CaseInsensitive = true
+	}
 }
 
 // Prefix represents a unit prefix (e.g., "k" for kilo, "m" for milli).
 type Prefix struct {
 	Symbol string
 	Scale  float64
+
+	// CaseSensitive and CaseInsensitive override the System-wide CaseInsensitive
+	// setting for this prefix (see System.SetPrefixCase).
+	CaseSensitive, CaseInsensitive bool
 }
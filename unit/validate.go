@@ -0,0 +1,124 @@
+package unit
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FindingKind classifies a problem reported by Validate.
+type FindingKind int
+
+const (
+	// FindingNoBaseUnit: a base dimension has units, but none with Scale 1, or a base
+	// unit declared with SetBase no longer has Scale 1.
+	FindingNoBaseUnit FindingKind = iota
+	// FindingDanglingReference: a prefix binding, alias, display symbol or preferred unit
+	// refers to a unit or prefix that is no longer registered.
+	FindingDanglingReference
+	// FindingShadowedSymbol: a symbol has several meanings (see Ambiguity), and Resolve
+	// silently picks the exact unit.
+	FindingShadowedSymbol
+	// FindingPrefixIsUnit: a prefix is spelled like a unit, e.g. "m" for milli and meter.
+	FindingPrefixIsUnit
+	// FindingUnusedPrefix: a prefix is bound to no unit.
+	FindingUnusedPrefix
+)
+
+// Finding is a problem in a System definition reported by Validate.
+type Finding struct {
+	Kind    FindingKind
+	Symbol  string // the unit, prefix or dimension concerned
+	Message string
+}
+
+func (f Finding) String() string {
+	return f.Message
+}
+
+// Validate checks the System for likely mistakes in its definition, e.g. in a test of
+// a hand-written System. The findings are sorted by kind, then symbol; a consistent
+// System returns none. Some findings may be deliberate, such as a prefix "m" (milli)
+// next to the unit "m" (meter), so callers decide which kinds to fail on.
+func (s *System) Validate() []Finding {
+	var out []Finding
+	add := func(kind FindingKind, symbol, format string, args ...any) {
+		out = append(out, Finding{Kind: kind, Symbol: symbol, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// Base units of the base dimensions.
+	hasBase := make(map[Dimension]bool)
+	for _, u := range s.units {
+		if _, err := baseDimensionPower(u.Dimension); err != nil {
+			continue
+		}
+		hasBase[u.Dimension] = hasBase[u.Dimension] || (u.Scale == 1 && u.Linear())
+	}
+	for dim, ok := range hasBase {
+		if !ok {
+			add(FindingNoBaseUnit, dim.String(), "dimension %s has no unit with scale 1", dim)
+		}
+	}
+	for dim, symbol := range s.bases {
+		if r, ok := s.ResolveFull(symbol); !ok || !r.Dimension().Equals(dim) || !r.Unit.Linear() || r.Scale() != 1 {
+			add(FindingNoBaseUnit, dim.String(), "declared base unit %s of dimension %s does not have scale 1", symbol, dim)
+		}
+	}
+
+	// References to removed units and prefixes.
+	prefixKeys := make(map[string]bool, len(s.prefixes))
+	for _, p := range s.prefixes {
+		prefixKeys[s.normalizeKey(p.Symbol)] = true
+	}
+	bound := make(map[string]bool)
+	for uKey, pSet := range s.unitPrefixes {
+		if _, ok := s.units[uKey]; !ok {
+			add(FindingDanglingReference, uKey, "prefixes are bound to unknown unit %s", uKey)
+		}
+		for pKey, allowed := range pSet {
+			if !prefixKeys[pKey] {
+				add(FindingDanglingReference, pKey, "unknown prefix %s is bound to unit %s", pKey, uKey)
+			}
+			bound[pKey] = bound[pKey] || allowed
+		}
+	}
+	for _, a := range s.aliases {
+		if _, ok := s.units[a.unit]; !ok {
+			add(FindingDanglingReference, a.symbol, "alias %s refers to unknown unit %s", a.symbol, a.unit)
+		}
+	}
+	for key, symbol := range s.displaySymbols {
+		if k, _, ok := s.lookupUnit(symbol); !ok || k != key {
+			add(FindingDanglingReference, symbol, "display symbol %s does not spell unit %s", symbol, key)
+		}
+	}
+	for dim, symbol := range s.preferredUnits {
+		if r, ok := s.ResolveFull(symbol); !ok || !r.Dimension().Equals(dim) {
+			add(FindingDanglingReference, symbol, "preferred unit %s does not resolve to %s", symbol, dim)
+		}
+	}
+
+	// Symbols that hide one another.
+	for _, a := range s.Ambiguities() {
+		add(FindingShadowedSymbol, a.Symbol, "ambiguous symbol %s", a)
+	}
+	for _, p := range s.prefixes {
+		pKey := s.normalizeKey(p.Symbol)
+		if _, u, ok := s.lookupUnit(p.Symbol); ok {
+			add(FindingPrefixIsUnit, p.Symbol, "prefix %s is spelled like unit %s", p.Symbol, u.Symbol)
+		}
+		if !bound[pKey] && !s.universalPrefixes[pKey] {
+			add(FindingUnusedPrefix, p.Symbol, "prefix %s is bound to no unit", p.Symbol)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind < out[j].Kind
+		}
+		if out[i].Symbol != out[j].Symbol {
+			return out[i].Symbol < out[j].Symbol
+		}
+		return out[i].Message < out[j].Message
+	})
+	return out
+}
@@ -0,0 +1,135 @@
+package unit
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Watcher keeps a frozen System loaded from a definition file and reloads it when the
+// file changes, so operators can add units or aliases without redeploying. Parses in
+// flight keep the System they started with; later calls to System see the new one.
+// A file that fails to load leaves the previous System in place; replace the file
+// atomically (write a temporary file and rename it) so no poll sees it half written.
+//
+// Watcher is a Resolver, so it can be passed to the parser with parser.WithResolver.
+type Watcher struct {
+	path, format string
+	onReload     func(*System, error)
+	sys          atomic.Pointer[System]
+
+	mu      sync.Mutex // serializes reloads and guards modTime and size
+	modTime time.Time
+	size    int64
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+var _ Resolver = (*Watcher)(nil)
+
+// WatchSystem loads the definition file at path with LoadSystem and polls it every
+// interval for changes of its modification time or size. onReload, if not nil, is
+// called after every reload with the new System, or with the error that kept the
+// previous one. The initial load must succeed. Call Close to stop polling.
+func WatchSystem(path, format string, interval time.Duration, onReload func(*System, error)) (*Watcher, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("watch interval must be positive, got %v", interval)
+	}
+	w := &Watcher{
+		path:     path,
+		format:   format,
+		onReload: onReload,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if err := w.Reload(); err != nil {
+		return nil, err
+	}
+	go w.poll(interval)
+	return w, nil
+}
+
+// System returns the current frozen System.
+func (w *Watcher) System() *System {
+	return w.sys.Load()
+}
+
+// Resolve resolves symbol with the current System.
+func (w *Watcher) Resolve(symbol string) (Unit, float64, bool) {
+	return w.System().Resolve(symbol)
+}
+
+// Reload loads the definition file now, regardless of whether it changed, and swaps in
+// the new System on success. It does not call onReload.
+func (w *Watcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return err
+	}
+	return w.load(info)
+}
+
+// Close stops polling and waits for a reload in progress. It is safe to call more than
+// once and from several goroutines. The last loaded System stays available.
+func (w *Watcher) Close() {
+	w.stopOnce.Do(func() { close(w.stop) })
+	<-w.done
+}
+
+// poll reloads the file whenever its modification time or size changes.
+func (w *Watcher) poll(interval time.Duration) {
+	defer close(w.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if sys, changed, err := w.check(); changed && w.onReload != nil {
+				w.onReload(sys, err)
+			}
+		}
+	}
+}
+
+// check reloads the file if it changed since the last load.
+func (w *Watcher) check() (sys *System, changed bool, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return nil, true, err
+	}
+	if info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return nil, false, nil
+	}
+	if err := w.load(info); err != nil {
+		return nil, true, err
+	}
+	return w.System(), true, nil
+}
+
+// load builds and freezes the System in the file described by info. The file's
+// modification time and size are recorded even on failure, so a broken file is
+// reported once rather than on every poll.
+func (w *Watcher) load(info os.FileInfo) error {
+	w.modTime, w.size = info.ModTime(), info.Size()
+	f, err := os.Open(w.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	sys, err := LoadSystem(f, w.format)
+	if err != nil {
+		return fmt.Errorf("load %s: %w", w.path, err)
+	}
+	w.sys.Store(sys.Freeze())
+	return nil
+}
@@ -0,0 +1,104 @@
+package unit_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/armourstill/str2quantity/unit"
+)
+
+func TestWatchSystem(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "units.yaml")
+	write := func(src string) {
+		// Replace the file atomically, so no poll sees it half written.
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("units:\n  - {symbol: m, scale: 1, dimension: {L: 1}}\n")
+
+	reloads := make(chan error, 10)
+	w, err := unit.WatchSystem(path, "yaml", time.Millisecond, func(_ *unit.System, err error) { reloads <- err })
+	if err != nil {
+		t.Fatalf("WatchSystem failed: %v", err)
+	}
+	defer w.Close()
+	first := w.System()
+	if !first.Frozen() {
+		t.Error("watched System is not frozen")
+	}
+	if _, _, found := w.Resolve("ft"); found {
+		t.Fatal("ft resolved before it was defined")
+	}
+
+	write("units:\n  - {symbol: m, scale: 1, dimension: {L: 1}}\n  - {symbol: ft, scale: 0.3048, dimension: {L: 1}}\n")
+	if err := waitReload(t, reloads); err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	if _, _, found := w.Resolve("ft"); !found {
+		t.Error("ft not resolved after reload")
+	}
+	if _, _, found := first.Resolve("ft"); found {
+		t.Error("reload changed the previous System")
+	}
+
+	write("units: [")
+	if err := waitReload(t, reloads); err == nil {
+		t.Fatal("reload of a broken file expected error")
+	}
+	if _, _, found := w.Resolve("ft"); !found {
+		t.Error("broken file replaced the last good System")
+	}
+	if err := w.Reload(); err == nil {
+		t.Error("Reload of a broken file expected error")
+	}
+}
+
+func TestWatcher_ConcurrentClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "units.json")
+	if err := os.WriteFile(path, []byte(`{"units": [{"symbol": "m", "scale": 1, "dimension": {"L": 1}}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	w, err := unit.WatchSystem(path, "json", time.Millisecond, nil)
+	if err != nil {
+		t.Fatalf("WatchSystem failed: %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.Close()
+		}()
+	}
+	wg.Wait()
+	w.Close()
+}
+
+func TestWatchSystem_Errors(t *testing.T) {
+	if _, err := unit.WatchSystem(filepath.Join(t.TempDir(), "missing.yaml"), "yaml", time.Second, nil); err == nil {
+		t.Error("WatchSystem of a missing file expected error")
+	}
+	if _, err := unit.WatchSystem("units.yaml", "yaml", 0, nil); err == nil {
+		t.Error("WatchSystem with a zero interval expected error")
+	}
+}
+
+// waitReload waits for the next onReload call and returns its error.
+func waitReload(t *testing.T, reloads <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-reloads:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reload within 5s")
+		return nil
+	}
+}
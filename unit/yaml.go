@@ -0,0 +1,427 @@
+package unit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// decodeYAML decodes the subset of YAML needed for System definitions into the
+// values of encoding/json (map[string]any, []any, string, float64, bool and nil).
+// The subset is:
+//
+//   - a single document, optionally opened by "---" on its first line;
+//   - block mappings ("key: value") and block sequences ("- item"), indented with spaces;
+//   - flow sequences ("[a, b]") and flow mappings ("{L: 1}") that end on the line they start;
+//   - single-line plain, single-quoted and double-quoted scalars, where plain scalars
+//     read as null ("null", "~", empty), true, false, a finite number or a string;
+//   - comments starting with '#' at the start of a line or after a space.
+//
+// Anything else is rejected with the line and column where it starts: anchors ("&a"),
+// aliases ("*a"), tags ("!!str"), block scalars ("|", ">"), directives ("%YAML"),
+// explicit keys ("? "), merge keys ("<<"), further documents and multi-line flow
+// collections or quoted scalars.
+func decodeYAML(src string) (any, error) {
+	lines, err := splitYAMLLines(src)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return map[string]any{}, nil
+	}
+	p := &yamlParser{lines: lines}
+	v, err := p.block(lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(lines) {
+		return nil, p.errorf("unexpected indentation")
+	}
+	return v, nil
+}
+
+// yamlLine is a non-empty line without its comment and indentation.
+type yamlLine struct {
+	no     int
+	indent int
+	text   string
+}
+
+func splitYAMLLines(src string) ([]yamlLine, error) {
+	var lines []yamlLine
+	for i, raw := range strings.Split(src, "\n") {
+		text := strings.TrimRight(stripYAMLComment(raw), " \t\r")
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || (i == 0 && trimmed == "---") {
+			continue
+		}
+		indent := len(text) - len(trimmed)
+		if trimmed[0] == '\t' {
+			return nil, fmt.Errorf("yaml: line %d, column %d: tabs are not allowed in indentation", i+1, indent+1)
+		}
+		if isYAMLDocumentMarker(trimmed) {
+			return nil, fmt.Errorf("yaml: line %d, column %d: multiple documents are not supported", i+1, indent+1)
+		}
+		lines = append(lines, yamlLine{no: i + 1, indent: indent, text: trimmed})
+	}
+	return lines, nil
+}
+
+// isYAMLDocumentMarker reports whether text starts or ends a document ("---", "...").
+func isYAMLDocumentMarker(text string) bool {
+	for _, m := range []string{"---", "..."} {
+		if text == m || strings.HasPrefix(text, m+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// stripYAMLComment removes a '#' comment that is not inside a quoted scalar.
+func stripYAMLComment(s string) string {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return s[:i]
+		}
+	}
+	return s
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// errorf reports an error at the start of the current line.
+func (p *yamlParser) errorf(format string, args ...any) error {
+	l := p.lines[p.pos]
+	return yamlErrorf(l, 0, format, args...)
+}
+
+// yamlErrorf reports an error at byte off of the text of l.
+func yamlErrorf(l yamlLine, off int, format string, args ...any) error {
+	return fmt.Errorf("yaml: line %d, column %d: "+format, append([]any{l.no, l.indent + off + 1}, args...)...)
+}
+
+// flowError positions an error of parseYAMLFlow, which read the text of l from byte off.
+func flowError(l yamlLine, off int, err error) error {
+	if fe, ok := err.(*yamlFlowError); ok {
+		return yamlErrorf(l, off+fe.off, "%s", fe.msg)
+	}
+	return yamlErrorf(l, off, "%v", err)
+}
+
+// block parses the node starting at the current line, indented by indent.
+func (p *yamlParser) block(indent int) (any, error) {
+	l := p.lines[p.pos]
+	switch {
+	case isYAMLSeqItem(l.text):
+		return p.sequence(indent)
+	case yamlKeyEnd(l.text) >= 0:
+		return p.mapping(indent)
+	}
+	v, err := parseYAMLFlow(l.text)
+	if err != nil {
+		return nil, flowError(l, 0, err)
+	}
+	p.pos++
+	return v, nil
+}
+
+func (p *yamlParser) sequence(indent int) (any, error) {
+	out := []any{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isYAMLSeqItem(p.lines[p.pos].text) {
+		l := p.lines[p.pos]
+		rest := strings.TrimLeft(l.text[1:], " ")
+		var v any
+		var err error
+		if rest == "" {
+			p.pos++
+			if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+				v, err = p.block(p.lines[p.pos].indent)
+			}
+		} else {
+			// Parse the item content as if it started its own line, so "- key: v"
+			// continues with the keys aligned below it.
+			itemIndent := indent + len(l.text) - len(rest)
+			p.lines[p.pos] = yamlLine{no: l.no, indent: itemIndent, text: rest}
+			v, err = p.block(itemIndent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func (p *yamlParser) mapping(indent int) (any, error) {
+	out := map[string]any{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && !isYAMLSeqItem(p.lines[p.pos].text) {
+		l := p.lines[p.pos]
+		end := yamlKeyEnd(l.text)
+		if end < 0 {
+			return nil, p.errorf("expected \"key: value\", got %q", l.text)
+		}
+		rawKey := strings.TrimSpace(l.text[:end])
+		if rawKey == "<<" {
+			return nil, p.errorf("merge keys are not supported")
+		}
+		if err := checkYAMLPlain(rawKey); err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		key, err := parseYAMLScalar(rawKey)
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		k := fmt.Sprint(key)
+		if _, dup := out[k]; dup {
+			return nil, p.errorf("duplicate key %q", k)
+		}
+		rest := l.text[end+1:]
+		value := strings.TrimSpace(rest)
+		p.pos++
+
+		var v any
+		switch {
+		case value != "":
+			if v, err = parseYAMLFlow(value); err != nil {
+				return nil, flowError(l, end+1+len(rest)-len(strings.TrimLeft(rest, " ")), err)
+			}
+		case p.pos < len(p.lines) && (p.lines[p.pos].indent > indent ||
+			p.lines[p.pos].indent == indent && isYAMLSeqItem(p.lines[p.pos].text)):
+			if v, err = p.block(p.lines[p.pos].indent); err != nil {
+				return nil, err
+			}
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+func isYAMLSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// yamlKeyEnd returns the index of the ':' ending the mapping key of text, or -1.
+func yamlKeyEnd(text string) int {
+	i := 0
+	switch {
+	case text == "" || text[0] == '[' || text[0] == '{':
+		return -1
+	case text[0] == '"' || text[0] == '\'':
+		n, err := yamlQuotedLen(text)
+		if err != nil {
+			return -1
+		}
+		i = n
+	}
+	for ; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return i
+		}
+	}
+	return -1
+}
+
+// yamlQuotedLen returns the length of the quoted scalar at the start of s.
+func yamlQuotedLen(s string) (int, error) {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote == '"':
+			i++
+		case s[i] == quote && quote == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == quote:
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated quoted scalar %s", s)
+}
+
+// checkYAMLPlain rejects a plain scalar starting with an indicator of a construct
+// outside the supported subset (see decodeYAML).
+func checkYAMLPlain(s string) error {
+	if s == "" {
+		return nil
+	}
+	switch s[0] {
+	case '&':
+		return fmt.Errorf("anchors are not supported")
+	case '*':
+		return fmt.Errorf("aliases are not supported")
+	case '!':
+		return fmt.Errorf("tags are not supported")
+	case '|', '>':
+		return fmt.Errorf("block scalars are not supported")
+	case '%':
+		return fmt.Errorf("directives are not supported")
+	case '?':
+		return fmt.Errorf("explicit keys are not supported")
+	case '@', '`':
+		return fmt.Errorf("reserved indicator %q", s[0])
+	}
+	return nil
+}
+
+// parseYAMLFlow parses a scalar or a flow collection spanning all of s. Its errors
+// are *yamlFlowError.
+func parseYAMLFlow(s string) (any, error) {
+	f := yamlFlow{s: s, n: len(s)}
+	v, err := f.value("")
+	if err != nil {
+		return nil, err
+	}
+	f.skipSpace()
+	if f.s != "" {
+		return nil, f.errorf("unexpected %q", f.s)
+	}
+	return v, nil
+}
+
+// yamlFlowError is an error at byte off of the input of parseYAMLFlow.
+type yamlFlowError struct {
+	off int
+	msg string
+}
+
+func (e *yamlFlowError) Error() string { return e.msg }
+
+// yamlFlow parses flow collections; s is the remaining input of n bytes.
+type yamlFlow struct {
+	s string
+	n int
+}
+
+// errorf reports an error at the start of the remaining input.
+func (f *yamlFlow) errorf(format string, args ...any) error {
+	return f.errorAt(f.n-len(f.s), format, args...)
+}
+
+func (f *yamlFlow) errorAt(off int, format string, args ...any) error {
+	return &yamlFlowError{off: off, msg: fmt.Sprintf(format, args...)}
+}
+
+func (f *yamlFlow) skipSpace() {
+	f.s = strings.TrimLeft(f.s, " ")
+}
+
+// value parses a node; a plain scalar ends at any byte of stop.
+func (f *yamlFlow) value(stop string) (any, error) {
+	f.skipSpace()
+	start := f.n - len(f.s)
+	switch {
+	case strings.HasPrefix(f.s, "["):
+		return f.collection(']', func(out *[]any, _ map[string]any) error {
+			v, err := f.value(",]")
+			*out = append(*out, v)
+			return err
+		})
+	case strings.HasPrefix(f.s, "{"):
+		return f.collection('}', func(_ *[]any, out map[string]any) error {
+			key, err := f.value(":,}")
+			if err != nil {
+				return err
+			}
+			f.skipSpace()
+			if !strings.HasPrefix(f.s, ":") {
+				return f.errorf("missing ':' after key %v", key)
+			}
+			f.s = f.s[1:]
+			v, err := f.value(",}")
+			out[fmt.Sprint(key)] = v
+			return err
+		})
+	case strings.HasPrefix(f.s, `"`) || strings.HasPrefix(f.s, "'"):
+		n, err := yamlQuotedLen(f.s)
+		if err != nil {
+			return nil, f.errorf("%v", err)
+		}
+		v, err := parseYAMLScalar(f.s[:n])
+		if err != nil {
+			return nil, f.errorf("%v", err)
+		}
+		f.s = f.s[n:]
+		return v, nil
+	}
+	end := len(f.s)
+	if stop != "" {
+		if i := strings.IndexAny(f.s, stop); i >= 0 {
+			end = i
+		}
+	}
+	plain := strings.TrimSpace(f.s[:end])
+	if err := checkYAMLPlain(plain); err != nil {
+		return nil, f.errorAt(start, "%v", err)
+	}
+	v, err := parseYAMLScalar(plain)
+	if err != nil {
+		return nil, f.errorAt(start, "%v", err)
+	}
+	f.s = f.s[end:]
+	return v, nil
+}
+
+// collection parses "[...]" or "{...}", calling item for each entry.
+func (f *yamlFlow) collection(closing byte, item func(*[]any, map[string]any) error) (any, error) {
+	list, obj := []any{}, map[string]any{}
+	f.s = f.s[1:]
+	for {
+		f.skipSpace()
+		if f.s == "" {
+			return nil, f.errorf("missing %q; flow collections must end on their line", closing)
+		}
+		if f.s[0] == closing {
+			f.s = f.s[1:]
+			break
+		}
+		if err := item(&list, obj); err != nil {
+			return nil, err
+		}
+		f.skipSpace()
+		if strings.HasPrefix(f.s, ",") {
+			f.s = f.s[1:]
+		}
+	}
+	if closing == ']' {
+		return list, nil
+	}
+	return obj, nil
+}
+
+// parseYAMLScalar resolves a quoted or plain scalar.
+func parseYAMLScalar(s string) (any, error) {
+	if s == "" {
+		return nil, nil
+	}
+	switch s[0] {
+	case '"':
+		return strconv.Unquote(s)
+	case '\'':
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+	switch s {
+	case "null", "~":
+		return nil, nil
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	if v, err := strconv.ParseFloat(s, 64); err == nil && !math.IsInf(v, 0) && !math.IsNaN(v) {
+		return v, nil
+	}
+	return s, nil
+}